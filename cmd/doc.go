@@ -5,6 +5,7 @@
 // Main Functions:
 // - Execute: Runs the root command, handling errors by printing to stderr, displaying usage if appropriate, and exiting with a non-zero status via ExitFunc.
 // - SetVersionInfo: Sets the version string for the root command, incorporating build details like commit and date.
+// - versionOutput: Renders the --version output as prose or, with --version-format json, as a JSON object.
 //
 // Exported Variables:
 // - ExitFunc: A variable for the exit function (defaults to os.Exit), allowing mocking in tests.
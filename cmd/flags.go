@@ -17,6 +17,14 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 
 package cmd
 
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nicholas-fedor/touch/internal/timestamp"
+)
+
 // init initializes the root command by defining all supported flags.
 // Flags are bound using Cobra's flag definitions, mirroring GNU touch options.
 func init() {
@@ -27,23 +35,191 @@ func init() {
 	rootCmd.Flags().BoolP("access", "a", false, "change only the access time")
 	rootCmd.Flags().BoolP("modification", "m", false, "change only the modification time")
 	rootCmd.Flags().
-		String("time", "", "change the specified time: access, atime, use (like -a); modify, mtime (like -m)")
+		String("time", "", "change the specified time: access, atime, use (like -a); modify, mtime (like -m); birth")
 
 	// Flags for controlling file creation.
 	rootCmd.Flags().BoolP("no-create", "c", false, "do not create any files")
+	rootCmd.Flags().
+		Bool("if-exists", false, "only touch files that exist, skipping missing or vanished files without error (overrides -c)")
+	rootCmd.Flags().BoolP("parents", "p", false, "create missing parent directories of files that are created")
+	rootCmd.Flags().
+		Bool("clamp-new-to-now", false, "never give files that are created times later than the current time")
+	rootCmd.Flags().
+		Bool("monotonic-components", false, "never move an existing file's access or modification time backward")
+	rootCmd.Flags().
+		Bool("special", false, "touch FIFOs, sockets, and device files instead of reporting an error")
+	rootCmd.Flags().String("content", "", "write this content to files that are created")
+	rootCmd.Flags().
+		String("content-file", "", "write the contents of this file (- for stdin) to files that are created")
+	rootCmd.Flags().
+		String("exec", "", "run this command after touching each file, with {} replaced by the file name")
+	rootCmd.Flags().
+		String("audit-log", "", "append a tab-separated record of each changed file's old and new times to this file")
+	rootCmd.Flags().
+		String("manifest", "", "after processing, write each touched file's final times and SHA-256 content hash to this file")
+	rootCmd.Flags().
+		Bool("journal", false, "report each changed file's old and new times to the systemd journal (Linux; stderr elsewhere)")
+	rootCmd.Flags().
+		Bool("verbose", false, "print a line to stdout for each file that is touched")
+	rootCmd.Flags().
+		BoolP("quiet", "q", false, "do not print per-file error messages or warnings to stderr")
+	rootCmd.Flags().
+		Bool("summary", false, "print the numbers of files updated and failed to stderr at the end")
+	rootCmd.Flags().
+		Bool("histogram", false, "print a histogram of the final modification times to stderr at the end")
+	rootCmd.Flags().
+		Duration("histogram-bucket", time.Hour, "with --histogram, the width of each bucket, such as 1h or 24h")
+	rootCmd.Flags().
+		Bool("dry-run", false, "report what would be created or changed without modifying anything")
+	rootCmd.Flags().
+		Bool("diff", false, "with --dry-run, also show each existing file's current and new access and modification times")
+	rootCmd.Flags().
+		IntP("jobs", "j", 0, "touch at most this many files, or read this many comma-separated references, at once; 0 uses the number of CPUs")
+	rootCmd.Flags().
+		Bool("sequential", false, "touch files one at a time in argument order, for filesystems that mishandle concurrent updates; same as --jobs 1")
+	rootCmd.Flags().
+		Bool("atomic", false, "restore every file to its original times, removing any it created, if any file fails")
 
 	// Flags for symlink handling.
 	rootCmd.Flags().
-		BoolP("no-dereference", "h", false, "affect each symbolic link instead of any referenced file (unsupported on Windows)")
+		BoolP("no-dereference", "h", false, "affect each symbolic link instead of any referenced file")
+	rootCmd.Flags().
+		BoolP("recursive", "R", false, "touch directories and every file and subdirectory beneath them")
+	rootCmd.Flags().
+		Bool("no-glob", false, "do not expand *, ?, and [ in file operands that the shell left unexpanded; implied by -c")
+	rootCmd.Flags().
+		Bool("preserve-link-times", false, "keep each symbolic link's own times when touching the file it references")
 
 	// Ignored flag for compatibility.
 	rootCmd.Flags().Bool("f", false, "(ignored for compatibility)")
 
 	// Flags for specifying reference file or timestamps.
 	rootCmd.Flags().StringP("reference", "r", "", "use this file's times instead of current time")
+	rootCmd.Flags().String("reference-atime", "", "use this file's access time, with --reference-mtime")
+	rootCmd.Flags().String("reference-mtime", "", "use this file's modification time, with --reference-atime")
+	rootCmd.Flags().
+		String("reduce", "", "treat -r as comma-separated files and reduce their times: min, max, mean, median")
+	rootCmd.Flags().
+		Bool("prefer-birth", false, "with -r, use the reference's birth time instead of its modification time when available")
+	rootCmd.Flags().
+		Bool("swap", false, "with -r, use the reference's access time as the modification time and vice versa")
+	rootCmd.Flags().
+		String("reference-newest-under", "", "use the times of the newest entry anywhere under this directory")
+	rootCmd.Flags().
+		String("reference-newest-type", "", "with --dir, use the times of the newest file there whose sniffed content type is this, e.g. image/jpeg")
+	rootCmd.Flags().
+		String("dir", "", "directory searched by --reference-newest-type")
+	rootCmd.Flags().
+		String("reference-newest-atime", "", "use the times of the most recently accessed of these comma-separated files")
+	rootCmd.Flags().
+		String("reference-oldest-atime", "", "use the times of the least recently accessed of these comma-separated files")
+	rootCmd.Flags().
+		String("reference-max-change", "", "use the latest modification or change time of these comma-separated files")
+	rootCmd.Flags().
+		String("reference-oldest-ctime", "", "use the earliest change time of these comma-separated files")
+	rootCmd.Flags().
+		String("reference-glob", "", "with --reference-percentile or --reference-min-after, select among the modification times of files matching this glob")
+	rootCmd.Flags().
+		String("reference-percentile", "", "use this percentile, 0 to 100, of the --reference-glob modification times")
+	rootCmd.Flags().
+		String("reference-min-after", "", "use the earliest --reference-glob modification time later than this RFC3339 time")
+	rootCmd.Flags().
+		String("reference-mode-glob", "", "use the most common modification time of files matching this glob, ties going to the newest")
+	rootCmd.Flags().
+		String("reference-mount", "", "use the mount time of the filesystem containing this path (Linux only)")
+	rootCmd.Flags().
+		String("reference-ssh", "", "use the times of the remote file [user@]host:path, read with ssh and stat")
+	rootCmd.Flags().
+		Bool("reference-ancestor", false, "use the times of each file's nearest existing ancestor directory")
+	rootCmd.Flags().
+		Bool("normalize-symlink-times", false, "set each symlink's own times to those of its target, skipping other files")
+	rootCmd.Flags().
+		Bool("normalize-to-fs", false, "truncate each file's existing times to the timestamp granularity its filesystem stores")
+	rootCmd.Flags().
+		Bool("reference-boot", false, "use the approximate system boot time, now minus uptime (Linux only)")
+	rootCmd.Flags().
+		String("reference-proc-fds", "", "use the newest modification time among the files this PID has open (Linux only)")
+	rootCmd.Flags().
+		String("reference-unit", "", "use the time this systemd unit last became active, its ActiveEnterTimestamp (Linux only)")
+	rootCmd.Flags().
+		Bool("reference-self-atime", false, "use the access time of this program's executable")
+	rootCmd.Flags().
+		String("buildinfo", "", "use the BuildTime recorded in this key=value .buildinfo file")
+	rootCmd.Flags().
+		String("rsync-list", "", "use the newest time in this rsync --list-only listing")
+	rootCmd.Flags().
+		String("warc", "", "with --warc-target, use the WARC-Date of that URL's record in this WARC archive")
+	rootCmd.Flags().
+		String("warc-target", "", "target URL of the --warc record whose date is used")
+	rootCmd.Flags().
+		String("reference-oci", "", "use the created date of this Docker or OCI image tarball, as written by docker save")
+	rootCmd.Flags().
+		String("reference-image", "", "use the newest modification time among the members of this squashfs or erofs image, extracted with unsquashfs or fsck.erofs")
+	rootCmd.Flags().
+		String("lockfile", "", "with --lock-entry, use the time recorded for that package in this package-lock.json or other JSON lockfile")
+	rootCmd.Flags().
+		String("lock-entry", "", "package in the --lockfile whose recorded time is used")
+	rootCmd.Flags().
+		String("lock-path", timestamp.DefaultLockPath, "dot-separated path of the time field within the --lock-entry, such as meta.published")
+	rootCmd.Flags().
+		String("reference-git-newest", "", "use the time of the newest commit touching this path, or the whole repository if omitted")
+	rootCmd.Flags().Lookup("reference-git-newest").NoOptDefVal = timestamp.GitRepoRoot
+	rootCmd.Flags().
+		String("reference-seed", "", "use a time derived deterministically from the SHA-256 of this string")
+	rootCmd.Flags().
+		String("next-cron", "", "use the next occurrence of this 5-field cron expression, e.g. '0 * * * *'")
+	rootCmd.Flags().
+		String("seed-window", "", "START,END window for --reference-seed times (default "+timestamp.DefaultSeedWindow+")")
+	rootCmd.Flags().
+		Bool("size-time", false, "use a time mapped linearly from each file's size into --size-window, reaching its end at --size-max")
+	rootCmd.Flags().
+		String("size-window", "", "START,END window for --size-time times (default "+timestamp.DefaultSeedWindow+")")
+	rootCmd.Flags().Int64("size-max", 0, "file size in bytes that --size-time maps to the end of --size-window")
+	rootCmd.Flags().
+		String("stat-time", "", "use epoch seconds computed from each file's size, mode, and lines, such as '1700000000 + size'")
+	rootCmd.Flags().
+		String("jsonl-times", "", "apply per-file times from JSON Lines records read from this file (- for stdin)")
+	rootCmd.Flags().
+		String("files-from", "", "also touch the files named one per line in this file (- for stdin)")
+	rootCmd.Flags().
+		BoolP("null", "z", false, "with --files-from, separate file names with NUL bytes instead of newlines")
 	rootCmd.Flags().StringP("stamp", "t", "", "use [[CC]YY]MMDDhhmm[.ss] instead of current time")
 	rootCmd.Flags().StringP("date", "d", "", "parse ARG and use it instead of current time")
+	rootCmd.Flags().
+		String("format", "", "parse -d with this Go time layout, such as 02.01.2006, instead of the built-in formats")
+	rootCmd.Flags().
+		String("set-atime", "", "set only the access time, to this date or [[CC]YY]MMDDhhmm[.ss] stamp; with --set-mtime, set both independently")
+	rootCmd.Flags().
+		String("set-mtime", "", "set only the modification time, to this date or [[CC]YY]MMDDhhmm[.ss] stamp; with --set-atime, set both independently")
+	rootCmd.Flags().
+		Bool("utc", false, "interpret -t and -d values without an explicit offset as UTC instead of local time")
+	rootCmd.Flags().
+		String("timezone", "", "interpret -t and -d values without an explicit offset in this IANA time zone, such as America/New_York")
+	rootCmd.Flags().
+		String("adjust", "", "shift each file's existing times by this duration, such as +1h or -2 days")
+	rootCmd.Flags().
+		Bool("monotonic-now", false, "use a current time that strictly increases across touches in this process")
+	rootCmd.Flags().
+		String("truncate-to", "", "round the computed times down to the start of their day, hour, or minute")
+	rootCmd.Flags().
+		Bool("floor-to-dir", false, "never set times earlier than the containing directory's modification time")
+	rootCmd.Flags().
+		Bool("skip-network-fs", false, "skip files on network filesystems such as NFS, CIFS, and FUSE (Linux only)")
+	rootCmd.Flags().
+		String("only-older-than", "", "only touch existing files last modified longer ago than this, such as 36h or 7d")
+	rootCmd.Flags().
+		String("time-sidecars", "", "use the RFC3339 time in each file's sidecar with this suffix when present")
+	rootCmd.Flags().Lookup("time-sidecars").NoOptDefVal = ".time"
+	rootCmd.Flags().
+		Bool("uuid-time", false, "use the time embedded in each file's UUIDv1 or UUIDv7 name as its modification time")
+	rootCmd.Flags().
+		Bool("strict", false, "fail on malformed input, or on -h where symlink times can't be set, instead of warning and continuing")
 
 	// Enable version flag with shorthand.
 	rootCmd.Flags().BoolP("version", "v", false, "output version information and exit")
+	versionFormat := versionFormatValue(versionFormatText)
+	rootCmd.Flags().
+		Var(&versionFormat, "version-format", "format of --version output: text or json")
+	cobra.AddTemplateFunc("versionOutput", versionOutput)
+	rootCmd.SetVersionTemplate("{{versionOutput .}}")
 }
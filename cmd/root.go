@@ -20,17 +20,41 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nicholas-fedor/touch/internal/cli"
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/version"
+)
+
+// Output formats for --version, selected with --version-format.
+const (
+	versionFormatText = "text"
+	versionFormatJSON = "json"
+)
+
+// Exit statuses reported by Execute.
+const (
+	// ExitFailure is the status when touch fails outright, or when every file failed.
+	ExitFailure = 1
+	// ExitPartialFailure is the status when some files were touched and others failed.
+	ExitPartialFailure = 2
 )
 
 // ExitFunc is a variable for the exit function, allowing mocking in tests.
 var ExitFunc = os.Exit
 
+// versionInfo is the build information given to SetVersionInfo, printed by --version-format json.
+var versionInfo version.Info
+
 // rootCmd represents the base command when called without any subcommands.
 // It configures the "touch" command to mimic the behavior of the GNU touch utility,
 // allowing creation or timestamp updates for files with various options.
@@ -40,7 +64,10 @@ var rootCmd = &cobra.Command{
 	Long: `touch changes the access and/or modification times of the specified files.
 If a file does not exist, it is created empty unless -c or --no-create is specified.
 By default, the current time is used unless a specific time is provided via -d, -r, or -t.
-Supported date formats for -d include RFC3339, YYYY-MM-DDTHH:MM:SS, YYYY-MM-DD HH:MM:SS, YYYY-MM-DDTHH:MM, YYYY-MM-DD, HH:MM:SS, HH:MM.
+Supported date formats for -d include RFC3339, YYYY-MM-DD HH:MM:SS -0700, YYYY-MM-DDTHH:MM:SS, YYYY-MM-DD HH:MM:SS, YYYY-MM-DDTHH:MM, YYYY-MM-DD HH:MM, YYYY-MM-DD, HH:MM:SS, HH:MM,
+month-name dates such as "Jul 13 2025 14:30", "Jul 13 14:30" (current year), and "13 Jul 2025",
+12-hour times such as "07/13/2025 02:30 PM" and "2:30 PM", and ISO week dates such as
+"2025-W28-1" (the weekday defaults to Monday).
 
 Examples:
   touch file.txt                  # Create or update file.txt with current time
@@ -48,6 +75,9 @@ Examples:
   touch -d "2025-07-13 14:30" file.txt  # Set specific date and time
   touch -r ref.txt file.txt       # Use times from ref.txt
 
+Exit status is 0 if every file was touched, 1 if touch failed or every file failed,
+and 2 if some files were touched but others failed.
+
 For more details, see the GNU touch manual or use --help.`,
 	RunE:          cli.RunTouch, // Delegate to cli.RunTouch for execution logic, allowing separation from Cobra setup.
 	SilenceErrors: true,         // Prevent Cobra from printing errors automatically.
@@ -56,9 +86,27 @@ For more details, see the GNU touch manual or use --help.`,
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
-// It handles any errors by exiting with a non-zero status.
+// It handles any errors by exiting with a non-zero status, as chosen by exitCode.
+// An interrupt (Ctrl-C) cancels the run, which then reports how many files it completed;
+// a second interrupt exits immediately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Restore the default interrupt handling once cancelled, so a second Ctrl-C isn't ignored.
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		code := exitCode(err)
+
+		// Each file's error was already printed as it happened; only summarize them here.
+		if errors.Is(err, touchErrors.ErrProcessingFiles) {
+			err = touchErrors.ErrProcessingFiles
+		}
+
 		fmt.Fprintln(os.Stderr, "Error:", err)
 
 		if err.Error() == "missing operands" || err.Error() == "invalid time argument" {
@@ -67,11 +115,69 @@ func Execute() {
 			}
 		}
 
-		ExitFunc(1)
+		ExitFunc(code)
+	}
+}
+
+// exitCode maps an error returned by the root command to the process exit status:
+// ExitPartialFailure when some files were touched and others failed, otherwise ExitFailure.
+func exitCode(err error) int {
+	if errors.Is(err, touchErrors.ErrPartialFailure) {
+		return ExitPartialFailure
 	}
+
+	return ExitFailure
 }
 
 // SetVersionInfo sets the version information for the root command.
-func SetVersionInfo(version, commit, date string) {
-	rootCmd.Version = fmt.Sprintf("%s (Built on %s from Git SHA %s)", version, date, commit)
+func SetVersionInfo(release, commit, date string) {
+	versionInfo = version.Info{Version: release, Commit: commit, Date: date}
+	rootCmd.Version = fmt.Sprintf("%s (Built on %s from Git SHA %s)", release, date, commit)
+}
+
+// versionOutput renders cmd's --version output, used by its version template: the prose
+// "touch version ..." line by default or, with --version-format json, a JSON object holding
+// the version, commit, and date given to SetVersionInfo.
+func versionOutput(cmd *cobra.Command) (string, error) {
+	format := versionFormatText
+	if flag := cmd.Flags().Lookup("version-format"); flag != nil {
+		format = flag.Value.String()
+	}
+
+	if format != versionFormatJSON {
+		return fmt.Sprintf("%s version %s\n", cmd.DisplayName(), cmd.Version), nil
+	}
+
+	data, err := json.Marshal(versionInfo)
+	if err != nil {
+		return "", fmt.Errorf("encode version: %w", err)
+	}
+
+	return string(data) + "\n", nil
+}
+
+// versionFormatValue is the --version-format flag, rejecting formats other than text and json
+// while the flags are parsed, before --version is handled.
+type versionFormatValue string
+
+// String returns the selected format.
+func (v *versionFormatValue) String() string {
+	return string(*v)
+}
+
+// Set selects format, case-insensitively.
+func (v *versionFormatValue) Set(format string) error {
+	switch strings.ToLower(format) {
+	case versionFormatText, versionFormatJSON:
+		*v = versionFormatValue(strings.ToLower(format))
+
+		return nil
+	default:
+		return touchErrors.ErrInvalidVersionFormat
+	}
+}
+
+// Type names the flag's value type in usage output.
+func (v *versionFormatValue) Type() string {
+	return "string"
 }
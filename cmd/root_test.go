@@ -21,8 +21,11 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"strings"
 	"testing"
@@ -33,12 +36,13 @@ import (
 
 	"github.com/nicholas-fedor/touch/internal/cli"
 	"github.com/nicholas-fedor/touch/internal/core"
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
 	"github.com/nicholas-fedor/touch/internal/filesystem"
 	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
 	"github.com/nicholas-fedor/touch/internal/version"
 )
 
-const usageStr = "Usage:\n  touch [flags] file...\n\nFlags:\n  -a, --access             change only the access time\n  -d, --date string        parse ARG and use it instead of current time\n      --f                  (ignored for compatibility)\n      --help               help for touch\n  -m, --modification       change only the modification time\n  -c, --no-create          do not create any files\n  -h, --no-dereference     affect each symbolic link instead of any referenced file (unsupported on Windows)\n  -r, --reference string   use this file's times instead of current time\n  -t, --stamp string       use [[CC]YY]MMDDhhmm[.ss] instead of current time\n      --time string        change the specified time: access, atime, use (like -a); modify, mtime (like -m)\n  -v, --version            output version information and exit\n"
+const usageStr = "Usage:\n  touch [flags] file...\n\nFlags:\n  -a, --access             change only the access time\n  -d, --date string        parse ARG and use it instead of current time\n      --f                  (ignored for compatibility)\n      --help               help for touch\n  -m, --modification       change only the modification time\n  -c, --no-create          do not create any files\n  -h, --no-dereference     affect each symbolic link instead of any referenced file\n  -r, --reference string   use this file's times instead of current time\n  -t, --stamp string       use [[CC]YY]MMDDhhmm[.ss] instead of current time\n      --time string        change the specified time: access, atime, use (like -a); modify, mtime (like -m)\n  -v, --version            output version information and exit\n"
 
 func TestRootCmd(t *testing.T) {
 	if rootCmd.Use != "touch [flags] file..." {
@@ -113,6 +117,19 @@ func TestExecute(t *testing.T) {
 			wantExit:   1,
 			wantStderr: "touch: \"errorfile.txt\": stat file errorfile.txt: permission denied\n",
 		},
+		{
+			name: "partial failure exit 2",
+			args: []string{"testfile.txt", "errorfile.txt"},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "testfile.txt").Return(&mockFileInfo{mod: time.Now()}, nil)
+				m.On("Chtimes", "testfile.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+				m.On("Stat", "errorfile.txt").Return(nil, os.ErrPermission)
+			},
+			mockRunE:   cli.RunTouch,
+			wantExit:   2,
+			wantStderr: "touch: \"errorfile.txt\": stat file errorfile.txt: permission denied\n",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -136,7 +153,7 @@ func TestExecute(t *testing.T) {
 				String("time", "", "change the specified time: access, atime, use (like -a); modify, mtime (like -m)")
 			cmd.Flags().BoolP("no-create", "c", false, "do not create any files")
 			cmd.Flags().
-				BoolP("no-dereference", "h", false, "affect each symbolic link instead of any referenced file (unsupported on Windows)")
+				BoolP("no-dereference", "h", false, "affect each symbolic link instead of any referenced file")
 			cmd.Flags().Bool("f", false, "(ignored for compatibility)")
 			cmd.Flags().
 				StringP("reference", "r", "", "use this file's times instead of current time")
@@ -165,24 +182,24 @@ func TestExecute(t *testing.T) {
 			os.Stderr = w
 
 			// Capture exit code.
-			exitCode := 0
+			gotExit := 0
 			origExit := ExitFunc
 			ExitFunc = func(code int) {
-				exitCode = code
+				gotExit = code
 			}
 
 			defer func() { ExitFunc = origExit }()
 
 			// Run Execute with the mocked command.
 			if err := cmd.Execute(); err != nil {
-				switch err.Error() {
-				case "missing operands", "invalid time argument":
+				switch {
+				case err.Error() == "missing operands" || err.Error() == "invalid time argument":
 					fmt.Fprintln(os.Stderr, "Error:", err)
 
 					if usageErr := cmd.Usage(); usageErr != nil {
 						fmt.Fprintln(os.Stderr, "Error displaying usage:", usageErr)
 					}
-				case "errors occurred while processing files":
+				case errors.Is(err, touchErrors.ErrProcessingFiles):
 					// Do nothing, specific errors already printed
 				default:
 					if strings.HasPrefix(err.Error(), "touch: ") {
@@ -192,7 +209,7 @@ func TestExecute(t *testing.T) {
 					}
 				}
 
-				ExitFunc(1)
+				ExitFunc(exitCode(err))
 			}
 
 			w.Close()
@@ -201,8 +218,8 @@ func TestExecute(t *testing.T) {
 			buf.ReadFrom(r)
 			stderrOutput := strings.ReplaceAll(buf.String(), "\r\n", "\n")
 
-			if exitCode != tt.wantExit {
-				t.Errorf("Execute() exit code = %v, want %v", exitCode, tt.wantExit)
+			if gotExit != tt.wantExit {
+				t.Errorf("Execute() exit code = %v, want %v", gotExit, tt.wantExit)
 			}
 
 			if stderrOutput != tt.wantStderr {
@@ -212,6 +229,141 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func Test_exitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "usage error",
+			err:  touchErrors.ErrMissingOperands,
+			want: ExitFailure,
+		},
+		{
+			name: "every file failed",
+			err:  fmt.Errorf("%w: %w", touchErrors.ErrProcessingFiles, os.ErrPermission),
+			want: ExitFailure,
+		},
+		{
+			name: "some files failed",
+			err: fmt.Errorf(
+				"%w: %w: %w",
+				touchErrors.ErrPartialFailure,
+				touchErrors.ErrProcessingFiles,
+				os.ErrPermission,
+			),
+			want: ExitPartialFailure,
+		},
+		{
+			name: "cancelled",
+			err:  fmt.Errorf("%w: %w", touchErrors.ErrCancelled, context.Canceled),
+			want: ExitFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_versionOutput(t *testing.T) {
+	SetVersionInfo("v1.2.3", "abc123", "2025-07-13T14:30:00Z")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantText string
+		wantJSON *version.Info
+		wantErr  error
+	}{
+		{
+			name:     "default text",
+			args:     []string{"--version"},
+			wantText: "touch version v1.2.3 (Built on 2025-07-13T14:30:00Z from Git SHA abc123)\n",
+		},
+		{
+			name:     "explicit text",
+			args:     []string{"--version", "--version-format", "text"},
+			wantText: "touch version v1.2.3 (Built on 2025-07-13T14:30:00Z from Git SHA abc123)\n",
+		},
+		{
+			name:     "json",
+			args:     []string{"--version", "--version-format", "json"},
+			wantJSON: &version.Info{Version: "v1.2.3", Commit: "abc123", Date: "2025-07-13T14:30:00Z"},
+		},
+		{
+			name:     "json in upper case",
+			args:     []string{"-v", "--version-format=JSON"},
+			wantJSON: &version.Info{Version: "v1.2.3", Commit: "abc123", Date: "2025-07-13T14:30:00Z"},
+		},
+		{
+			name:    "unknown format",
+			args:    []string{"--version", "--version-format", "xml"},
+			wantErr: touchErrors.ErrInvalidVersionFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create a new command instance for each test, with the root command's version handling.
+			format := versionFormatValue(versionFormatText)
+			cmd := &cobra.Command{
+				Use:     "touch [flags] file...",
+				Version: rootCmd.Version,
+				RunE:    func(_ *cobra.Command, _ []string) error { return nil },
+			}
+			cmd.Flags().BoolP("version", "v", false, "output version information and exit")
+			cmd.Flags().Var(&format, "version-format", "format of --version output: text or json")
+			cmd.SetVersionTemplate("{{versionOutput .}}")
+
+			var stdout bytes.Buffer
+
+			cmd.SetOut(&stdout)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if tt.wantJSON == nil {
+				if stdout.String() != tt.wantText {
+					t.Errorf("Execute() stdout = %q, want %q", stdout.String(), tt.wantText)
+				}
+
+				return
+			}
+
+			var fields map[string]string
+			if err := json.Unmarshal(stdout.Bytes(), &fields); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v, output %q", err, stdout.String())
+			}
+
+			wantFields := map[string]string{
+				"version": tt.wantJSON.Version,
+				"commit":  tt.wantJSON.Commit,
+				"date":    tt.wantJSON.Date,
+			}
+			if !maps.Equal(fields, wantFields) {
+				t.Errorf("Execute() JSON = %v, want %v", fields, wantFields)
+			}
+
+			var info version.Info
+			if err := json.Unmarshal(stdout.Bytes(), &info); err != nil || info != *tt.wantJSON {
+				t.Errorf("json.Unmarshal() = %+v, %v, want %+v", info, err, *tt.wantJSON)
+			}
+		})
+	}
+}
+
 func TestVersionFromBuildInfo(t *testing.T) {
 	info := version.GetVersionInfo()
 
@@ -20,53 +20,676 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cli
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/nicholas-fedor/touch/internal/core"
-	"github.com/nicholas-fedor/touch/internal/errors"
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+	"github.com/nicholas-fedor/touch/internal/timestamp"
 )
 
+// newFilePerm is the mode files are created with before the umask, as with os.Create.
+const newFilePerm = 0o666
+
+// fileCounts is how many files applyToFilesCtx left updated and how many failed.
+type fileCounts struct {
+	updated int64
+	failed  int64
+}
+
+// wrapPartial wraps err, a failure to process files, in ErrPartialFailure when other files
+// were left updated. Any other error, such as a cancellation, is returned unchanged.
+func (c fileCounts) wrapPartial(err error) error {
+	if c.updated == 0 || c.failed == 0 || !errors.Is(err, touchErrors.ErrProcessingFiles) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", touchErrors.ErrPartialFailure, err)
+}
+
 // applyToFiles applies the touch operation concurrently to the list of files.
-// Uses goroutines for parallel processing; prints errors to stderr and returns an error if any fail.
+// At most opts.jobs files, or runtime.NumCPU when unset, are touched at once by a fixed pool
+// of workers; prints errors to stderr and, if any fail, returns ErrProcessingFiles wrapping
+// the errors.Join of each failure, prefixed with its quoted file name.
+// With a single job, as with --sequential, files are instead touched one at a time in
+// argument order on the calling goroutine, so filesystem calls are never concurrent.
+// With opts.verbose each successfully touched file is reported on stdout, one whole line at a time,
+// and with opts.summary the numbers of files updated and failed are printed to stderr at the end.
+// With opts.skipNetFS, files on network filesystems are skipped and counted as neither,
+// as are files modified too recently for opts.olderThan and missing files, including those that
+// vanish before they are touched, with opts.ifExists.
+// With opts.atomic, each file's original times are recorded before it is touched; once any file
+// fails no more are started, and every file started is restored, or removed if it was created.
 func applyToFiles(
-	changeTimes int,
-	noCreate, noDeref bool,
+	opts touchOptions,
 	accessTime, modTime core.Time,
 	files []string,
 ) error {
+	_, err := applyToFilesCtx(context.Background(), opts, accessTime, modTime, files)
+
+	return err
+}
+
+// applyToFilesCtx behaves like applyToFiles, but stops handing out files once ctx is done,
+// and also returns how many files were left updated and how many failed.
+// Files not yet started are skipped, and an ErrCancelled error reporting how many files
+// were completed is returned in place of any per-file failure.
+func applyToFilesCtx(
+	ctx context.Context,
+	opts touchOptions,
+	accessTime, modTime core.Time,
+	files []string,
+) (fileCounts, error) {
+	return applyJobsCtx(ctx, opts, len(files), func(yield func(fileJob) bool) error {
+		for _, file := range files {
+			if !yield(fileJob{file: file, accessTime: accessTime, modTime: modTime}) {
+				break
+			}
+		}
+
+		return nil
+	})
+}
+
+// fileJob is one file for applyJobsCtx to touch, with the times to give it.
+type fileJob struct {
+	file        string
+	changeTimes int // Times to change, narrowing opts.changeTimes; zero changes all of them.
+	accessTime  core.Time
+	modTime     core.Time
+}
+
+// applyJobsCtx behaves like applyToFilesCtx, but touches the files jobs yields, each with
+// its own times, as they are produced; yield reports false once no more should be produced.
+// total is how many files jobs yields, or -1 when that isn't known in advance, as for a
+// stream. An error from jobs, such as malformed input, is returned in place of any
+// per-file failure once the files already yielded are done.
+func applyJobsCtx(
+	ctx context.Context,
+	opts touchOptions,
+	total int,
+	jobs func(yield func(fileJob) bool) error,
+) (fileCounts, error) {
 	var (
-		wg       sync.WaitGroup
-		hadError atomic.Bool
+		wg         sync.WaitGroup
+		updated    atomic.Int64
+		failed     atomic.Int64
+		skipped    atomic.Int64
+		dispatched int
+		outMu      sync.Mutex
+		errsMu     sync.Mutex
+		fileErrs   []error
+		rollback   *rollbackLog
 	)
 
-	for _, file := range files {
-		wg.Add(1)
+	// A dry run changes nothing, so there is nothing to roll back.
+	if opts.atomic && !opts.dryRun {
+		rollback = &rollbackLog{}
+	}
+
+	process := func(job fileJob) {
+		currentFile := job.file
+
+		// Under --atomic the run is going to be rolled back, so don't touch anything more.
+		if rollback != nil && failed.Load() > 0 {
+			return
+		}
+
+		skip, err := skipNetworkFS(opts, currentFile)
+		if skip {
+			skipped.Add(1)
+			opts.progress.report(FileResult{File: currentFile, Skipped: true})
+
+			return
+		}
+
+		fileOpts := opts
+		if job.changeTimes != 0 {
+			fileOpts.changeTimes = job.changeTimes
+		}
 
-		go func(currentFile string) {
-			defer wg.Done()
+		// Record the original state before touching, so a partial touch is also undone.
+		if err == nil && rollback != nil {
+			fileOpts.rollbackEntry, err = rollback.capture(opts, currentFile)
+		}
 
-			if err := core.Touch(
-				currentFile,
-				changeTimes,
-				noCreate,
-				noDeref,
-				accessTime,
-				modTime,
-			); err != nil {
+		if err == nil {
+			err = touchFile(ctx, fileOpts, currentFile, job.accessTime, job.modTime)
+		}
+
+		// A file skipped for cancellation is neither updated nor failed.
+		if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			return
+		}
+
+		if skipUntouched(opts, err) {
+			skipped.Add(1)
+			opts.progress.report(FileResult{File: currentFile, Skipped: true})
+
+			return
+		}
+
+		if err != nil {
+			if !opts.quiet {
 				fmt.Fprintf(os.Stderr, "touch: %s: %v\n", core.Quote(currentFile), err)
-				hadError.Store(true)
 			}
-		}(file)
+
+			failed.Add(1)
+
+			errsMu.Lock()
+			fileErrs = append(fileErrs, fmt.Errorf("%s: %w", core.Quote(currentFile), err))
+			errsMu.Unlock()
+
+			opts.progress.report(FileResult{File: currentFile, Err: err})
+
+			return
+		}
+
+		updated.Add(1)
+		opts.progress.report(FileResult{File: currentFile})
+
+		// A dry run already reported what it would have done.
+		if opts.verbose && !opts.dryRun {
+			outMu.Lock()
+			fmt.Fprintf(os.Stdout, "touch: updated %s\n", core.Quote(currentFile))
+			outMu.Unlock()
+		}
+	}
+
+	workers := opts.jobs
+	if workers <= 0 {
+		workers = runtime.NumCPU()
 	}
 
-	wg.Wait()
+	var jobsErr error
+
+	if workers == 1 {
+		jobsErr = jobs(func(job fileJob) bool {
+			if ctx.Err() != nil {
+				return false
+			}
+
+			dispatched++
+
+			process(job)
+
+			return true
+		})
+	} else {
+		if total >= 0 {
+			workers = min(workers, total)
+		}
+
+		queue := make(chan fileJob)
+
+		for range workers {
+			wg.Go(func() {
+				for job := range queue {
+					process(job)
+				}
+			})
+		}
+
+		jobsErr = jobs(func(job fileJob) bool {
+			select {
+			case queue <- job:
+				dispatched++
+
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+
+		close(queue)
+		wg.Wait()
+	}
+
+	if opts.summary {
+		fmt.Fprintf(os.Stderr, "touch: %d updated, %d failed\n", updated.Load(), failed.Load())
+	}
+
+	// Without a known total, report the files handed out before the cancellation.
+	if total < 0 {
+		total = dispatched
+	}
+
+	var err error
+
+	switch ctxErr := ctx.Err(); {
+	case ctxErr != nil:
+		completed := updated.Load() + failed.Load() + skipped.Load()
+		err = fmt.Errorf(
+			"%w after completing %d of %d files: %w",
+			touchErrors.ErrCancelled,
+			completed,
+			total,
+			ctxErr,
+		)
+	case jobsErr != nil:
+		err = jobsErr
+	case len(fileErrs) > 0:
+		err = fmt.Errorf("%w: %w", touchErrors.ErrProcessingFiles, errors.Join(fileErrs...))
+	}
+
+	counts := fileCounts{updated: updated.Load(), failed: failed.Load()}
+
+	if err == nil || rollback == nil {
+		return counts, err
+	}
+
+	if rollbackErr := rollback.restore(); rollbackErr != nil {
+		fmt.Fprintf(os.Stderr, "touch: rollback incomplete: %v\n", rollbackErr)
+
+		return counts, errors.Join(
+			err,
+			fmt.Errorf("%w: %w", touchErrors.ErrRollbackFailed, rollbackErr),
+		)
+	}
+
+	fmt.Fprintf(os.Stderr, "touch: rolled back %d files\n", rollback.len())
+
+	// Every file touched before the failure was restored, so none remain updated.
+	counts.updated = 0
+
+	return counts, err
+}
+
+// touchFile applies the per-file adjustments selected in opts and touches a single file,
+// returning ctx's error without touching it if ctx is done.
+func touchFile(ctx context.Context, opts touchOptions, file string, accessTime, modTime core.Time) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("touch %s: %w", file, err)
+	}
+
+	// Give each file a current time strictly later than any file touched before it.
+	if opts.perFileNow {
+		now := core.MonotonicNowFrom(opts.clock)
+
+		var err error
+
+		accessTime, modTime, err = truncateTimes(opts, now, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.ancestorRef {
+		var err error
+
+		accessTime, modTime, err = timestamp.GetTimesFromNearestAncestor(file)
+		if err != nil {
+			return fmt.Errorf("get ancestor times: %w", err)
+		}
+	}
+
+	if opts.sizeTime {
+		sizeTime, err := timeFromSize(opts, file)
+		if err != nil {
+			return err
+		}
+
+		accessTime, modTime = sizeTime, sizeTime
+	}
+
+	if opts.statExpr != nil {
+		statTime, err := timeFromStats(opts, file)
+		if err != nil {
+			return err
+		}
+
+		accessTime, modTime = statTime, statTime
+	}
+
+	if opts.uuidTime {
+		if uuidTime, err := timestamp.GetTimeFromUUIDName(file); err == nil {
+			modTime = uuidTime
+		}
+	}
+
+	if opts.sidecar != "" {
+		var err error
+
+		accessTime, modTime, err = applySidecar(opts, file, accessTime, modTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.floorToDir {
+		var err error
+
+		// Raising the times is only reported with --verbose, and never with --quiet.
+		warn := io.Discard
+		if opts.verbose && !opts.quiet {
+			warn = os.Stderr
+		}
+
+		accessTime, modTime, err = floorToDir(warn, file, accessTime, modTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	touchOpts := core.Options{
+		Content:           []byte(opts.content),
+		ClampNewToNow:     opts.clampNew,
+		Clock:             opts.clock,
+		CreateParents:     opts.parents,
+		PreserveLinkTimes: opts.keepLinks,
+		DryRun:            opts.dryRun,
+		Diff:              opts.diff,
+		ForwardOnly:       opts.forwardOnly,
+		IfExists:          opts.ifExists,
+		Special:           opts.special,
+		OnlyOlderThan:     opts.olderThan,
+		Adjust:            opts.adjust,
+	}
+
+	// Under --atomic, only a file created here is removed by a rollback.
+	if opts.rollbackEntry != nil {
+		touchOpts.OnCreate = opts.rollbackEntry.markCreated
+	}
+
+	// Normalized symlinks are updated themselves, so record their own times.
+	auditNoDeref := opts.noDeref || opts.normLinks
+	recordChanges := opts.audit != nil || opts.journal
+
+	var before auditTimes
+
+	if recordChanges {
+		var err error
+
+		before, err = readAuditTimes(file, auditNoDeref)
+		if err != nil {
+			return err
+		}
+	}
+
+	if recordChanges || opts.manifestLog != nil || opts.histLog != nil || opts.execTemplate != "" {
+		touchOpts.AfterTouch = func(touched string) error {
+			if opts.audit != nil {
+				if err := opts.audit.record(touched, before, auditNoDeref); err != nil {
+					return err
+				}
+			}
+
+			if opts.journal {
+				if err := recordJournal(touched, before, auditNoDeref); err != nil {
+					return err
+				}
+			}
+
+			if opts.manifestLog != nil {
+				if err := opts.manifestLog.record(touched, auditNoDeref); err != nil {
+					return err
+				}
+			}
+
+			if opts.histLog != nil {
+				if err := opts.histLog.record(touched, auditNoDeref); err != nil {
+					return err
+				}
+			}
+
+			if opts.execTemplate != "" {
+				return runExec(opts.execTemplate, touched)
+			}
+
+			return nil
+		}
+	}
+
+	if opts.normLinks {
+		return core.NormalizeSymlinkTimes(file, opts.changeTimes, touchOpts)
+	}
+
+	if opts.normFS {
+		return core.NormalizeToFS(file, opts.changeTimes, opts.fsGrain, touchOpts)
+	}
+
+	err := core.TouchWithOptionsCtx(
+		ctx,
+		file,
+		opts.changeTimes,
+		opts.noCreate,
+		opts.noDeref,
+		accessTime,
+		modTime,
+		touchOpts,
+	)
+	if !opts.noDeref || opts.strict || !errors.Is(err, touchErrors.ErrNoDerefUnsupported) {
+		return err
+	}
+
+	// The link itself can't be updated here; follow it instead, as GNU touch does without -h.
+	if !opts.quiet {
+		fmt.Fprintf(
+			os.Stderr,
+			"touch: %s: cannot change the times of a symlink itself; following it\n",
+			core.Quote(file),
+		)
+	}
+
+	return core.TouchWithOptionsCtx(
+		ctx,
+		file,
+		opts.changeTimes,
+		opts.noCreate,
+		false,
+		accessTime,
+		modTime,
+		touchOpts,
+	)
+}
+
+// timeFromSize maps the size of file, or of the content it will be created with if it doesn't
+// exist, linearly into the --size-window for --size-time.
+func timeFromSize(opts touchOptions, file string) (core.Time, error) {
+	var (
+		fileInfo os.FileInfo
+		err      error
+	)
+
+	if opts.noDeref {
+		fileInfo, err = filesystem.Default.Lstat(file)
+	} else {
+		fileInfo, err = filesystem.Default.Stat(file)
+	}
+
+	size := int64(len(opts.content))
+
+	switch {
+	case err == nil:
+		size = fileInfo.Size()
+	case !errors.Is(err, os.ErrNotExist):
+		return core.Time{}, fmt.Errorf("get file size: %w", err)
+	}
+
+	return timestamp.TimeFromSize(size, opts.sizeMax, opts.sizeStart, opts.sizeEnd), nil
+}
+
+// timeFromStats evaluates --stat-time against the stats of file. A file that doesn't exist
+// yet is evaluated with the size and lines of the content it will be created with, and the
+// mode 0666 it is created with before the umask. Lines are only counted when referenced.
+func timeFromStats(opts touchOptions, file string) (core.Time, error) {
+	var (
+		fileInfo os.FileInfo
+		err      error
+	)
+
+	if opts.noDeref {
+		fileInfo, err = filesystem.Default.Lstat(file)
+	} else {
+		fileInfo, err = filesystem.Default.Stat(file)
+	}
+
+	vars := timestamp.StatVars{
+		Size: int64(len(opts.content)),
+		Mode: newFilePerm,
+	}
+
+	if opts.statExpr.UsesLines() {
+		vars.Lines = int64(strings.Count(opts.content, "\n"))
+	}
+
+	switch {
+	case err == nil:
+		vars.Size = fileInfo.Size()
+		vars.Mode = int64(fileInfo.Mode().Perm())
+		vars.Lines = 0
+
+		if opts.statExpr.UsesLines() && fileInfo.Mode().IsRegular() {
+			vars.Lines, err = countLines(file)
+			if err != nil {
+				return core.Time{}, err
+			}
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return core.Time{}, fmt.Errorf("get file stats: %w", err)
+	}
+
+	seconds, err := opts.statExpr.Eval(vars)
+	if err != nil {
+		return core.Time{}, fmt.Errorf("%s: %w", core.Quote(file), err)
+	}
+
+	return time.Unix(seconds, 0), nil
+}
+
+// lineCounter is an io.Writer counting the newline characters written to it.
+type lineCounter int64
+
+// Write counts the newlines in p.
+func (c *lineCounter) Write(p []byte) (int, error) {
+	*c += lineCounter(bytes.Count(p, []byte{'\n'}))
+
+	return len(p), nil
+}
+
+// countLines returns the number of newline characters in file, read through the FS.
+func countLines(file string) (int64, error) {
+	contentFile, err := filesystem.Default.Open(file)
+	if err != nil {
+		return 0, fmt.Errorf("open %s to count lines: %w", file, err)
+	}
+	defer contentFile.Close()
+
+	var lines lineCounter
+	if _, err := io.Copy(&lines, contentFile); err != nil {
+		return 0, fmt.Errorf("count lines of %s: %w", file, err)
+	}
+
+	return int64(lines), nil
+}
+
+// skipNetworkFS reports whether file should be left alone under --skip-network-fs because
+// it, or its parent directory if it doesn't exist yet, is on a network filesystem.
+// Under --verbose each skipped file is noted on stderr, unless --quiet is also given.
+func skipNetworkFS(opts touchOptions, file string) (bool, error) {
+	if !opts.skipNetFS {
+		return false, nil
+	}
+
+	fsType, err := platform.GetFSType(file)
+	if errors.Is(err, os.ErrNotExist) {
+		fsType, err = platform.GetFSType(filepath.Dir(file))
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("get filesystem type: %w", err)
+	}
+
+	if fsType.Network && opts.verbose {
+		fmt.Fprintf(
+			warnWriter(opts),
+			"touch: skipping %s on network filesystem %s\n",
+			core.Quote(file),
+			fsType.Name,
+		)
+	}
+
+	return fsType.Network, nil
+}
+
+// warnWriter returns where per-file errors and warnings are printed: stderr, or nowhere
+// under --quiet.
+func warnWriter(opts touchOptions) io.Writer {
+	if opts.quiet {
+		return io.Discard
+	}
+
+	return os.Stderr
+}
+
+// skipUntouched reports whether err, from touching a file, means the file was deliberately
+// left alone: it was modified too recently for --only-older-than, or it is missing or vanished
+// before it could be touched and opts.ifExists asks for such files to be skipped.
+func skipUntouched(opts touchOptions, err error) bool {
+	return errors.Is(err, touchErrors.ErrNotOlder) || opts.ifExists && errors.Is(err, os.ErrNotExist)
+}
+
+// applySidecar replaces accessTime and modTime with the time in file's sidecar, named by
+// appending opts.sidecar to file. Without a sidecar the given times are kept. Malformed
+// sidecars are an error under --strict and are otherwise ignored, with a warning unless quiet.
+func applySidecar(opts touchOptions, file string, accessTime, modTime core.Time) (core.Time, core.Time, error) {
+	sidecarTime, err := timestamp.GetTimeFromSidecar(file + opts.sidecar)
+
+	switch {
+	case err == nil:
+		return sidecarTime, sidecarTime, nil
+	case errors.Is(err, os.ErrNotExist):
+		return accessTime, modTime, nil
+	case errors.Is(err, touchErrors.ErrInvalidSidecar) && !opts.strict:
+		fmt.Fprintf(warnWriter(opts), "touch: %s: ignoring sidecar: %v\n", core.Quote(file), err)
+
+		return accessTime, modTime, nil
+	default:
+		return core.Time{}, core.Time{}, fmt.Errorf("get sidecar time: %w", err)
+	}
+}
+
+// floorToDir raises accessTime and modTime to the modification time of the directory
+// containing file when they would otherwise precede it, reporting it on warn when it does.
+func floorToDir(warn io.Writer, file string, accessTime, modTime core.Time) (core.Time, core.Time, error) {
+	dir := filepath.Dir(file)
+
+	dirInfo, err := filesystem.Default.Stat(dir)
+	if err != nil {
+		return core.Time{}, core.Time{}, fmt.Errorf("stat containing directory %s: %w", dir, err)
+	}
+
+	floor := dirInfo.ModTime()
+	if !accessTime.Before(floor) && !modTime.Before(floor) {
+		return accessTime, modTime, nil
+	}
+
+	fmt.Fprintf(
+		warn,
+		"touch: %s: raising times to containing directory time %s\n",
+		core.Quote(file),
+		floor.Format(time.RFC3339Nano),
+	)
+
+	if accessTime.Before(floor) {
+		accessTime = floor
+	}
 
-	if hadError.Load() {
-		return errors.ErrProcessingFiles
+	if modTime.Before(floor) {
+		modTime = floor
 	}
 
-	return nil
+	return accessTime, modTime, nil
 }
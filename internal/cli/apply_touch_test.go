@@ -21,25 +21,35 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/nicholas-fedor/touch/internal/core"
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
 	"github.com/nicholas-fedor/touch/internal/filesystem"
 	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
+	"github.com/nicholas-fedor/touch/internal/platform"
 )
 
 func Test_applyToFiles(t *testing.T) {
 	type args struct {
-		changeTimes int
-		noCreate    bool
-		noDeref     bool
-		accessTime  core.Time
-		modTime     core.Time
-		files       []string
+		opts       touchOptions
+		accessTime core.Time
+		modTime    core.Time
+		files      []string
 	}
 
 	tests := []struct {
@@ -52,12 +62,12 @@ func Test_applyToFiles(t *testing.T) {
 		{
 			name: "no files",
 			args: args{
-				changeTimes: core.ChAtime | core.ChMtime,
-				noCreate:    false,
-				noDeref:     false,
-				accessTime:  time.Now(),
-				modTime:     time.Now(),
-				files:       []string{},
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+				},
+				accessTime: time.Now(),
+				modTime:    time.Now(),
+				files:      []string{},
 			},
 			mockFSSetup: nil,
 			wantErr:     false,
@@ -66,12 +76,12 @@ func Test_applyToFiles(t *testing.T) {
 		{
 			name: "single file success",
 			args: args{
-				changeTimes: core.ChAtime | core.ChMtime,
-				noCreate:    false,
-				noDeref:     false,
-				accessTime:  time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
-				modTime:     time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
-				files:       []string{"testfile.txt"},
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"testfile.txt"},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "testfile.txt").
@@ -85,12 +95,12 @@ func Test_applyToFiles(t *testing.T) {
 		{
 			name: "multiple files success",
 			args: args{
-				changeTimes: core.ChAtime | core.ChMtime,
-				noCreate:    false,
-				noDeref:     false,
-				accessTime:  time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
-				modTime:     time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
-				files:       []string{"file1.txt", "file2.txt"},
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"file1.txt", "file2.txt"},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "file1.txt").
@@ -108,12 +118,12 @@ func Test_applyToFiles(t *testing.T) {
 		{
 			name: "single file error",
 			args: args{
-				changeTimes: core.ChAtime | core.ChMtime,
-				noCreate:    false,
-				noDeref:     false,
-				accessTime:  time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
-				modTime:     time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
-				files:       []string{"errorfile.txt"},
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"errorfile.txt"},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "errorfile.txt").Return(nil, os.ErrPermission)
@@ -121,15 +131,93 @@ func Test_applyToFiles(t *testing.T) {
 			wantErr:    true,
 			wantStderr: "touch: \"errorfile.txt\": stat file errorfile.txt: permission denied\n",
 		},
+		{
+			name: "quiet single file error",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					quiet:       true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"errorfile.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "errorfile.txt").Return(nil, os.ErrPermission)
+			},
+			wantErr:    true,
+			wantStderr: "",
+		},
+		{
+			name: "if exists missing file skipped uncounted",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					ifExists:    true,
+					summary:     true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
+			},
+			wantErr:    false,
+			wantStderr: "touch: 0 updated, 0 failed\n",
+		},
+		{
+			name: "if exists file vanished before update skipped",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					ifExists:    true,
+					summary:     true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
+				m.On("Chtimes", "file.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(os.ErrNotExist)
+			},
+			wantErr:    false,
+			wantStderr: "touch: 0 updated, 0 failed\n",
+		},
+		{
+			name: "if exists permission denied still fails",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					ifExists:    true,
+					summary:     true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
+				m.On("Chtimes", "file.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(os.ErrPermission)
+			},
+			wantErr: true,
+			wantStderr: "touch: \"file.txt\": chtimes file.txt: permission denied\n" +
+				"touch: 0 updated, 1 failed\n",
+		},
 		{
 			name: "multiple files one error",
 			args: args{
-				changeTimes: core.ChAtime | core.ChMtime,
-				noCreate:    false,
-				noDeref:     false,
-				accessTime:  time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
-				modTime:     time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
-				files:       []string{"file1.txt", "errorfile.txt"},
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"file1.txt", "errorfile.txt"},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "file1.txt").
@@ -141,19 +229,90 @@ func Test_applyToFiles(t *testing.T) {
 			wantErr:    true,
 			wantStderr: "touch: \"errorfile.txt\": stat file errorfile.txt: permission denied\n",
 		},
+		{
+			name: "summary with multiple files one error",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					summary:     true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"file1.txt", "errorfile.txt", "file2.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				for _, file := range []string{"file1.txt", "file2.txt"} {
+					m.On("Stat", file).
+						Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
+					m.On("Chtimes", file, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+						Return(nil)
+				}
+
+				m.On("Stat", "errorfile.txt").Return(nil, os.ErrPermission)
+			},
+			wantErr: true,
+			wantStderr: "touch: \"errorfile.txt\": stat file errorfile.txt: permission denied\n" +
+				"touch: 2 updated, 1 failed\n",
+		},
+		{
+			name: "summary leaves out files too recent to touch",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					olderThan:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.Local),
+					verbose:     true,
+					summary:     true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"old.txt", "recent.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "old.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)}, nil)
+				m.On("Chtimes", "old.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+				m.On("Stat", "recent.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 12, 0, 0, 0, 0, time.Local)}, nil)
+			},
+			wantErr:    false,
+			wantStderr: "touch: 1 updated, 0 failed\n",
+		},
+		{
+			name: "summary with all files succeeding",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					summary:     true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"file1.txt", "file2.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				for _, file := range []string{"file1.txt", "file2.txt"} {
+					m.On("Stat", file).
+						Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
+					m.On("Chtimes", file, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+						Return(nil)
+				}
+			},
+			wantErr:    false,
+			wantStderr: "touch: 2 updated, 0 failed\n",
+		},
 		{
 			name: "create new file",
 			args: args{
-				changeTimes: core.ChAtime | core.ChMtime,
-				noCreate:    false,
-				noDeref:     false,
-				accessTime:  time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
-				modTime:     time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
-				files:       []string{"newfile.txt"},
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"newfile.txt"},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "newfile.txt").Return(nil, os.ErrNotExist)
-				m.On("Create", "newfile.txt").Return(&os.File{}, nil)
+				m.On("OpenForCreate", "newfile.txt").Return(&os.File{}, true, nil)
 				m.On("Chtimes", "newfile.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
 					Return(nil)
 			},
@@ -163,12 +322,13 @@ func Test_applyToFiles(t *testing.T) {
 		{
 			name: "no create missing file",
 			args: args{
-				changeTimes: core.ChAtime | core.ChMtime,
-				noCreate:    true,
-				noDeref:     false,
-				accessTime:  time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
-				modTime:     time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
-				files:       []string{"missing.txt"},
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					noCreate:    true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"missing.txt"},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "missing.txt").Return(nil, os.ErrNotExist)
@@ -176,6 +336,139 @@ func Test_applyToFiles(t *testing.T) {
 			wantErr:    false,
 			wantStderr: "",
 		},
+		{
+			name: "floor to dir raises earlier times",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					floorToDir:  true,
+				},
+				accessTime: time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local),
+				files:      []string{"dir/file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "dir").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
+				m.On("Stat", "dir/file.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 9, 0, 0, 0, time.Local)}, nil)
+				m.On("Chtimes", "dir/file.txt", time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local), time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)).
+					Return(nil)
+			},
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name: "floor to dir raises earlier times verbosely",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					floorToDir:  true,
+					verbose:     true,
+				},
+				accessTime: time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local),
+				files:      []string{"dir/file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "dir").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
+				m.On("Stat", "dir/file.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 9, 0, 0, 0, time.Local)}, nil)
+				m.On("Chtimes", "dir/file.txt", time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local), time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)).
+					Return(nil)
+			},
+			wantErr:    false,
+			wantStderr: "touch: \"dir/file.txt\": raising times to containing directory time " + time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local).Format(time.RFC3339Nano) + "\n",
+		},
+		{
+			name: "floor to dir raises earlier times quietly",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					floorToDir:  true,
+					verbose:     true,
+					quiet:       true,
+				},
+				accessTime: time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local),
+				files:      []string{"dir/file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "dir").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
+				m.On("Stat", "dir/file.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 9, 0, 0, 0, time.Local)}, nil)
+				m.On("Chtimes", "dir/file.txt", time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local), time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)).
+					Return(nil)
+			},
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name: "floor to dir keeps later times",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					floorToDir:  true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"dir/file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "dir").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
+				m.On("Stat", "dir/file.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 9, 0, 0, 0, time.Local)}, nil)
+				m.On("Chtimes", "dir/file.txt", time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local), time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local)).
+					Return(nil)
+			},
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name: "floor to dir missing directory",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					floorToDir:  true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+				files:      []string{"missing/file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "missing").Return(nil, os.ErrNotExist)
+			},
+			wantErr:    true,
+			wantStderr: "touch: \"missing/file.txt\": stat containing directory missing: file does not exist\n",
+		},
+		{
+			name: "reference ancestor skips missing directories",
+			args: args{
+				opts: touchOptions{
+					changeTimes: core.ChAtime | core.ChMtime,
+					noCreate:    true,
+					ancestorRef: true,
+				},
+				accessTime: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTime:    time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				files:      []string{"a/b/c/file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a/b/c").Return(nil, os.ErrNotExist)
+				m.On("Stat", "a/b").Return(nil, os.ErrNotExist)
+				m.On("Stat", "a").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 8, 0, 0, 0, time.Local), dir: true}, nil)
+				m.On("Stat", "a/b/c/file.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 9, 0, 0, 0, time.Local)}, nil)
+				m.On("Chtimes", "a/b/c/file.txt", time.Date(2025, 7, 13, 8, 0, 0, 0, time.Local), time.Date(2025, 7, 13, 8, 0, 0, 0, time.Local)).
+					Return(nil)
+			},
+			wantErr:    false,
+			wantStderr: "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -191,14 +484,7 @@ func Test_applyToFiles(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stderr = w
 
-			err := applyToFiles(
-				tt.args.changeTimes,
-				tt.args.noCreate,
-				tt.args.noDeref,
-				tt.args.accessTime,
-				tt.args.modTime,
-				tt.args.files,
-			)
+			err := applyToFiles(tt.args.opts, tt.args.accessTime, tt.args.modTime, tt.args.files)
 
 			w.Close()
 
@@ -218,3 +504,1145 @@ func Test_applyToFiles(t *testing.T) {
 		})
 	}
 }
+
+func Test_applyToFiles_sidecars(t *testing.T) {
+	globalTime := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+	sidecarTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		sidecar    *string
+		strict     bool
+		quiet      bool
+		wantTime   core.Time
+		wantErr    bool
+		wantStderr string
+	}{
+		{
+			name:       "present sidecar",
+			sidecar:    func() *string { s := "2020-01-02T03:04:05Z\n"; return &s }(),
+			strict:     false,
+			quiet:      false,
+			wantTime:   sidecarTime,
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name:       "absent sidecar falls back to global time",
+			sidecar:    nil,
+			strict:     false,
+			quiet:      false,
+			wantTime:   globalTime,
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name:       "malformed sidecar warns and falls back",
+			sidecar:    func() *string { s := "not a time"; return &s }(),
+			strict:     false,
+			quiet:      false,
+			wantTime:   globalTime,
+			wantErr:    false,
+			wantStderr: "ignoring sidecar: invalid time sidecar",
+		},
+		{
+			name:       "malformed sidecar falls back silently under quiet",
+			sidecar:    func() *string { s := "not a time"; return &s }(),
+			strict:     false,
+			quiet:      true,
+			wantTime:   globalTime,
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name:       "malformed sidecar under strict",
+			sidecar:    func() *string { s := "not a time"; return &s }(),
+			strict:     true,
+			quiet:      false,
+			wantTime:   core.Time{},
+			wantErr:    true,
+			wantStderr: "get sidecar time: invalid time sidecar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "file.txt")
+			if tt.sidecar != nil {
+				if err := os.WriteFile(file+".time", []byte(*tt.sidecar), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			mockFS := mocks.NewMockFS(t)
+			mockFS.On("Open", file+".time").Return(os.Open) // Read the sidecar from disk.
+
+			if !tt.wantErr {
+				mockFS.On("Stat", file).Return(&mockFileInfo{mod: globalTime}, nil)
+				mockFS.On("Chtimes", file, tt.wantTime, tt.wantTime).Return(nil)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			// Capture stderr.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			opts := touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				sidecar:     ".time",
+				strict:      tt.strict,
+				quiet:       tt.quiet,
+			}
+			err := applyToFiles(opts, globalTime, globalTime, []string{file})
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+			stderrOutput := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyToFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !strings.Contains(stderrOutput, tt.wantStderr) || (tt.wantStderr == "") != (stderrOutput == "") {
+				t.Errorf("applyToFiles() stderr = %v, want %v", stderrOutput, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func Test_applyToFiles_noDerefFallback(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		setTimesErr error
+		strict      bool
+		mockFSSetup func(*mocks.MockFS)
+		wantErr     bool
+		wantStderr  string
+	}{
+		{
+			name:        "link updated itself",
+			setTimesErr: nil,
+			strict:      false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Lstat", "link.txt").Return(&mockFileInfo{mod: stamp}, nil)
+			},
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name:        "unsupported falls back to following the link",
+			setTimesErr: touchErrors.ErrNoDerefUnsupported,
+			strict:      false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Lstat", "link.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Stat", "link.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Chtimes", "link.txt", stamp, stamp).Return(nil)
+			},
+			wantErr:    false,
+			wantStderr: "touch: \"link.txt\": cannot change the times of a symlink itself; following it\n",
+		},
+		{
+			name:        "other errors are not retried",
+			setTimesErr: os.ErrPermission,
+			strict:      false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Lstat", "link.txt").Return(&mockFileInfo{mod: stamp}, nil)
+			},
+			wantErr:    true,
+			wantStderr: "touch: \"link.txt\": set times no deref link.txt: permission denied\n",
+		},
+		{
+			name:        "unsupported fails under strict",
+			setTimesErr: touchErrors.ErrNoDerefUnsupported,
+			strict:      true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Lstat", "link.txt").Return(&mockFileInfo{mod: stamp}, nil)
+			},
+			wantErr:    true,
+			wantStderr: "touch: \"link.txt\": set times no deref link.txt: no-dereference is not supported on this platform\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			oldSetTimesNoDeref := platform.SetTimesNoDeref
+
+			defer func() { platform.SetTimesNoDeref = oldSetTimesNoDeref }()
+
+			platform.SetTimesNoDeref = func(_ string, _, _ core.Time) error {
+				return tt.setTimesErr
+			}
+
+			// Capture stderr.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			opts := touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				noDeref:     true,
+				strict:      tt.strict,
+			}
+			err := applyToFiles(opts, stamp, stamp, []string{"link.txt"})
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyToFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := buf.String(); got != tt.wantStderr {
+				t.Errorf("applyToFiles() stderr = %q, want %q", got, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func Test_applyToFiles_verbose(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		files       []string
+		mockFSSetup func(*mocks.MockFS)
+		wantErr     bool
+		wantStdout  []string
+	}{
+		{
+			name:  "single file success",
+			files: []string{"testfile.txt"},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "testfile.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Chtimes", "testfile.txt", stamp, stamp).Return(nil)
+			},
+			wantErr:    false,
+			wantStdout: []string{"touch: updated \"testfile.txt\""},
+		},
+		{
+			name:  "multiple files success",
+			files: []string{"file1.txt", "file2.txt", "file3.txt"},
+			mockFSSetup: func(m *mocks.MockFS) {
+				for _, file := range []string{"file1.txt", "file2.txt", "file3.txt"} {
+					m.On("Stat", file).Return(&mockFileInfo{mod: stamp}, nil)
+					m.On("Chtimes", file, stamp, stamp).Return(nil)
+				}
+			},
+			wantErr: false,
+			wantStdout: []string{
+				"touch: updated \"file1.txt\"",
+				"touch: updated \"file2.txt\"",
+				"touch: updated \"file3.txt\"",
+			},
+		},
+		{
+			name:  "failed files are not reported",
+			files: []string{"file1.txt", "errorfile.txt"},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file1.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Chtimes", "file1.txt", stamp, stamp).Return(nil)
+				m.On("Stat", "errorfile.txt").Return(nil, os.ErrPermission)
+			},
+			wantErr:    true,
+			wantStdout: []string{"touch: updated \"file1.txt\""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			// Capture stdout, and discard the stderr of failed files.
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			devNull, _ := os.Open(os.DevNull)
+			os.Stderr = devNull
+
+			opts := touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				verbose:     true,
+			}
+			err := applyToFiles(opts, stamp, stamp, tt.files)
+
+			w.Close()
+			devNull.Close()
+
+			os.Stdout, os.Stderr = oldStdout, oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyToFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// Files are touched concurrently, so only whole lines are ordered.
+			got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.wantStdout) {
+				t.Errorf("applyToFiles() stdout lines = %q, want %q", got, tt.wantStdout)
+			}
+		})
+	}
+}
+
+func Test_applyToFiles_skipNetworkFS(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	oldFile := filepath.FromSlash("/mnt/nfs/old.txt")
+	newFile := filepath.FromSlash("/mnt/nfs/new.txt")
+	localFile := filepath.FromSlash("/home/local.txt")
+
+	// Paths below /mnt/nfs are on a network filesystem; newFile doesn't exist yet.
+	fsTypes := map[string]platform.FSType{
+		oldFile:               {Name: "nfs", Network: true},
+		filepath.Dir(newFile): {Name: "nfs", Network: true},
+		localFile:             {Name: "0xef53", Network: false},
+	}
+
+	oldGetFSType := platform.GetFSType
+
+	defer func() { platform.GetFSType = oldGetFSType }()
+
+	platform.GetFSType = func(path string) (platform.FSType, error) {
+		if fsType, ok := fsTypes[path]; ok {
+			return fsType, nil
+		}
+
+		return platform.FSType{}, fmt.Errorf("statfs %s: %w", path, os.ErrNotExist)
+	}
+
+	files := []string{oldFile, newFile, localFile}
+
+	tests := []struct {
+		name       string
+		verbose    bool
+		quiet      bool
+		wantStderr []string
+	}{
+		{
+			name:       "silent without verbose",
+			verbose:    false,
+			quiet:      false,
+			wantStderr: nil,
+		},
+		{
+			name:    "verbose notes skipped files",
+			verbose: true,
+			quiet:   false,
+			wantStderr: []string{
+				"touch: skipping " + core.Quote(newFile) + " on network filesystem nfs",
+				"touch: skipping " + core.Quote(oldFile) + " on network filesystem nfs",
+			},
+		},
+		{
+			name:       "quiet overrides verbose",
+			verbose:    true,
+			quiet:      true,
+			wantStderr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mock fails the test on any call not set up, so skipped files are never touched.
+			mockFS := mocks.NewMockFS(t)
+			mockFS.On("Stat", localFile).Return(&mockFileInfo{mod: stamp}, nil)
+			mockFS.On("Chtimes", localFile, stamp, stamp).Return(nil)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			// Capture stderr, and discard the stdout of verbose updates.
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			devNull, _ := os.Open(os.DevNull)
+			os.Stdout = devNull
+
+			opts := touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				skipNetFS:   true,
+				verbose:     tt.verbose,
+				quiet:       tt.quiet,
+			}
+			err := applyToFiles(opts, stamp, stamp, files)
+
+			w.Close()
+			devNull.Close()
+
+			os.Stdout, os.Stderr = oldStdout, oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if err != nil {
+				t.Fatalf("applyToFiles() error = %v", err)
+			}
+
+			// Files are touched concurrently, so only whole lines are ordered.
+			var got []string
+			if buf.Len() > 0 {
+				got = strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+				slices.Sort(got)
+			}
+
+			if !slices.Equal(got, tt.wantStderr) {
+				t.Errorf("applyToFiles() stderr lines = %q, want %q", got, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func Test_applyToFiles_dryRun(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	// The mock fails the test on any Create or Chtimes call.
+	mockFS := mocks.NewMockFS(t)
+	mockFS.On("Stat", "existing.txt").Return(&mockFileInfo{mod: stamp}, nil)
+	mockFS.On("Stat", "new.txt").Return(nil, os.ErrNotExist)
+
+	filesystem.Default = mockFS // Override default FS with mock.
+
+	// Capture stdout.
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	opts := touchOptions{
+		changeTimes: core.ChAtime | core.ChMtime,
+		dryRun:      true,
+		verbose:     true,
+	}
+	err := applyToFiles(opts, stamp, stamp, []string{"existing.txt", "new.txt"})
+
+	w.Close()
+
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	if err != nil {
+		t.Errorf("applyToFiles() error = %v", err)
+	}
+
+	// Verbose output is suppressed in favor of the dry run's own report.
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	slices.Sort(got)
+
+	want := []string{"would create \"new.txt\"", "would set times on \"existing.txt\""}
+	if !slices.Equal(got, want) {
+		t.Errorf("applyToFiles() stdout lines = %q, want %q", got, want)
+	}
+}
+
+func Test_applyToFilesCtx_cancelled(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		mockFSSetup func(m *mocks.MockFS, cancel context.CancelFunc)
+		cancelFirst bool
+		wantErr     string
+	}{
+		{
+			name:        "cancelled before starting",
+			mockFSSetup: func(*mocks.MockFS, context.CancelFunc) {},
+			cancelFirst: true,
+			wantErr:     "cancelled after completing 0 of 3 files: context canceled",
+		},
+		{
+			name: "cancelled while touching the first file",
+			mockFSSetup: func(m *mocks.MockFS, cancel context.CancelFunc) {
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Chtimes", "a.txt", stamp, stamp).Run(func(mock.Arguments) { cancel() }).Return(nil)
+			},
+			cancelFirst: false,
+			wantErr:     "cancelled after completing 1 of 3 files: context canceled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// The mock fails the test on any call not set up, so skipped files are never touched.
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS, cancel)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			if tt.cancelFirst {
+				cancel()
+			}
+
+			// Capture stderr, where skipped files must not be reported as failures.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			opts := touchOptions{changeTimes: core.ChAtime | core.ChMtime, jobs: 1}
+			_, err := applyToFilesCtx(ctx, opts, stamp, stamp, []string{"a.txt", "b.txt", "c.txt"})
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if !errors.Is(err, touchErrors.ErrCancelled) || !errors.Is(err, context.Canceled) {
+				t.Errorf("applyToFilesCtx() error = %v, want %v and %v", err, touchErrors.ErrCancelled, context.Canceled)
+			}
+
+			if err != nil && err.Error() != tt.wantErr {
+				t.Errorf("applyToFilesCtx() error = %q, want %q", err, tt.wantErr)
+			}
+
+			if buf.Len() > 0 {
+				t.Errorf("applyToFilesCtx() stderr = %q, want none", buf.String())
+			}
+		})
+	}
+}
+
+func Test_applyToFiles_joinedErrors(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+	permErr := &os.PathError{Op: "chtimes", Path: "locked.txt", Err: os.ErrPermission}
+
+	mockFS := mocks.NewMockFS(t)
+	for _, file := range []string{"locked.txt", "broken.txt", "fine.txt"} {
+		mockFS.On("Stat", file).Return(&mockFileInfo{mod: stamp}, nil)
+	}
+
+	mockFS.On("Chtimes", "locked.txt", stamp, stamp).Return(permErr)
+	mockFS.On("Chtimes", "broken.txt", stamp, stamp).Return(errors.New("disk failure"))
+	mockFS.On("Chtimes", "fine.txt", stamp, stamp).Return(nil)
+
+	filesystem.Default = mockFS // Override default FS with mock.
+
+	// Discard the per-file messages printed to stderr.
+	oldStderr := os.Stderr
+	devNull, _ := os.Open(os.DevNull)
+	os.Stderr = devNull
+
+	opts := touchOptions{changeTimes: core.ChAtime | core.ChMtime}
+	err := applyToFiles(opts, stamp, stamp, []string{"locked.txt", "broken.txt", "fine.txt"})
+
+	os.Stderr = oldStderr
+
+	devNull.Close()
+
+	if !errors.Is(err, touchErrors.ErrProcessingFiles) {
+		t.Fatalf("applyToFiles() error = %v, want %v", err, touchErrors.ErrProcessingFiles)
+	}
+
+	if !errors.Is(err, os.ErrPermission) {
+		t.Errorf("applyToFiles() error = %v, want %v in its chain", err, os.ErrPermission)
+	}
+
+	// The error wraps ErrProcessingFiles alongside the errors.Join of the per-file errors.
+	outer, ok := err.(interface{ Unwrap() []error })
+	if !ok || len(outer.Unwrap()) != 2 {
+		t.Fatalf("applyToFiles() error = %v, want ErrProcessingFiles and the joined errors", err)
+	}
+
+	joined, ok := outer.Unwrap()[1].(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("applyToFiles() error = %v, want a joined error in its chain", err)
+	}
+
+	fileErrs := joined.Unwrap()
+	if len(fileErrs) != 2 {
+		t.Fatalf("applyToFiles() joined %d errors, want 2: %v", len(fileErrs), fileErrs)
+	}
+
+	var gotPerm error
+
+	for _, fileErr := range fileErrs {
+		if errors.Is(fileErr, os.ErrPermission) {
+			gotPerm = fileErr
+		}
+	}
+
+	if gotPerm == nil || !strings.HasPrefix(gotPerm.Error(), core.Quote("locked.txt")+": ") {
+		t.Errorf("applyToFiles() permission error = %v, want it prefixed with %s", gotPerm, core.Quote("locked.txt"))
+	}
+}
+
+func Test_applyToFiles_jobs(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	files := make([]string, 24)
+	for i := range files {
+		files[i] = fmt.Sprintf("file%d.txt", i)
+	}
+
+	tests := []struct {
+		name string
+		jobs int
+	}{
+		{name: "one job", jobs: 1},
+		{name: "three jobs", jobs: 3},
+		{name: "more jobs than files", jobs: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, peak atomic.Int32
+
+			mockFS := mocks.NewMockFS(t)
+			for _, file := range files {
+				mockFS.On("Stat", file).Return(&mockFileInfo{mod: stamp}, nil)
+				mockFS.On("Chtimes", file, stamp, stamp).
+					Run(func(mock.Arguments) {
+						current := inFlight.Add(1)
+						defer inFlight.Add(-1)
+
+						for {
+							old := peak.Load()
+							if current <= old || peak.CompareAndSwap(old, current) {
+								break
+							}
+						}
+
+						// Hold the worker briefly so that others get a chance to overlap.
+						time.Sleep(2 * time.Millisecond)
+					}).
+					Return(nil)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			opts := touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				jobs:        tt.jobs,
+			}
+			if err := applyToFiles(opts, stamp, stamp, files); err != nil {
+				t.Fatalf("applyToFiles() error = %v", err)
+			}
+
+			if got := int(peak.Load()); got < 1 || got > tt.jobs {
+				t.Errorf("applyToFiles() touched %d files at once, want between 1 and %d", got, tt.jobs)
+			}
+		})
+	}
+}
+
+func Test_applyToFiles_sequential(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	files := make([]string, 24)
+	for i := range files {
+		files[i] = fmt.Sprintf("file%d.txt", i)
+	}
+
+	var (
+		callsMu  sync.Mutex
+		calls    []string
+		offStack []string
+	)
+
+	// record notes each filesystem call, and any made off the test's own goroutine, whose
+	// stack wouldn't include a frame for this test function itself, rather than its closures.
+	record := func(call string) {
+		callsMu.Lock()
+		defer callsMu.Unlock()
+
+		calls = append(calls, call)
+		if !strings.Contains(string(debug.Stack()), ".Test_applyToFiles_sequential(") {
+			offStack = append(offStack, call)
+		}
+	}
+
+	mockFS := mocks.NewMockFS(t)
+	for _, file := range files {
+		mockFS.On("Stat", file).
+			Run(func(mock.Arguments) { record("Stat " + file) }).
+			Return(&mockFileInfo{mod: stamp}, nil)
+		mockFS.On("Chtimes", file, stamp, stamp).
+			Run(func(mock.Arguments) { record("Chtimes " + file) }).
+			Return(nil)
+	}
+
+	filesystem.Default = mockFS // Override default FS with mock.
+
+	opts := touchOptions{
+		changeTimes: core.ChAtime | core.ChMtime,
+		jobs:        1,
+	}
+	if err := applyToFiles(opts, stamp, stamp, files); err != nil {
+		t.Fatalf("applyToFiles() error = %v", err)
+	}
+
+	want := make([]string, 0, 2*len(files))
+	for _, file := range files {
+		want = append(want, "Stat "+file, "Chtimes "+file)
+	}
+
+	if !slices.Equal(calls, want) {
+		t.Errorf("applyToFiles() made calls %q, want %q", calls, want)
+	}
+
+	if len(offStack) > 0 {
+		t.Errorf("applyToFiles() made calls %q on another goroutine, want none", offStack)
+	}
+}
+
+func TestRunTouch_onlyOlderThan(t *testing.T) {
+	filesystem.Default = realFS
+
+	dir := t.TempDir()
+	now := time.Now()
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	// Files of varying ages against a 7-day threshold.
+	ages := map[string]time.Duration{
+		"month.txt":  30 * 24 * time.Hour,
+		"eight.txt":  8 * 24 * time.Hour,
+		"three.txt":  3 * 24 * time.Hour,
+		"hour.txt":   time.Hour,
+		"future.txt": -time.Hour,
+	}
+	wantTouched := map[string]bool{"month.txt": true, "eight.txt": true}
+
+	files := make([]string, 0, len(ages))
+
+	for name, age := range ages {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+			t.Fatal(err)
+		}
+
+		files = append(files, path)
+	}
+
+	cmd := createTestCmd(func(cmd *cobra.Command) {
+		cmd.Flags().Set("stamp", "202507131430")
+		cmd.Flags().Set("only-older-than", "7d")
+	})
+	if err := RunTouch(cmd, files); err != nil {
+		t.Fatalf("RunTouch() error = %v", err)
+	}
+
+	for name, age := range ages {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := now.Add(-age)
+		if wantTouched[name] {
+			want = stamp
+		}
+
+		if !info.ModTime().Equal(want) {
+			t.Errorf("%s mtime = %v, want %v", name, info.ModTime(), want)
+		}
+	}
+}
+
+func TestRunTouch_uuidTime(t *testing.T) {
+	filesystem.Default = realFS
+
+	dir := t.TempDir()
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	// The RFC 9562 example UUIDs, both generated at 2022-02-22 19:22:22 UTC.
+	uuidTime := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	want := map[string]struct{ atime, mtime time.Time }{
+		"c232ab00-9414-11ec-b3c8-9f6bdeced846":      {atime: stamp, mtime: uuidTime},
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f.json": {atime: stamp, mtime: uuidTime},
+		"919108f7-52d1-4320-9bac-f847db4148a8":      {atime: stamp, mtime: stamp},
+		"notes.txt":                                 {atime: stamp, mtime: stamp},
+	}
+
+	files := make([]string, 0, len(want))
+	for name := range want {
+		files = append(files, filepath.Join(dir, name))
+	}
+
+	cmd := createTestCmd(func(cmd *cobra.Command) {
+		cmd.Flags().Set("stamp", "202507131430")
+		cmd.Flags().Set("uuid-time", "true")
+	})
+	if err := RunTouch(cmd, files); err != nil {
+		t.Fatalf("RunTouch() error = %v", err)
+	}
+
+	for name, times := range want {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !info.ModTime().Equal(times.mtime) {
+			t.Errorf("%s mtime = %v, want %v", name, info.ModTime(), times.mtime)
+		}
+
+		if atime := platform.GetAtime(info); !atime.Equal(times.atime) {
+			t.Errorf("%s atime = %v, want %v", name, atime, times.atime)
+		}
+	}
+}
+
+func TestRunTouch_sizeTime(t *testing.T) {
+	filesystem.Default = realFS
+
+	dir := t.TempDir()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// Sizes against a 1000-byte maximum; missing.txt is created with the 100-byte content.
+	sizes := map[string]int{"empty.txt": 0, "half.txt": 500, "max.txt": 1000, "huge.txt": 4000}
+	want := map[string]time.Time{
+		"empty.txt":   start,
+		"half.txt":    start.Add(5 * time.Hour),
+		"max.txt":     end,
+		"huge.txt":    end,
+		"missing.txt": start.Add(time.Hour),
+	}
+
+	files := []string{filepath.Join(dir, "missing.txt")}
+
+	for name, size := range sizes {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, make([]byte, size), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		files = append(files, path)
+	}
+
+	cmd := createTestCmd(func(cmd *cobra.Command) {
+		cmd.Flags().Set("size-time", "true")
+		cmd.Flags().Set("size-window", "2020-01-01T00:00:00Z,2020-01-01T10:00:00Z")
+		cmd.Flags().Set("size-max", "1000")
+		cmd.Flags().Set("content", strings.Repeat("x", 100))
+	})
+	if err := RunTouch(cmd, files); err != nil {
+		t.Fatalf("RunTouch() error = %v", err)
+	}
+
+	for name, wantTime := range want {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !info.ModTime().Equal(wantTime) {
+			t.Errorf("%s mtime = %v, want %v", name, info.ModTime(), wantTime)
+		}
+
+		if atime := platform.GetAtime(info); !atime.Equal(wantTime) {
+			t.Errorf("%s atime = %v, want %v", name, atime, wantTime)
+		}
+	}
+}
+
+func TestRunTouch_statTime(t *testing.T) {
+	filesystem.Default = realFS
+
+	base := int64(1700000000)
+
+	// File contents by name; missing.txt is created with the --content below.
+	contents := map[string]string{
+		"empty.txt": "",
+		"small.txt": "abc\n",
+		"lines.txt": "one\ntwo\nthree\n",
+	}
+
+	const content = "created\ncontent\n"
+
+	tests := []struct {
+		name       string
+		expression string
+		want       map[string]int64
+	}{
+		{
+			name:       "offset by size",
+			expression: "1700000000 + size",
+			want: map[string]int64{
+				"empty.txt":   base,
+				"small.txt":   base + 4,
+				"lines.txt":   base + 14,
+				"missing.txt": base + int64(len(content)),
+			},
+		},
+		{
+			name:       "days per line",
+			expression: "1700000000 + lines * 86400",
+			want: map[string]int64{
+				"empty.txt":   base,
+				"small.txt":   base + 86400,
+				"lines.txt":   base + 3*86400,
+				"missing.txt": base + 2*86400,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := []string{filepath.Join(dir, "missing.txt")}
+
+			for name, data := range contents {
+				path := filepath.Join(dir, name)
+				if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				files = append(files, path)
+			}
+
+			cmd := createTestCmd(func(cmd *cobra.Command) {
+				cmd.Flags().Set("stat-time", tt.expression)
+				cmd.Flags().Set("content", content)
+			})
+			if err := RunTouch(cmd, files); err != nil {
+				t.Fatalf("RunTouch() error = %v", err)
+			}
+
+			for name, seconds := range tt.want {
+				info, err := os.Stat(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				wantTime := time.Unix(seconds, 0)
+				if !info.ModTime().Equal(wantTime) {
+					t.Errorf("%s mtime = %v, want %v", name, info.ModTime(), wantTime)
+				}
+
+				if atime := platform.GetAtime(info); !atime.Equal(wantTime) {
+					t.Errorf("%s atime = %v, want %v", name, atime, wantTime)
+				}
+			}
+		})
+	}
+}
+
+func TestRunTouch_adjust(t *testing.T) {
+	filesystem.Default = realFS
+
+	dir := t.TempDir()
+	atime := time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local)
+	mtime := time.Date(2025, 7, 12, 9, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		adjust    string
+		wantAtime time.Time
+		wantMtime time.Time
+	}{
+		{
+			name:      "positive",
+			adjust:    "+1h",
+			wantAtime: atime.Add(time.Hour),
+			wantMtime: mtime.Add(time.Hour),
+		},
+		{
+			name:      "negative",
+			adjust:    "-2 days",
+			wantAtime: atime.Add(-48 * time.Hour),
+			wantMtime: mtime.Add(-48 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".txt")
+			if err := os.WriteFile(path, nil, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.Chtimes(path, atime, mtime); err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := createTestCmd(func(cmd *cobra.Command) {
+				cmd.Flags().Set("adjust", tt.adjust)
+			})
+			if err := RunTouch(cmd, []string{path}); err != nil {
+				t.Fatalf("RunTouch() error = %v", err)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := platform.GetAtime(info); !got.Equal(tt.wantAtime) {
+				t.Errorf("atime = %v, want %v", got, tt.wantAtime)
+			}
+
+			if got := info.ModTime(); !got.Equal(tt.wantMtime) {
+				t.Errorf("mtime = %v, want %v", got, tt.wantMtime)
+			}
+		})
+	}
+}
+
+func Benchmark_applyToFiles(b *testing.B) {
+	filesystem.Default = realFS
+
+	dir := b.TempDir()
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	files := make([]string, 1000)
+	for i := range files {
+		files[i] = filepath.Join(dir, fmt.Sprintf("file%d.txt", i))
+		if err := os.WriteFile(files[i], nil, 0o600); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	opts := touchOptions{changeTimes: core.ChAtime | core.ChMtime}
+
+	for b.Loop() {
+		if err := applyToFiles(opts, stamp, stamp, files); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Test_applyToFilesCtx_counts(t *testing.T) {
+	oldTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+	newTime := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		opts        touchOptions
+		files       []string
+		want        fileCounts
+		wantPartial bool
+	}{
+		{
+			name:        "all updated",
+			opts:        touchOptions{changeTimes: core.ChAtime | core.ChMtime},
+			files:       []string{"a.txt", "b.txt"},
+			want:        fileCounts{updated: 2, failed: 0},
+			wantPartial: false,
+		},
+		{
+			name:        "some failed",
+			opts:        touchOptions{changeTimes: core.ChAtime | core.ChMtime},
+			files:       []string{"a.txt", filepath.Join("a.txt", "c.txt"), "b.txt"},
+			want:        fileCounts{updated: 2, failed: 1},
+			wantPartial: true,
+		},
+		{
+			name:        "all failed",
+			opts:        touchOptions{changeTimes: core.ChAtime | core.ChMtime},
+			files:       []string{filepath.Join("a.txt", "c.txt"), filepath.Join("a.txt", "d.txt")},
+			want:        fileCounts{updated: 0, failed: 2},
+			wantPartial: false,
+		},
+		{
+			name:        "rolled back",
+			opts:        touchOptions{changeTimes: core.ChAtime | core.ChMtime, atomic: true, jobs: 1},
+			files:       []string{"a.txt", filepath.Join("a.txt", "c.txt"), "b.txt"},
+			want:        fileCounts{updated: 0, failed: 1},
+			wantPartial: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := filesystem.NewMemFS()
+			memCreate(t, m, "a.txt", oldTime, oldTime)
+
+			filesystem.Default = m // Override default FS with an in-memory one.
+
+			// Discard the per-file messages printed to stderr.
+			oldStderr := os.Stderr
+			devNull, _ := os.Open(os.DevNull)
+			os.Stderr = devNull
+
+			got, err := applyToFilesCtx(context.Background(), tt.opts, newTime, newTime, tt.files)
+
+			os.Stderr = oldStderr
+
+			devNull.Close()
+
+			if got != tt.want {
+				t.Errorf("applyToFilesCtx() counts = %+v, want %+v", got, tt.want)
+			}
+
+			gotPartial := errors.Is(got.wrapPartial(err), touchErrors.ErrPartialFailure)
+			if gotPartial != tt.wantPartial {
+				t.Errorf("fileCounts.wrapPartial() partial = %v, want %v", gotPartial, tt.wantPartial)
+			}
+		})
+	}
+}
+
+func Test_fileCounts_wrapPartial(t *testing.T) {
+	processingErr := fmt.Errorf("%w: %w", touchErrors.ErrProcessingFiles, os.ErrPermission)
+	cancelErr := fmt.Errorf("%w: %w", touchErrors.ErrCancelled, context.Canceled)
+
+	tests := []struct {
+		name        string
+		counts      fileCounts
+		err         error
+		wantPartial bool
+	}{
+		{
+			name:        "success",
+			counts:      fileCounts{updated: 3, failed: 0},
+			err:         nil,
+			wantPartial: false,
+		},
+		{
+			name:        "mixed outcomes",
+			counts:      fileCounts{updated: 2, failed: 1},
+			err:         processingErr,
+			wantPartial: true,
+		},
+		{
+			name:        "every file failed",
+			counts:      fileCounts{updated: 0, failed: 3},
+			err:         processingErr,
+			wantPartial: false,
+		},
+		{
+			name:        "cancelled",
+			counts:      fileCounts{updated: 2, failed: 1},
+			err:         cancelErr,
+			wantPartial: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.counts.wrapPartial(tt.err)
+
+			if gotPartial := errors.Is(err, touchErrors.ErrPartialFailure); gotPartial != tt.wantPartial {
+				t.Errorf("fileCounts.wrapPartial() = %v, want partial %v", err, tt.wantPartial)
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Errorf("fileCounts.wrapPartial() = %v, want it to wrap %v", err, tt.err)
+			}
+		})
+	}
+}
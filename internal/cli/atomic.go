@@ -0,0 +1,209 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file records each file's original times for --atomic and restores them on failure.
+package cli
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+// rollbackLog holds the original state of every file an --atomic run has started to touch.
+// Entries are appended one at a time so concurrent workers never lose a record.
+type rollbackLog struct {
+	mu      sync.Mutex       // Serializes appends.
+	entries []*rollbackEntry // Original states, in the order files were started.
+}
+
+// rollbackEntry is the state of a single file before it was touched.
+type rollbackEntry struct {
+	file     string    // File as given on the command line.
+	existed  bool      // Whether the file existed when its state was recorded.
+	created  bool      // Whether touching created the file, which is then removed on rollback.
+	newDirs  []string  // Parent directories -p would create, deepest first.
+	atime    core.Time // Original access time.
+	mtime    core.Time // Original modification time.
+	btime    core.Time // Original birth time, when hasBtime is set.
+	hasBtime bool      // Whether the birth time was read and should be restored.
+	noDeref  bool      // Restore the times of a symlink itself.
+}
+
+// capture records the current state of file before it is touched with opts, returning the
+// entry to mark with markCreated if touching creates the file.
+func (r *rollbackLog) capture(opts touchOptions, file string) (*rollbackEntry, error) {
+	// Normalized symlinks are updated themselves, so record their own times.
+	entry := &rollbackEntry{file: file, noDeref: opts.noDeref || opts.normLinks}
+
+	stat := filesystem.Default.Stat
+	if entry.noDeref {
+		stat = filesystem.Default.Lstat
+	}
+
+	fileInfo, err := stat(file)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if opts.parents {
+			entry.newDirs = missingParents(file)
+		}
+	case err != nil:
+		return nil, fmt.Errorf("record original times of %s: %w", file, err)
+	default:
+		entry.existed = true
+		entry.atime = platform.GetAtime(fileInfo)
+		entry.mtime = fileInfo.ModTime()
+
+		if opts.changeTimes&core.ChBtime != 0 {
+			entry.btime, entry.hasBtime = platform.GetBtime(file, fileInfo, entry.noDeref)
+		}
+	}
+
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.mu.Unlock()
+
+	return entry, nil
+}
+
+// markCreated records that touching created file, as reported by core.Options.OnCreate.
+// Being missing when captured isn't enough, as someone else may have created it since.
+func (e *rollbackEntry) markCreated(string) {
+	e.created = true
+}
+
+// restore returns every recorded file to its original state, most recent first: files that
+// touching created are removed, and the rest get their original times back. Parent
+// directories created for new files are removed last, once all files are gone. Failures are
+// joined and returned after trying every file.
+func (r *rollbackLog) restore() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+
+	for _, entry := range slices.Backward(r.entries) {
+		if err := entry.restore(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", core.Quote(entry.file), err))
+		}
+	}
+
+	for _, dir := range r.newDirs() {
+		if err := filesystem.Default.Remove(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("%s: roll back: %w", core.Quote(dir), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// newDirs returns the parent directories recorded for all files, each once and deepest
+// first, so a directory shared by several new files is only removed after all of them.
+// The caller must hold the lock.
+func (r *rollbackLog) newDirs() []string {
+	var dirs []string
+
+	for _, entry := range r.entries {
+		dirs = append(dirs, entry.newDirs...)
+	}
+
+	slices.SortFunc(dirs, func(a, b string) int {
+		return cmp.Or(
+			cmp.Compare(pathDepth(b), pathDepth(a)),
+			strings.Compare(a, b),
+		)
+	})
+
+	return slices.Compact(dirs)
+}
+
+// pathDepth returns the number of separators in the cleaned path dir.
+func pathDepth(dir string) int {
+	return strings.Count(dir, string(filepath.Separator))
+}
+
+// len returns the number of files recorded.
+func (r *rollbackLog) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.entries)
+}
+
+// restore returns a single file to its recorded state, leaving its new parent directories
+// to rollbackLog.restore. A file that was missing but not created here is left alone.
+func (e *rollbackEntry) restore() error {
+	if !e.existed {
+		if !e.created {
+			return nil
+		}
+
+		if err := filesystem.Default.Remove(e.file); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("roll back: %w", err)
+		}
+
+		return nil
+	}
+
+	// Restore the birth time first; on some systems an earlier mtime also moves it.
+	if e.hasBtime {
+		if err := platform.SetBtime(e.file, e.btime, e.noDeref); err != nil {
+			return fmt.Errorf("roll back birth time: %w", err)
+		}
+	}
+
+	var err error
+	if e.noDeref {
+		err = platform.SetTimesNoDeref(e.file, e.atime, e.mtime)
+	} else {
+		err = filesystem.Default.Chtimes(e.file, e.atime, e.mtime)
+	}
+
+	if err != nil {
+		return fmt.Errorf("roll back times: %w", err)
+	}
+
+	return nil
+}
+
+// missingParents returns the parent directories of file that don't exist yet, deepest first.
+func missingParents(file string) []string {
+	var dirs []string
+
+	for dir := filepath.Dir(file); ; dir = filepath.Dir(dir) {
+		if _, err := filesystem.Default.Stat(dir); !errors.Is(err, fs.ErrNotExist) {
+			return dirs
+		}
+
+		dirs = append(dirs, dir)
+
+		if parent := filepath.Dir(dir); parent == dir {
+			return dirs
+		}
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file tests the --atomic rollback of files touched before a failure.
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+func Test_applyToFiles_atomic(t *testing.T) {
+	oldAtime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+	oldMtime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+	newTime := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	// Two new files share the parent directories -p creates.
+	newFile := filepath.Join("dir", "sub", "new.txt")
+	otherFile := filepath.Join("dir", "sub", "other.txt")
+
+	tests := []struct {
+		name       string
+		opts       touchOptions
+		wantStderr string
+		wantGone   []string
+	}{
+		{
+			name: "sequential",
+			opts: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				parents:     true,
+				atomic:      true,
+				jobs:        1,
+			},
+			wantStderr: "touch: rolled back 4 files\n",
+			wantGone:   []string{newFile, otherFile, filepath.Join("dir", "sub"), "dir"},
+		},
+		{
+			name: "concurrent",
+			opts: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				parents:     true,
+				atomic:      true,
+				jobs:        4,
+			},
+			wantStderr: "touch: rolled back ",
+			wantGone:   []string{newFile, otherFile, filepath.Join("dir", "sub"), "dir"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := filesystem.NewMemFS()
+			memCreate(t, m, "a.txt", oldAtime, oldMtime)
+			memCreate(t, m, "d.txt", oldAtime, oldMtime)
+
+			filesystem.Default = m // Override default FS with an in-memory one.
+
+			// Capture stderr.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			// The third file fails, as its parent is a regular file.
+			files := []string{"a.txt", newFile, otherFile, filepath.Join("a.txt", "c.txt"), "d.txt"}
+			err := applyToFiles(tt.opts, newTime, newTime, files)
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if !errors.Is(err, touchErrors.ErrProcessingFiles) {
+				t.Errorf("applyToFiles() error = %v, want %v", err, touchErrors.ErrProcessingFiles)
+			}
+
+			if errors.Is(err, touchErrors.ErrRollbackFailed) {
+				t.Errorf("applyToFiles() error = %v, want a complete rollback", err)
+			}
+
+			if !strings.Contains(buf.String(), tt.wantStderr) {
+				t.Errorf("applyToFiles() stderr = %q, want it to contain %q", buf.String(), tt.wantStderr)
+			}
+
+			for _, file := range []string{"a.txt", "d.txt"} {
+				info, err := m.Stat(file)
+				if err != nil {
+					t.Fatalf("MemFS.Stat(%s) error = %v", file, err)
+				}
+
+				atime := platform.GetAtime(info)
+				if !atime.Equal(oldAtime) || !info.ModTime().Equal(oldMtime) {
+					t.Errorf("%s times = %v, %v, want %v, %v", file, atime, info.ModTime(), oldAtime, oldMtime)
+				}
+			}
+
+			for _, path := range tt.wantGone {
+				if _, err := m.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf("MemFS.Stat(%s) error = %v, want %v", path, err, fs.ErrNotExist)
+				}
+			}
+		})
+	}
+}
+
+func Test_rollbackLog_restore(t *testing.T) {
+	m := filesystem.NewMemFS()
+	filesystem.Default = m // Override default FS with an in-memory one.
+
+	dir := filepath.Join("dir", "sub")
+	shared := []string{filepath.Join(dir, "x.txt"), filepath.Join(dir, "y.txt")}
+	opts := touchOptions{changeTimes: core.ChAtime | core.ChMtime, parents: true, atomic: true}
+
+	// Record every file before any is created, as concurrent workers may, so both new files
+	// record the same missing parents.
+	r := &rollbackLog{}
+
+	var entries []*rollbackEntry
+
+	for _, file := range append(shared, "theirs.txt") {
+		entry, err := r.capture(opts, file)
+		if err != nil {
+			t.Fatalf("rollbackLog.capture(%s) error = %v", file, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := m.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	for _, file := range append(shared, "theirs.txt") {
+		memCreate(t, m, file, stamp, stamp)
+	}
+
+	// Only the shared files were created by touching; theirs.txt appeared from elsewhere.
+	entries[0].markCreated(shared[0])
+	entries[1].markCreated(shared[1])
+
+	if err := r.restore(); err != nil {
+		t.Errorf("rollbackLog.restore() error = %v", err)
+	}
+
+	for _, path := range append(shared, dir, "dir") {
+		if _, err := m.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("MemFS.Stat(%s) error = %v, want %v", path, err, fs.ErrNotExist)
+		}
+	}
+
+	if _, err := m.Stat("theirs.txt"); err != nil {
+		t.Errorf("MemFS.Stat(theirs.txt) error = %v, want it kept", err)
+	}
+}
+
+func Test_applyToFiles_atomicSuccess(t *testing.T) {
+	oldTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+	newTime := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	m := filesystem.NewMemFS()
+	memCreate(t, m, "a.txt", oldTime, oldTime)
+
+	filesystem.Default = m // Override default FS with an in-memory one.
+
+	opts := touchOptions{changeTimes: core.ChAtime | core.ChMtime, atomic: true}
+	if err := applyToFiles(opts, newTime, newTime, []string{"a.txt", "b.txt"}); err != nil {
+		t.Fatalf("applyToFiles() error = %v", err)
+	}
+
+	// Nothing failed, so every change is kept.
+	for _, file := range []string{"a.txt", "b.txt"} {
+		info, err := m.Stat(file)
+		if err != nil || !info.ModTime().Equal(newTime) {
+			t.Errorf("MemFS.Stat(%s) = %v, %v, want modification time %v", file, info, err, newTime)
+		}
+	}
+}
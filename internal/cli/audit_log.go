@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file appends per-file records of time changes to the --audit-log file.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+const (
+	// auditMissing stands in for the times of a file that did not exist before the touch.
+	auditMissing = "-"
+	// auditLogPerm is the permission used when the audit log is created.
+	auditLogPerm = 0o644
+)
+
+// auditLogger appends one tab-separated record per changed file to an audit log.
+// Records are written one at a time so concurrent touches never interleave lines.
+type auditLogger struct {
+	path    string     // Audit log appended to.
+	runTime string     // Time of the run, shared by every record it writes.
+	mu      sync.Mutex // Serializes appends.
+}
+
+// auditTimes holds a file's access and modification times formatted for an audit record.
+type auditTimes struct {
+	atime string
+	mtime string
+}
+
+// newAuditLogger returns an auditLogger appending to path for a run started at runTime.
+func newAuditLogger(path string, runTime core.Time) *auditLogger {
+	return &auditLogger{
+		path:    path,
+		runTime: runTime.Format(time.RFC3339Nano),
+	}
+}
+
+// readAuditTimes reads file's current times for an audit record, without following a
+// final symlink when noDeref is set. A missing file yields auditMissing for both times.
+func readAuditTimes(file string, noDeref bool) (auditTimes, error) {
+	stat := filesystem.Default.Stat
+	if noDeref {
+		stat = filesystem.Default.Lstat
+	}
+
+	fileInfo, err := stat(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return auditTimes{atime: auditMissing, mtime: auditMissing}, nil
+	}
+
+	if err != nil {
+		return auditTimes{}, fmt.Errorf("read times for audit log: %w", err)
+	}
+
+	return auditTimes{
+		atime: platform.GetAtime(fileInfo).Format(time.RFC3339Nano),
+		mtime: fileInfo.ModTime().Format(time.RFC3339Nano),
+	}, nil
+}
+
+// record appends the change of file's times from before to their current values.
+func (a *auditLogger) record(file string, before auditTimes, noDeref bool) error {
+	after, err := readAuditTimes(file, noDeref)
+	if err != nil {
+		return err
+	}
+
+	line := strings.Join([]string{
+		a.runTime,
+		file,
+		before.atime,
+		after.atime,
+		before.mtime,
+		after.mtime,
+	}, "\t") + "\n"
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	logFile, err := filesystem.Default.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, auditLogPerm)
+	if err != nil {
+		return fmt.Errorf("open audit log: %w", err)
+	}
+
+	if _, err := logFile.WriteString(line); err != nil {
+		logFile.Close()
+
+		return fmt.Errorf("write audit log %s: %w", a.path, err)
+	}
+
+	if err := logFile.Close(); err != nil {
+		return fmt.Errorf("close audit log %s: %w", a.path, err)
+	}
+
+	return nil
+}
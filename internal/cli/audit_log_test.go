@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file appends per-file records of time changes to the --audit-log file.
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// realFS is the os-backed file system, captured before any test swaps in a mock.
+var realFS = filesystem.Default
+
+func Test_applyToFiles_auditLog(t *testing.T) {
+	runTime := time.Date(2025, 7, 13, 15, 0, 0, 0, time.UTC)
+	oldTime := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	format := func(t time.Time) string { return t.Format(time.RFC3339Nano) }
+
+	tests := []struct {
+		name        string
+		existing    []string
+		files       []string
+		changeTimes int
+		noCreate    bool
+		wantLines   func(dir string) []string
+	}{
+		{
+			name:        "existing and created files",
+			existing:    []string{"a.txt"},
+			files:       []string{"a.txt", "b.txt"},
+			changeTimes: core.ChAtime | core.ChMtime,
+			noCreate:    false,
+			wantLines: func(dir string) []string {
+				return []string{
+					strings.Join([]string{
+						format(runTime), filepath.Join(dir, "a.txt"),
+						format(oldTime), format(stamp), format(oldTime), format(stamp),
+					}, "\t"),
+					strings.Join([]string{
+						format(runTime), filepath.Join(dir, "b.txt"),
+						"-", format(stamp), "-", format(stamp),
+					}, "\t"),
+				}
+			},
+		},
+		{
+			name:        "unchanged access time",
+			existing:    []string{"a.txt"},
+			files:       []string{"a.txt"},
+			changeTimes: core.ChMtime,
+			noCreate:    false,
+			wantLines: func(dir string) []string {
+				return []string{
+					strings.Join([]string{
+						format(runTime), filepath.Join(dir, "a.txt"),
+						format(oldTime), format(oldTime), format(oldTime), format(stamp),
+					}, "\t"),
+				}
+			},
+		},
+		{
+			name:        "files skipped by no-create are not recorded",
+			existing:    []string{"a.txt"},
+			files:       []string{"a.txt", "b.txt"},
+			changeTimes: core.ChAtime | core.ChMtime,
+			noCreate:    true,
+			wantLines: func(dir string) []string {
+				return []string{
+					strings.Join([]string{
+						format(runTime), filepath.Join(dir, "a.txt"),
+						format(oldTime), format(stamp), format(oldTime), format(stamp),
+					}, "\t"),
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem.Default = realFS
+
+			dir := t.TempDir()
+			logPath := filepath.Join(dir, "audit.log")
+
+			for _, name := range tt.existing {
+				path := filepath.Join(dir, name)
+				if err := os.WriteFile(path, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.Chtimes(path, oldTime, oldTime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			files := make([]string, 0, len(tt.files))
+			for _, name := range tt.files {
+				files = append(files, filepath.Join(dir, name))
+			}
+
+			opts := touchOptions{
+				changeTimes: tt.changeTimes,
+				noCreate:    tt.noCreate,
+				auditLog:    logPath,
+				audit:       newAuditLogger(logPath, runTime),
+			}
+			if err := applyToFiles(opts, stamp, stamp, files); err != nil {
+				t.Fatalf("applyToFiles() error = %v", err)
+			}
+
+			data, err := os.ReadFile(logPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Files are touched concurrently, so records may be in any order.
+			got := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+			slices.Sort(got)
+
+			if want := tt.wantLines(dir); !slices.Equal(got, want) {
+				t.Errorf("audit log = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -22,46 +22,304 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/nicholas-fedor/touch/internal/core"
 	"github.com/nicholas-fedor/touch/internal/timestamp"
 )
 
-// calculateTimestamps computes the access and modification times based on flags and args.
-// Handles reference, stamp, date, obsolete usage, or defaults to current time.
-// Returns the computed times and updated files list or an error.
+// calculateTimestamps computes the access and modification times from the time source
+// selected in opts, falling back to the current time when none is. Without a source, a
+// stamp-shaped first operand followed by files is taken as an obsolete POSIX timestamp.
+// Returns the computed times, the remaining files, and whether the times are the current
+// time, or an error.
 func calculateTimestamps(
-	noDeref bool,
-	refFilePath, tStamp, dateStr string,
+	opts touchOptions,
 	files []string,
-) (core.Time, core.Time, []string, error) {
+) (core.Time, core.Time, []string, bool, error) {
 	var accessTime, modTime core.Time
 
 	dateSet := false
 
-	var err error
+	// Interpret -t, -d, and obsolete stamps in UTC with --utc, or the zone of --timezone.
+	loc, err := location(opts)
+	if err != nil {
+		return core.Time{}, core.Time{}, nil, false, err
+	}
+
+	// Read the clock once, so relative dates and the default time agree.
+	now := core.NowFrom(opts.clock)
 
 	// Use switch to determine timestamp source, addressing ifElseChain lint rule.
 	switch {
-	case refFilePath != "":
-		accessTime, modTime, err = timestamp.GetTimesFromRef(refFilePath, noDeref)
+	case opts.refFilePath != "" && opts.reduce != "":
+		refFilePaths := strings.Split(opts.refFilePath, ",")
+
+		accessTime, modTime, err = timestamp.GetTimesFromRefsJobs(
+			refFilePaths,
+			opts.noDeref,
+			opts.reduce,
+			opts.jobs,
+		)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get reduced reference times: %w", err)
+		}
+
+		dateSet = true
+	case opts.refFilePath != "" && (opts.preferBirth || opts.changeTimes&core.ChBtime != 0):
+		accessTime, modTime, err = timestamp.GetTimesFromRefPreferBirth(opts.refFilePath, opts.noDeref)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get reference times: %w", err)
+		}
+
+		dateSet = true
+	case opts.refFilePath != "":
+		accessTime, modTime, err = timestamp.GetTimesFromRef(opts.refFilePath, opts.noDeref)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get reference times: %w", err)
+		}
+
+		dateSet = true
+	case opts.refAtime != "":
+		accessTime, modTime, err = splitRefTimes(opts.refAtime, opts.refMtime, opts.noDeref)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, err
+		}
+
+		dateSet = true
+	case opts.newestUnder != "":
+		accessTime, modTime, err = timestamp.GetTimesFromNewestUnder(opts.newestUnder, opts.noDeref)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get newest entry times: %w", err)
+		}
+
+		dateSet = true
+	case opts.newestType != "":
+		accessTime, modTime, err = timestamp.GetTimesFromNewestType(opts.typeDir, opts.newestType)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get newest file of type times: %w", err)
+		}
+
+		dateSet = true
+	case opts.newestAtime != "":
+		refFilePaths := strings.Split(opts.newestAtime, ",")
+
+		accessTime, modTime, err = timestamp.GetTimesFromNewestAtime(
+			refFilePaths,
+			opts.noDeref,
+			opts.jobs,
+		)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get newest access time: %w", err)
+		}
+
+		dateSet = true
+	case opts.oldestAtime != "":
+		refFilePaths := strings.Split(opts.oldestAtime, ",")
+
+		accessTime, modTime, err = timestamp.GetTimesFromOldestAtime(
+			refFilePaths,
+			opts.noDeref,
+			opts.jobs,
+		)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get oldest access time: %w", err)
+		}
+
+		dateSet = true
+	case opts.maxChange != "":
+		refFilePaths := strings.Split(opts.maxChange, ",")
+
+		accessTime, err = timestamp.GetTimeFromMaxChange(refFilePaths, opts.noDeref, opts.jobs)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get latest change time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.oldestCtime != "":
+		refFilePaths := strings.Split(opts.oldestCtime, ",")
+
+		accessTime, err = timestamp.GetTimeFromOldestCtime(refFilePaths, opts.noDeref, opts.jobs)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get oldest change time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.refGlob != "" && !opts.minAfter.IsZero():
+		accessTime, err = timestamp.GetTimeFromMinAfter(opts.refGlob, opts.minAfter, opts.noDeref)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get earliest time after floor: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.refGlob != "":
+		accessTime, err = timestamp.GetTimeFromPercentile(opts.refGlob, opts.percentile, opts.noDeref)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get percentile time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.modeGlob != "":
+		accessTime, err = timestamp.GetTimeFromModeGlob(opts.modeGlob, opts.noDeref)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get most common time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.mountRef != "":
+		accessTime, err = timestamp.GetTimeFromMount(opts.mountRef)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get mount time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.sshRef != "":
+		accessTime, modTime, err = timestamp.GetTimesFromSSH(opts.sshRef)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get remote reference times: %w", err)
+		}
+
+		dateSet = true
+	case opts.bootRef:
+		accessTime, err = timestamp.GetTimeFromBoot()
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get boot time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.procFDs != "":
+		accessTime, err = timestamp.GetTimeFromProcFDs(opts.procFDs)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get process open file time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.unitRef != "":
+		accessTime, err = timestamp.GetTimeFromUnit(opts.unitRef)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get unit active time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.selfAtime:
+		accessTime, err = timestamp.GetTimeFromSelfAtime()
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get executable access time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.buildInfo != "":
+		accessTime, err = timestamp.GetTimeFromBuildInfo(opts.buildInfo)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get buildinfo time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.rsyncList != "":
+		accessTime, err = timestamp.GetTimeFromRsyncList(opts.rsyncList)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get rsync listing time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.warc != "":
+		accessTime, err = timestamp.GetTimeFromWARC(opts.warc, opts.warcTarget)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get WARC record time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.ociRef != "":
+		accessTime, err = timestamp.GetTimeFromOCI(opts.ociRef)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get image created time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.lockfile != "":
+		accessTime, err = timestamp.GetTimeFromLockfile(opts.lockfile, opts.lockEntry, opts.lockPath)
 		if err != nil {
-			return core.Time{}, core.Time{}, nil, fmt.Errorf("get reference times: %w", err)
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get lockfile entry time: %w", err)
 		}
 
+		modTime = accessTime
 		dateSet = true
-	case tStamp != "":
-		accessTime, err = timestamp.ParsePosixTime(tStamp)
+	case opts.fsImage != "":
+		accessTime, err = timestamp.GetTimeFromFSImage(opts.fsImage)
 		if err != nil {
-			return core.Time{}, core.Time{}, nil, fmt.Errorf("parse POSIX stamp: %w", err)
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get filesystem image time: %w", err)
 		}
 
 		modTime = accessTime
 		dateSet = true
-	case dateStr != "":
-		newTime, err := timestamp.ParseDate(dateStr)
+	case opts.ancestorRef, opts.normLinks, opts.normFS, opts.sizeTime, opts.statTime != "",
+		opts.adjust != 0:
+		// Times are resolved per file when touching; only suppress the obsolete stamp and default.
+		dateSet = true
+	case opts.gitNewest != "":
+		accessTime, err = timestamp.GetTimeFromGitNewest(opts.gitNewest)
 		if err != nil {
-			return core.Time{}, core.Time{}, nil, fmt.Errorf("parse date: %w", err)
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get git commit time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.seed != "":
+		window := opts.seedWindow
+		if window == "" {
+			window = timestamp.DefaultSeedWindow
+		}
+
+		start, end, err := timestamp.ParseSeedWindow(window)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("parse seed window: %w", err)
+		}
+
+		accessTime = timestamp.TimeFromSeed(opts.seed, start, end)
+		modTime = accessTime
+		dateSet = true
+	case opts.nextCron != "":
+		accessTime, err = timestamp.NextCronTime(opts.nextCron, now)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("get next cron time: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.setAtime != "" || opts.setMtime != "":
+		accessTime, modTime, err = independentTimes(opts.setAtime, opts.setMtime, loc, now)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, err
+		}
+
+		dateSet = true
+	case opts.tStamp != "":
+		accessTime, err = timestamp.ParsePosixTimeAt(opts.tStamp, loc, now)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("parse POSIX stamp: %w", err)
+		}
+
+		modTime = accessTime
+		dateSet = true
+	case opts.dateStr != "":
+		newTime, err := timestamp.ParseDateAt(opts.dateStr, opts.dateFormat, loc, now)
+		if err != nil {
+			return core.Time{}, core.Time{}, nil, false, fmt.Errorf("parse date: %w", err)
 		}
 
 		accessTime = newTime
@@ -69,15 +327,22 @@ func calculateTimestamps(
 		dateSet = true
 	}
 
+	// Handle --swap, which processFlags only accepts together with -r.
+	if opts.swap {
+		accessTime, modTime = modTime, accessTime
+	}
+
 	// Handle obsolete usage if no source set: treat first arg as POSIX timestamp.
-	if !dateSet && len(files) >= 1 {
-		t, err := timestamp.ParsePosixTime(files[0])
+	// Only stamp-shaped args followed by at least one file are considered, so names like
+	// "07+31430", or a lone file named like a stamp, are never consumed.
+	if !dateSet && !opts.noObsolete && len(files) >= 2 && timestamp.IsPosixStamp(files[0]) {
+		t, err := timestamp.ParsePosixTimeAt(files[0], loc, now)
 		if err == nil {
 			accessTime = t
 			modTime = t
 			dateSet = true
 
-			if os.Getenv("POSIXLY_CORRECT") == "" {
+			if !opts.quiet && os.Getenv("POSIXLY_CORRECT") == "" {
 				fmt.Fprintf(
 					os.Stderr,
 					"warning: 'touch %s' is obsolete; use 'touch -t'\n",
@@ -91,10 +356,114 @@ func calculateTimestamps(
 
 	// Default to current time if still not set.
 	if !dateSet {
-		now := core.Now()
 		accessTime = now
 		modTime = now
 	}
 
-	return accessTime, modTime, files, nil
+	// Handle --truncate-to, applied to whichever source provided the times.
+	accessTime, modTime, err = truncateTimes(opts, accessTime, modTime)
+	if err != nil {
+		return core.Time{}, core.Time{}, nil, false, err
+	}
+
+	return accessTime, modTime, files, !dateSet, nil
+}
+
+// truncateTimes truncates accessTime and modTime to the unit of --truncate-to, if given, on
+// the wall clock of --utc or --timezone rather than that of the times' own locations.
+func truncateTimes(opts touchOptions, accessTime, modTime core.Time) (core.Time, core.Time, error) {
+	if opts.truncateTo == "" {
+		return accessTime, modTime, nil
+	}
+
+	loc, err := location(opts)
+	if err != nil {
+		return core.Time{}, core.Time{}, err
+	}
+
+	accessTime, err = timestamp.TruncateTime(accessTime.In(loc), opts.truncateTo)
+	if err != nil {
+		return core.Time{}, core.Time{}, fmt.Errorf("truncate access time: %w", err)
+	}
+
+	modTime, err = timestamp.TruncateTime(modTime.In(loc), opts.truncateTo)
+	if err != nil {
+		return core.Time{}, core.Time{}, fmt.Errorf("truncate modification time: %w", err)
+	}
+
+	return accessTime, modTime, nil
+}
+
+// splitRefTimes returns the access time of atimeRef and the modification time of mtimeRef.
+func splitRefTimes(atimeRef, mtimeRef string, noDeref bool) (core.Time, core.Time, error) {
+	accessTime, _, err := timestamp.GetTimesFromRef(atimeRef, noDeref)
+	if err != nil {
+		return core.Time{}, core.Time{}, fmt.Errorf("get access time reference: %w", err)
+	}
+
+	_, modTime, err := timestamp.GetTimesFromRef(mtimeRef, noDeref)
+	if err != nil {
+		return core.Time{}, core.Time{}, fmt.Errorf("get modification time reference: %w", err)
+	}
+
+	return accessTime, modTime, nil
+}
+
+// independentTimes parses the --set-atime and --set-mtime values in loc, resolving relative
+// dates against now. A time not given is left at now; processFlags ensures only the given
+// ones are changed on existing files.
+func independentTimes(
+	setAtime, setMtime string,
+	loc *time.Location,
+	now core.Time,
+) (core.Time, core.Time, error) {
+	accessTime, modTime := now, now
+
+	var err error
+
+	if setAtime != "" {
+		accessTime, err = parseTimeArg(setAtime, loc, now)
+		if err != nil {
+			return core.Time{}, core.Time{}, fmt.Errorf("parse access time: %w", err)
+		}
+	}
+
+	if setMtime != "" {
+		modTime, err = parseTimeArg(setMtime, loc, now)
+		if err != nil {
+			return core.Time{}, core.Time{}, fmt.Errorf("parse modification time: %w", err)
+		}
+	}
+
+	return accessTime, modTime, nil
+}
+
+// parseTimeArg parses value in loc as a [[CC]YY]MMDDhhmm[.ss] stamp, as with -t, or failing
+// that as a date string, as with -d, resolving relative and partial dates against now.
+func parseTimeArg(value string, loc *time.Location, now core.Time) (core.Time, error) {
+	if timestamp.IsPosixStamp(value) {
+		if t, err := timestamp.ParsePosixTimeAt(value, loc, now); err == nil {
+			return t, nil
+		}
+	}
+
+	t, err := timestamp.ParseDateAt(value, "", loc, now)
+	if err != nil {
+		return core.Time{}, fmt.Errorf("parse %q: %w", value, err)
+	}
+
+	return t, nil
+}
+
+// location returns the location -t, -d, and obsolete stamps are interpreted in: UTC with
+// --utc, the named zone with --timezone, and local time otherwise.
+func location(opts touchOptions) (*time.Location, error) {
+	switch {
+	case opts.utc:
+		return time.UTC, nil
+	case opts.timezone != "":
+		return timestamp.LoadTimezone(opts.timezone)
+	default:
+		return time.Local, nil
+	}
 }
@@ -21,12 +21,15 @@ package cli
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"reflect"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/nicholas-fedor/touch/internal/core"
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
 	"github.com/nicholas-fedor/touch/internal/filesystem"
 	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
 	"github.com/nicholas-fedor/touch/internal/platform"
@@ -35,23 +38,11 @@ import (
 
 func Test_calculateTimestamps(t *testing.T) {
 	fixedNow := time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local)
-	oldNow := core.Now
-
-	defer func() { core.Now = oldNow }()
-
-	oldTimestampNow := timestamp.Now
-
-	defer func() { timestamp.Now = oldTimestampNow }()
-
-	core.Now = func() core.Time { return fixedNow }
-	timestamp.Now = func() timestamp.Time { return fixedNow }
+	clock := core.ClockFunc(func() core.Time { return fixedNow })
 
 	type args struct {
-		noDeref     bool
-		refFilePath string
-		tStamp      string
-		dateStr     string
-		files       []string
+		opts  touchOptions
+		files []string
 	}
 
 	tests := []struct {
@@ -68,11 +59,8 @@ func Test_calculateTimestamps(t *testing.T) {
 		{
 			name: "default current time",
 			args: args{
-				noDeref:     false,
-				refFilePath: "",
-				tStamp:      "",
-				dateStr:     "",
-				files:       []string{},
+				opts:  touchOptions{},
+				files: []string{},
 			},
 			mockFSSetup: nil,
 			setupEnv:    nil,
@@ -85,17 +73,21 @@ func Test_calculateTimestamps(t *testing.T) {
 		{
 			name: "from reference no deref false",
 			args: args{
-				noDeref:     false,
-				refFilePath: "ref.txt",
-				tStamp:      "",
-				dateStr:     "",
-				files:       []string{},
+				opts: touchOptions{
+					refFilePath: "ref.txt",
+				},
+				files: []string{},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "ref.txt").
 					Return(&mockFileInfo{access: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local), mod: time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local)}, nil)
 			},
-			setupEnv: func(_ *testing.T) {
+			setupEnv: func(t *testing.T) {
+				t.Helper()
+
+				oldGetAtime := platform.GetAtime
+				t.Cleanup(func() { platform.GetAtime = oldGetAtime })
+
 				platform.GetAtime = func(fi os.FileInfo) core.Time {
 					return fi.(*mockFileInfo).access
 				}
@@ -109,17 +101,22 @@ func Test_calculateTimestamps(t *testing.T) {
 		{
 			name: "from reference no deref true",
 			args: args{
-				noDeref:     true,
-				refFilePath: "ref.txt",
-				tStamp:      "",
-				dateStr:     "",
-				files:       []string{},
+				opts: touchOptions{
+					noDeref:     true,
+					refFilePath: "ref.txt",
+				},
+				files: []string{},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Lstat", "ref.txt").
 					Return(&mockFileInfo{access: time.Date(2025, 7, 13, 15, 0, 0, 0, time.Local), mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
 			},
-			setupEnv: func(_ *testing.T) {
+			setupEnv: func(t *testing.T) {
+				t.Helper()
+
+				oldGetAtime := platform.GetAtime
+				t.Cleanup(func() { platform.GetAtime = oldGetAtime })
+
 				platform.GetAtime = func(fi os.FileInfo) core.Time {
 					return fi.(*mockFileInfo).access
 				}
@@ -130,14 +127,208 @@ func Test_calculateTimestamps(t *testing.T) {
 			wantErr:    false,
 			wantStderr: "",
 		},
+		{
+			name: "from reference swapped",
+			args: args{
+				opts: touchOptions{
+					refFilePath: "ref.txt",
+					swap:        true,
+				},
+				files: []string{},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "ref.txt").
+					Return(&mockFileInfo{access: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local), mod: time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local)}, nil)
+			},
+			setupEnv: func(t *testing.T) {
+				t.Helper()
+
+				oldGetAtime := platform.GetAtime
+				t.Cleanup(func() { platform.GetAtime = oldGetAtime })
+
+				platform.GetAtime = func(fi os.FileInfo) core.Time {
+					return fi.(*mockFileInfo).access
+				}
+			},
+			wantAccess: time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+			wantMod:    time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+			wantFiles:  []string{},
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name: "from split access and modification references",
+			args: args{
+				opts: touchOptions{
+					refAtime: "a.txt",
+					refMtime: "m.txt",
+				},
+				files: []string{},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{access: time.Date(2025, 7, 13, 16, 0, 0, 0, time.Local), mod: time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local)}, nil)
+				m.On("Stat", "m.txt").
+					Return(&mockFileInfo{access: time.Date(2025, 7, 13, 11, 0, 0, 0, time.Local), mod: time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local)}, nil)
+			},
+			setupEnv: func(t *testing.T) {
+				t.Helper()
+
+				oldGetAtime := platform.GetAtime
+				t.Cleanup(func() { platform.GetAtime = oldGetAtime })
+
+				platform.GetAtime = func(fi os.FileInfo) core.Time {
+					return fi.(*mockFileInfo).access
+				}
+			},
+			wantAccess: time.Date(2025, 7, 13, 16, 0, 0, 0, time.Local),
+			wantMod:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+			wantFiles:  []string{},
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name: "from split references with missing modification reference",
+			args: args{
+				opts: touchOptions{
+					refAtime: "a.txt",
+					refMtime: "missing.txt",
+				},
+				files: []string{"file.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{access: time.Date(2025, 7, 13, 16, 0, 0, 0, time.Local), mod: time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local)}, nil)
+				m.On("Stat", "missing.txt").Return(nil, os.ErrNotExist)
+			},
+			setupEnv:   nil,
+			wantAccess: core.Time{},
+			wantMod:    core.Time{},
+			wantFiles:  nil,
+			wantErr:    true,
+			wantStderr: "",
+		},
+		{
+			name: "from reduced references",
+			args: args{
+				opts: touchOptions{
+					refFilePath: "a.txt,b.txt",
+					reduce:      "max",
+				},
+				files: []string{},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{access: time.Date(2025, 7, 13, 16, 0, 0, 0, time.Local), mod: time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local)}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{access: time.Date(2025, 7, 13, 11, 0, 0, 0, time.Local), mod: time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local)}, nil)
+			},
+			setupEnv: func(t *testing.T) {
+				t.Helper()
+
+				oldGetAtime := platform.GetAtime
+				t.Cleanup(func() { platform.GetAtime = oldGetAtime })
+
+				platform.GetAtime = func(fi os.FileInfo) core.Time {
+					return fi.(*mockFileInfo).access
+				}
+			},
+			wantAccess: time.Date(2025, 7, 13, 16, 0, 0, 0, time.Local),
+			wantMod:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+			wantFiles:  []string{},
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name: "from seed in window",
+			args: args{
+				opts: touchOptions{
+					seed:       "project-a",
+					seedWindow: "2020-01-01T00:00:00Z,2021-01-01T00:00:00Z",
+				},
+				files: []string{"file.txt"},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  time.Date(2020, 9, 18, 11, 23, 32, 0, time.UTC),
+			wantMod:     time.Date(2020, 9, 18, 11, 23, 32, 0, time.UTC),
+			wantFiles:   []string{"file.txt"},
+			wantErr:     false,
+			wantStderr:  "",
+		},
+		{
+			name: "from next cron occurrence",
+			args: args{
+				opts: touchOptions{
+					nextCron: "30 * * * *",
+				},
+				files: []string{"file.txt"},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  time.Date(2025, 7, 13, 0, 30, 0, 0, time.Local),
+			wantMod:     time.Date(2025, 7, 13, 0, 30, 0, 0, time.Local),
+			wantFiles:   []string{"file.txt"},
+			wantErr:     false,
+			wantStderr:  "",
+		},
+		{
+			name: "error from invalid cron",
+			args: args{
+				opts: touchOptions{
+					nextCron: "* * *",
+				},
+				files: []string{"file.txt"},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  core.Time{},
+			wantMod:     core.Time{},
+			wantFiles:   nil,
+			wantErr:     true,
+			wantStderr:  "",
+		},
+		{
+			name: "from date truncated to hour",
+			args: args{
+				opts: touchOptions{
+					dateStr:    "2025-07-13T14:37:21",
+					truncateTo: "hour",
+				},
+				files: []string{"file.txt"},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+			wantMod:     time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+			wantFiles:   []string{"file.txt"},
+			wantErr:     false,
+			wantStderr:  "",
+		},
+		{
+			name: "error from seed window",
+			args: args{
+				opts: touchOptions{
+					seed:       "project-a",
+					seedWindow: "2021-01-01T00:00:00Z,2020-01-01T00:00:00Z",
+				},
+				files: []string{"file.txt"},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  core.Time{},
+			wantMod:     core.Time{},
+			wantFiles:   nil,
+			wantErr:     true,
+			wantStderr:  "",
+		},
 		{
 			name: "from stamp",
 			args: args{
-				noDeref:     false,
-				refFilePath: "",
-				tStamp:      "2507131430",
-				dateStr:     "",
-				files:       []string{},
+				opts: touchOptions{
+					tStamp: "2507131430",
+				},
+				files: []string{},
 			},
 			mockFSSetup: nil,
 			setupEnv:    nil,
@@ -150,11 +341,10 @@ func Test_calculateTimestamps(t *testing.T) {
 		{
 			name: "from date full",
 			args: args{
-				noDeref:     false,
-				refFilePath: "",
-				tStamp:      "",
-				dateStr:     "2025-07-13 14:30:00",
-				files:       []string{},
+				opts: touchOptions{
+					dateStr: "2025-07-13 14:30:00",
+				},
+				files: []string{},
 			},
 			mockFSSetup: nil,
 			setupEnv:    nil,
@@ -164,14 +354,47 @@ func Test_calculateTimestamps(t *testing.T) {
 			wantErr:     false,
 			wantStderr:  "",
 		},
+		{
+			name: "from date in UTC",
+			args: args{
+				opts: touchOptions{
+					dateStr: "2025-07-13 14:30:00",
+					utc:     true,
+				},
+				files: []string{},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+			wantMod:     time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+			wantFiles:   []string{},
+			wantErr:     false,
+			wantStderr:  "",
+		},
+		{
+			name: "from stamp in UTC",
+			args: args{
+				opts: touchOptions{
+					tStamp: "202507131430.15",
+					utc:    true,
+				},
+				files: []string{},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  time.Date(2025, 7, 13, 14, 30, 15, 0, time.UTC),
+			wantMod:     time.Date(2025, 7, 13, 14, 30, 15, 0, time.UTC),
+			wantFiles:   []string{},
+			wantErr:     false,
+			wantStderr:  "",
+		},
 		{
 			name: "from date time only",
 			args: args{
-				noDeref:     false,
-				refFilePath: "",
-				tStamp:      "",
-				dateStr:     "14:30:00",
-				files:       []string{},
+				opts: touchOptions{
+					dateStr: "14:30:00",
+				},
+				files: []string{},
 			},
 			mockFSSetup: nil,
 			setupEnv:    nil,
@@ -184,11 +407,8 @@ func Test_calculateTimestamps(t *testing.T) {
 		{
 			name: "obsolete usage success",
 			args: args{
-				noDeref:     false,
-				refFilePath: "",
-				tStamp:      "",
-				dateStr:     "",
-				files:       []string{"2507131430", "file1.txt", "file2.txt"},
+				opts:  touchOptions{},
+				files: []string{"2507131430", "file1.txt", "file2.txt"},
 			},
 			mockFSSetup: nil,
 			setupEnv:    nil,
@@ -198,14 +418,25 @@ func Test_calculateTimestamps(t *testing.T) {
 			wantErr:     false,
 			wantStderr:  "warning: 'touch 2507131430' is obsolete; use 'touch -t'\n",
 		},
+		{
+			name: "obsolete usage quiet no warn",
+			args: args{
+				opts:  touchOptions{quiet: true},
+				files: []string{"2507131430", "file1.txt"},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantMod:     time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantFiles:   []string{"file1.txt"},
+			wantErr:     false,
+			wantStderr:  "",
+		},
 		{
 			name: "obsolete usage with POSIXLY_CORRECT no warn",
 			args: args{
-				noDeref:     false,
-				refFilePath: "",
-				tStamp:      "",
-				dateStr:     "",
-				files:       []string{"2507131430", "file1.txt"},
+				opts:  touchOptions{},
+				files: []string{"2507131430", "file1.txt"},
 			},
 			mockFSSetup: nil,
 			setupEnv: func(t *testing.T) {
@@ -221,11 +452,8 @@ func Test_calculateTimestamps(t *testing.T) {
 		{
 			name: "obsolete invalid fallback current",
 			args: args{
-				noDeref:     false,
-				refFilePath: "",
-				tStamp:      "",
-				dateStr:     "",
-				files:       []string{"invalid", "file1.txt"},
+				opts:  touchOptions{},
+				files: []string{"invalid", "file1.txt"},
 			},
 			mockFSSetup: nil,
 			setupEnv:    nil,
@@ -235,14 +463,55 @@ func Test_calculateTimestamps(t *testing.T) {
 			wantErr:     false,
 			wantStderr:  "",
 		},
+		{
+			name: "obsolete not stamp-shaped kept as file",
+			args: args{
+				opts:  touchOptions{},
+				files: []string{"07+31430", "file1.txt"},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  fixedNow,
+			wantMod:     fixedNow,
+			wantFiles:   []string{"07+31430", "file1.txt"},
+			wantErr:     false,
+			wantStderr:  "",
+		},
+		{
+			name: "lone stamp-shaped file name kept as file",
+			args: args{
+				opts:  touchOptions{},
+				files: []string{"2507131430"},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  fixedNow,
+			wantMod:     fixedNow,
+			wantFiles:   []string{"2507131430"},
+			wantErr:     false,
+			wantStderr:  "",
+		},
+		{
+			name: "obsolete stamp-shaped with seconds",
+			args: args{
+				opts:  touchOptions{},
+				files: []string{"07131430.15", "file1.txt"},
+			},
+			mockFSSetup: nil,
+			setupEnv:    nil,
+			wantAccess:  time.Date(2025, 7, 13, 14, 30, 15, 0, time.Local),
+			wantMod:     time.Date(2025, 7, 13, 14, 30, 15, 0, time.Local),
+			wantFiles:   []string{"file1.txt"},
+			wantErr:     false,
+			wantStderr:  "warning: 'touch 07131430.15' is obsolete; use 'touch -t'\n",
+		},
 		{
 			name: "error from ref",
 			args: args{
-				noDeref:     false,
-				refFilePath: "invalid_ref.txt",
-				tStamp:      "",
-				dateStr:     "",
-				files:       []string{},
+				opts: touchOptions{
+					refFilePath: "invalid_ref.txt",
+				},
+				files: []string{},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "invalid_ref.txt").Return(nil, os.ErrNotExist)
@@ -257,11 +526,10 @@ func Test_calculateTimestamps(t *testing.T) {
 		{
 			name: "error from stamp",
 			args: args{
-				noDeref:     false,
-				refFilePath: "",
-				tStamp:      "invalid",
-				dateStr:     "",
-				files:       []string{},
+				opts: touchOptions{
+					tStamp: "invalid",
+				},
+				files: []string{},
 			},
 			mockFSSetup: nil,
 			setupEnv:    nil,
@@ -274,11 +542,10 @@ func Test_calculateTimestamps(t *testing.T) {
 		{
 			name: "error from date",
 			args: args{
-				noDeref:     false,
-				refFilePath: "",
-				tStamp:      "",
-				dateStr:     "invalid",
-				files:       []string{},
+				opts: touchOptions{
+					dateStr: "invalid",
+				},
+				files: []string{},
 			},
 			mockFSSetup: nil,
 			setupEnv:    nil,
@@ -308,13 +575,9 @@ func Test_calculateTimestamps(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stderr = w
 
-			got, got1, got2, err := calculateTimestamps(
-				tt.args.noDeref,
-				tt.args.refFilePath,
-				tt.args.tStamp,
-				tt.args.dateStr,
-				tt.args.files,
-			)
+			tt.args.opts.clock = clock
+
+			got, got1, got2, _, err := calculateTimestamps(tt.args.opts, tt.args.files)
 
 			w.Close()
 
@@ -348,3 +611,279 @@ func Test_calculateTimestamps(t *testing.T) {
 		})
 	}
 }
+
+func Test_calculateTimestamps_isNow(t *testing.T) {
+	fixedNow := time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local)
+	clock := core.ClockFunc(func() core.Time { return fixedNow })
+
+	tests := []struct {
+		name      string
+		opts      touchOptions
+		files     []string
+		wantIsNow bool
+	}{
+		{
+			name:      "default current time",
+			opts:      touchOptions{monotonic: true, clock: clock},
+			files:     []string{"file.txt"},
+			wantIsNow: true,
+		},
+		{
+			name:      "date source",
+			opts:      touchOptions{monotonic: true, clock: clock, dateStr: "2024-01-02"},
+			files:     []string{"file.txt"},
+			wantIsNow: false,
+		},
+		{
+			name:      "obsolete stamp",
+			opts:      touchOptions{monotonic: true, clock: clock, quiet: true},
+			files:     []string{"2507131430", "file.txt"},
+			wantIsNow: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, isNow, err := calculateTimestamps(tt.opts, tt.files)
+			if err != nil {
+				t.Fatalf("calculateTimestamps() error = %v", err)
+			}
+
+			if isNow != tt.wantIsNow {
+				t.Errorf("calculateTimestamps() isNow = %v, want %v", isNow, tt.wantIsNow)
+			}
+		})
+	}
+}
+
+func Test_calculateTimestamps_setTimes(t *testing.T) {
+	fixedNow := time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local)
+	clock := core.ClockFunc(func() core.Time { return fixedNow })
+
+	tests := []struct {
+		name      string
+		opts      touchOptions
+		wantAtime core.Time
+		wantMtime core.Time
+		wantErr   error
+	}{
+		{
+			name:      "access time only",
+			opts:      touchOptions{setAtime: "2024-01-02 03:04:05"},
+			wantAtime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local),
+			wantMtime: fixedNow,
+			wantErr:   nil,
+		},
+		{
+			name:      "modification time only as a stamp",
+			opts:      touchOptions{setMtime: "202406070809.10"},
+			wantAtime: fixedNow,
+			wantMtime: time.Date(2024, 6, 7, 8, 9, 10, 0, time.Local),
+			wantErr:   nil,
+		},
+		{
+			name:      "both",
+			opts:      touchOptions{setAtime: "202401020304", setMtime: "2024-06-07T08:09:10Z"},
+			wantAtime: time.Date(2024, 1, 2, 3, 4, 0, 0, time.Local),
+			wantMtime: time.Date(2024, 6, 7, 8, 9, 10, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "both in UTC",
+			opts:      touchOptions{setAtime: "202401020304", setMtime: "2024-06-07 08:09", utc: true},
+			wantAtime: time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC),
+			wantMtime: time.Date(2024, 6, 7, 8, 9, 0, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "invalid access time",
+			opts:      touchOptions{setAtime: "not a date", setMtime: "202401020304"},
+			wantAtime: core.Time{},
+			wantMtime: core.Time{},
+			wantErr:   touchErrors.ErrUnsupportedDateFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.clock = clock
+
+			got, got1, files, _, err := calculateTimestamps(tt.opts, []string{"file.txt"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("calculateTimestamps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !got.Equal(tt.wantAtime) || !got1.Equal(tt.wantMtime) {
+				t.Errorf("calculateTimestamps() got = %v, got1 = %v, want %v, %v",
+					got, got1, tt.wantAtime, tt.wantMtime)
+			}
+
+			if !slices.Equal(files, []string{"file.txt"}) {
+				t.Errorf("calculateTimestamps() files = %v, want [file.txt]", files)
+			}
+		})
+	}
+}
+
+func Test_calculateTimestamps_timezone(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	t.Setenv("POSIXLY_CORRECT", "1") // Silence the obsolete usage warning.
+
+	tests := []struct {
+		name    string
+		opts    touchOptions
+		files   []string
+		want    []core.Time // Any of these instants is accepted.
+		wantErr error
+	}{
+		{
+			name:  "date in zone",
+			opts:  touchOptions{dateStr: "2025-07-13 14:30", timezone: "America/New_York"},
+			files: []string{"file.txt"},
+			want:  []core.Time{time.Date(2025, 7, 13, 18, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "stamp in zone",
+			opts:  touchOptions{tStamp: "202501021430", timezone: "America/New_York"},
+			files: []string{"file.txt"},
+			want:  []core.Time{time.Date(2025, 1, 2, 19, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "obsolete stamp in zone",
+			opts:  touchOptions{timezone: "America/New_York"},
+			files: []string{"202501021430", "file.txt"},
+			want:  []core.Time{time.Date(2025, 1, 2, 19, 30, 0, 0, time.UTC)},
+		},
+		{
+			// 01:30 happens twice as clocks fall back, first in EDT and then in EST.
+			name:  "wall time repeated by DST fold",
+			opts:  touchOptions{dateStr: "2025-11-02 01:30", timezone: "America/New_York"},
+			files: []string{"file.txt"},
+			want: []core.Time{
+				time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC),
+				time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:  "explicit offset resolves DST fold",
+			opts:  touchOptions{dateStr: "2025-11-02 01:30:00 -0500", timezone: "America/New_York"},
+			files: []string{"file.txt"},
+			want:  []core.Time{time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC)},
+		},
+		{
+			name: "custom format in zone",
+			opts: touchOptions{
+				dateStr:    "13.07.2025 14:30",
+				dateFormat: "02.01.2006 15:04",
+				timezone:   "America/New_York",
+			},
+			files: []string{"file.txt"},
+			want:  []core.Time{time.Date(2025, 7, 13, 18, 30, 0, 0, time.UTC)},
+		},
+		{
+			name: "date not matching custom format",
+			opts: touchOptions{
+				dateStr:    "2025-07-13 14:30",
+				dateFormat: "02.01.2006 15:04",
+				timezone:   "America/New_York",
+			},
+			files:   []string{"file.txt"},
+			wantErr: touchErrors.ErrDateLayoutMismatch,
+		},
+		{
+			name:    "invalid zone",
+			opts:    touchOptions{dateStr: "2025-07-13 14:30", timezone: "Mars/Olympus_Mons"},
+			files:   []string{"file.txt"},
+			wantErr: touchErrors.ErrInvalidTimezone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, _, _, err := calculateTimestamps(tt.opts, tt.files)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("calculateTimestamps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !slices.ContainsFunc(tt.want, got.Equal) || !got1.Equal(got) {
+				t.Errorf("calculateTimestamps() got = %v, got1 = %v, want one of %v", got, got1, tt.want)
+			}
+
+			// Whichever instant is chosen, it reads as the requested wall time in the zone.
+			if want := tt.want[0].In(newYork); got.In(newYork).Hour() != want.Hour() ||
+				got.In(newYork).Minute() != want.Minute() {
+				t.Errorf("calculateTimestamps() wall time = %v, want %v", got.In(newYork), want)
+			}
+		})
+	}
+}
+
+func Test_calculateTimestamps_truncateTo(t *testing.T) {
+	// A half-hour offset, where truncating from UTC would land on the wrong boundary.
+	kolkata, err := timestamp.LoadTimezone("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The clock's own zone is neither of those asked for: 02:45:30 in Kolkata is still the
+	// previous day in UTC and in the clock's zone.
+	pacific := time.FixedZone("PDT", -7*60*60)
+	fixedNow := time.Date(2025, 7, 12, 14, 15, 30, 500, pacific)
+	clock := core.ClockFunc(func() core.Time { return fixedNow })
+
+	tests := []struct {
+		name     string
+		unit     string
+		utc      bool
+		timezone string
+		want     core.Time
+	}{
+		{
+			name:     "day in zone",
+			unit:     "day",
+			timezone: "Asia/Kolkata",
+			want:     time.Date(2025, 7, 13, 0, 0, 0, 0, kolkata),
+		},
+		{
+			name:     "hour in zone",
+			unit:     "hour",
+			timezone: "Asia/Kolkata",
+			want:     time.Date(2025, 7, 13, 2, 0, 0, 0, kolkata),
+		},
+		{
+			name:     "minute in zone",
+			unit:     "minute",
+			timezone: "Asia/Kolkata",
+			want:     time.Date(2025, 7, 13, 2, 45, 0, 0, kolkata),
+		},
+		{
+			name: "day in UTC",
+			unit: "day",
+			utc:  true,
+			want: time.Date(2025, 7, 12, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := touchOptions{truncateTo: tt.unit, utc: tt.utc, timezone: tt.timezone, clock: clock}
+
+			got, got1, _, _, err := calculateTimestamps(opts, []string{"file.txt"})
+			if err != nil {
+				t.Fatalf("calculateTimestamps() error = %v", err)
+			}
+
+			if !got.Equal(tt.want) || !got1.Equal(tt.want) {
+				t.Errorf("calculateTimestamps() got = %v, got1 = %v, want %v", got, got1, tt.want)
+			}
+		})
+	}
+}
@@ -6,13 +6,27 @@
 //
 // Main Functions:
 // - RunTouch: Orchestrates the entire touch operation, serving as the entry point for Cobra's RunE.
-// - processFlags: Retrieves and validates command-line flags, computing the changeTimes mask.
+// - Run, RunCtx: Run the same operation from Go code, with Options in place of command-line flags.
+// - Options.OnFile: Reports each file's FileResult as it completes, one call at a time.
+// - processFlags: Retrieves command-line flags into Options, computing the changeTimes mask.
+// - Options.touchOptions: Validates Options, whether from Go code or processFlags, for a touch run.
 // - calculateTimestamps: Determines access and modification times from flags or defaults to current time.
-// - applyToFiles: Applies timestamp changes concurrently to the list of files.
+// - applyToFiles: Applies timestamp changes to the list of files with a bounded pool of concurrent workers.
+// - applyToFilesCtx: Like applyToFiles, but stops handing out files once its context is cancelled, and counts outcomes.
+// - applyJobsCtx: Like applyToFilesCtx, but touches files as they are produced, each with its own times.
+// - applyJSONLTimes: Streams per-file times from JSON Lines input and applies them through applyJobsCtx.
+// - readFilesFrom: Reads further file operands, one per line, from --files-from.
+// - expandRecursive: Expands directory operands into their trees for -R/--recursive.
+// - expandGlobs: Expands glob patterns in operands that the shell left unexpanded.
+// - runExec: Runs the --exec command for a touched file, one command at a time.
+// - auditLogger: Appends a record of each changed file's old and new times to the --audit-log file.
+// - manifestLog: Collects each touched file's final times and content hash for the --manifest file.
+// - recordJournal: Reports a changed file's old and new times to the system journal, falling back to stderr.
+// - rollbackLog: Records each file's original times for --atomic and restores them if any file fails.
 //
 // This package integrates with the core package for the actual timestamp application
 // and uses the filesystem package for file operations. It also handles platform-specific
-// behaviors, such as warnings for unsupported flags on Windows.
+// behaviors, such as following a symlink when it can't be updated without dereferencing.
 //
 // For usage examples, see the RunTouch function and associated tests.
 package cli
@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file runs the --exec command for each touched file.
+package cli
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// execPlaceholder is replaced by the quoted file name in an --exec template.
+const execPlaceholder = "{}"
+
+// execFileVar is the environment variable holding the file name for an --exec command.
+// On Windows, the placeholder expands to a reference to it, since cmd can't escape a name.
+const execFileVar = "TOUCH_EXEC_FILE"
+
+// execRunner runs a single expanded --exec command line for file, overridable in tests.
+var execRunner = runShellCommand
+
+// execMu serializes --exec commands so concurrent touches don't fork a process each at once.
+var execMu sync.Mutex
+
+// runExec expands template for file and runs it with execRunner, one command at a time.
+func runExec(template, file string) error {
+	command := strings.ReplaceAll(template, execPlaceholder, shellQuote(file))
+
+	execMu.Lock()
+	defer execMu.Unlock()
+
+	if err := execRunner(command, file); err != nil {
+		return fmt.Errorf("exec %q: %w", command, err)
+	}
+
+	return nil
+}
+
+// runShellCommand runs command through the platform shell with execFileVar set to file,
+// sharing this process's stdio.
+func runShellCommand(command, file string) error {
+	cmd := shellCommand(command)
+	cmd.Env = append(os.Environ(), execFileVar+"="+file)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("run %s: %w", cmd.Args[0], err)
+	}
+
+	return nil
+}
+
+// shellQuote quotes s as a single word for the platform shell.
+// cmd expands %VAR% even inside quotes, with no way to escape it, so on Windows the word is
+// instead a quoted reference to execFileVar, which runShellCommand sets to s. The expanded
+// value isn't parsed again, so %, ^, &, and | in s stay literal.
+func shellQuote(s string) string {
+	if runtime.GOOS == osWindows {
+		return `"%` + execFileVar + `%"`
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
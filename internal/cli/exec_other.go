@@ -0,0 +1,30 @@
+//go:build !windows
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file builds the shell command for --exec on platforms other than Windows.
+package cli
+
+import "os/exec"
+
+// shellCommand returns a command running command through sh.
+func shellCommand(command string) *exec.Cmd {
+	//nolint:gosec // Running the user's --exec command line is the purpose of this function.
+	return exec.Command("sh", "-c", command)
+}
@@ -0,0 +1,265 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file runs the --exec command for each touched file.
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
+)
+
+func Test_runExec(t *testing.T) {
+	if runtime.GOOS == osWindows {
+		t.Skip("expectations use POSIX shell quoting")
+	}
+
+	errRunner := errors.New("exit status 1")
+
+	tests := []struct {
+		name      string
+		template  string
+		file      string
+		runnerErr error
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "placeholder replaced",
+			template:  "chmod 600 {}",
+			file:      "file.txt",
+			runnerErr: nil,
+			want:      "chmod 600 'file.txt'",
+			wantErr:   false,
+		},
+		{
+			name:      "every placeholder replaced",
+			template:  "cp {} {}.bak",
+			file:      "a b.txt",
+			runnerErr: nil,
+			want:      "cp 'a b.txt' 'a b.txt'.bak",
+			wantErr:   false,
+		},
+		{
+			name:      "single quote escaped",
+			template:  "chmod 600 {}",
+			file:      "it's.txt",
+			runnerErr: nil,
+			want:      `chmod 600 'it'\''s.txt'`,
+			wantErr:   false,
+		},
+		{
+			name:      "runner failure",
+			template:  "false {}",
+			file:      "file.txt",
+			runnerErr: errRunner,
+			want:      "false 'file.txt'",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldRunner := execRunner
+
+			defer func() { execRunner = oldRunner }()
+
+			var got, gotFile string
+
+			execRunner = func(command, file string) error {
+				got, gotFile = command, file
+
+				return tt.runnerErr
+			}
+
+			err := runExec(tt.template, tt.file)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runExec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("runExec() command = %q, want %q", got, tt.want)
+			}
+
+			if gotFile != tt.file {
+				t.Errorf("runExec() file = %q, want %q", gotFile, tt.file)
+			}
+		})
+	}
+}
+
+func Test_runShellCommand(t *testing.T) {
+	// A shell would act on these if it parsed the file name rather than only passing it on.
+	file := `a&b|c^d%PATH%!PATH!'$HOME.txt`
+
+	// cmd's echo keeps the quotes around the file name.
+	command, want := `echo "$`+execFileVar+`"`, file+"\n"
+	if runtime.GOOS == osWindows {
+		command, want = "echo "+shellQuote(file), `"`+file+"\"\r\n"
+	}
+
+	// Capture stdout.
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runShellCommand(command, file)
+
+	w.Close()
+
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	if err != nil {
+		t.Fatalf("runShellCommand() error = %v", err)
+	}
+
+	if got := buf.String(); got != want {
+		t.Errorf("runShellCommand() stdout = %q, want %q", got, want)
+	}
+
+	if err := runShellCommand("exit 1", file); err == nil {
+		t.Error("runShellCommand() error = nil for a failing command")
+	}
+}
+
+func Test_applyToFiles_exec(t *testing.T) {
+	if runtime.GOOS == osWindows {
+		t.Skip("expectations use POSIX shell quoting")
+	}
+
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name         string
+		files        []string
+		mockFSSetup  func(*mocks.MockFS)
+		failCommand  string
+		wantCommands []string
+		wantErr      bool
+	}{
+		{
+			name:  "runs once per touched file",
+			files: []string{"a.txt", "b.txt"},
+			mockFSSetup: func(m *mocks.MockFS) {
+				for _, file := range []string{"a.txt", "b.txt"} {
+					m.On("Stat", file).Return(&mockFileInfo{mod: stamp}, nil)
+					m.On("Chtimes", file, stamp, stamp).Return(nil)
+				}
+			},
+			failCommand:  "",
+			wantCommands: []string{"chmod 600 'a.txt'", "chmod 600 'b.txt'"},
+			wantErr:      false,
+		},
+		{
+			name:  "skipped for files that fail to touch",
+			files: []string{"a.txt", "locked.txt"},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Chtimes", "a.txt", stamp, stamp).Return(nil)
+				m.On("Stat", "locked.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Chtimes", "locked.txt", stamp, stamp).Return(os.ErrPermission)
+			},
+			failCommand:  "",
+			wantCommands: []string{"chmod 600 'a.txt'"},
+			wantErr:      true,
+		},
+		{
+			name:  "command failure is a per-file error",
+			files: []string{"a.txt", "b.txt"},
+			mockFSSetup: func(m *mocks.MockFS) {
+				for _, file := range []string{"a.txt", "b.txt"} {
+					m.On("Stat", file).Return(&mockFileInfo{mod: stamp}, nil)
+					m.On("Chtimes", file, stamp, stamp).Return(nil)
+				}
+			},
+			failCommand:  "chmod 600 'b.txt'",
+			wantCommands: []string{"chmod 600 'a.txt'", "chmod 600 'b.txt'"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			oldRunner := execRunner
+
+			defer func() { execRunner = oldRunner }()
+
+			var (
+				mu       sync.Mutex
+				commands []string
+			)
+
+			execRunner = func(command, _ string) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				commands = append(commands, command)
+				if command == tt.failCommand {
+					return errors.New("exit status 1")
+				}
+
+				return nil
+			}
+
+			// Capture stderr.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			opts := touchOptions{
+				changeTimes:  core.ChAtime | core.ChMtime,
+				execTemplate: "chmod 600 {}",
+			}
+			err := applyToFiles(opts, stamp, stamp, tt.files)
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyToFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// Files are touched concurrently, so compare the commands in sorted order.
+			slices.Sort(commands)
+
+			if !slices.Equal(commands, tt.wantCommands) {
+				t.Errorf("applyToFiles() commands = %q, want %q", commands, tt.wantCommands)
+			}
+		})
+	}
+}
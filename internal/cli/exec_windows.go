@@ -0,0 +1,38 @@
+//go:build windows
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file builds the shell command for --exec on Windows.
+package cli
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// shellCommand returns a command running command through cmd.
+// The command line is passed verbatim, as cmd doesn't follow the quoting rules Go applies to
+// arguments. /S keeps cmd from dropping quotes inside command, and /V:OFF keeps it from
+// expanding !VAR! in the file name a %VAR% reference expands to.
+func shellCommand(command string) *exec.Cmd {
+	cmd := exec.Command("cmd")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CmdLine: `cmd /V:OFF /S /C "` + command + `"`}
+
+	return cmd
+}
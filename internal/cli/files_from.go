@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file reads file operands listed one per line with --files-from.
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// readFilesFrom reads newline-delimited file names from name, where "-" selects standard input.
+// Trailing whitespace, including a carriage return, is trimmed and empty lines are skipped.
+// With null set, names are instead NUL-delimited, as written by find -print0, and are kept
+// exactly as read apart from skipping empty ones.
+func readFilesFrom(name string, null bool) ([]string, error) {
+	var reader io.Reader
+
+	if name == stdinName {
+		reader = os.Stdin
+	} else {
+		file, err := os.Open(name)
+		if err != nil {
+			return nil, fmt.Errorf("open files-from %s: %w", name, err)
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	var files []string
+
+	scanner := bufio.NewScanner(reader)
+	if null {
+		scanner.Split(scanNUL)
+	}
+
+	for scanner.Scan() {
+		file := scanner.Text()
+		if !null {
+			file = strings.TrimRightFunc(file, unicode.IsSpace)
+		}
+
+		if file == "" {
+			continue
+		}
+
+		files = append(files, file)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read files-from %s: %w", name, err)
+	}
+
+	return files, nil
+}
+
+// scanNUL is a bufio.SplitFunc that returns each NUL-terminated token without its NUL.
+// A final token without a terminating NUL is returned as well.
+func scanNUL(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil // Request more data.
+}
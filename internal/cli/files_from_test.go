@@ -0,0 +1,177 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file reads file operands listed one per line with --files-from.
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
+)
+
+// withStdin replaces standard input with input for the rest of the test.
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := w.WriteString(input); err != nil {
+		t.Fatal(err)
+	}
+
+	w.Close()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+
+	t.Cleanup(func() {
+		os.Stdin = oldStdin
+		r.Close()
+	})
+}
+
+func Test_readFilesFrom(t *testing.T) {
+	listing := "a.txt\r\n\nsub dir/b.txt  \n   \nc.txt"
+
+	listFile := filepath.Join(t.TempDir(), "list.txt")
+	if err := os.WriteFile(listFile, []byte(listing), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		source  string
+		null    bool
+		stdin   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "regular file",
+			source:  listFile,
+			null:    false,
+			stdin:   "",
+			want:    []string{"a.txt", "sub dir/b.txt", "c.txt"},
+			wantErr: false,
+		},
+		{
+			name:    "stdin",
+			source:  "-",
+			null:    false,
+			stdin:   listing,
+			want:    []string{"a.txt", "sub dir/b.txt", "c.txt"},
+			wantErr: false,
+		},
+		{
+			name:    "empty stdin",
+			source:  "-",
+			null:    false,
+			stdin:   "",
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "NUL-delimited stdin",
+			source:  "-",
+			null:    true,
+			stdin:   "a\nb.txt\x00c.txt\x00",
+			want:    []string{"a\nb.txt", "c.txt"},
+			wantErr: false,
+		},
+		{
+			name:    "NUL-delimited keeps whitespace and a final unterminated name",
+			source:  "-",
+			null:    true,
+			stdin:   "\x00 spaced.txt \x00\x00last.txt",
+			want:    []string{" spaced.txt ", "last.txt"},
+			wantErr: false,
+		},
+		{
+			name:    "missing file",
+			source:  filepath.Join(t.TempDir(), "missing.txt"),
+			null:    false,
+			stdin:   "",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withStdin(t, tt.stdin)
+
+			got, err := readFilesFrom(tt.source, tt.null)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readFilesFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("readFilesFrom() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunTouch_filesFrom(t *testing.T) {
+	// The first listed name is stamp-shaped, but must be touched rather than parsed.
+	withStdin(t, "202507131430\nlisted.txt\n")
+
+	mockFS := mocks.NewMockFS(t)
+	for _, file := range []string{"arg.txt", "202507131430", "listed.txt"} {
+		mockFS.On("Stat", file).Return(&mockFileInfo{mod: time.Now()}, nil)
+		mockFS.On("Chtimes", file, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+			Return(nil)
+	}
+
+	filesystem.Default = mockFS // Override default FS with mock.
+
+	// Capture stderr.
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	cmd := createTestCmd(func(cmd *cobra.Command) { cmd.Flags().Set("files-from", "-") })
+	err := RunTouch(cmd, []string{"arg.txt"})
+
+	w.Close()
+
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	if err != nil {
+		t.Errorf("RunTouch() error = %v", err)
+	}
+
+	if got := buf.String(); got != "" {
+		t.Errorf("RunTouch() stderr = %q, want none", got)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file expands glob patterns in operands the shell left unexpanded.
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// globMeta holds the characters that make an operand a pattern for filepath.Glob.
+const globMeta = "*?["
+
+// expandGlobs replaces each operand in files that contains *, ?, or [ with the files it
+// matches, as understood by filepath.Glob, in lexical order. This covers shells, such as
+// those on Windows, that pass patterns through unexpanded. An operand naming an existing
+// file is kept as given, and so is a pattern that matches nothing or is malformed, so it is
+// created under that literal name, as with an unmatched shell glob. It isn't called under -c.
+func expandGlobs(files []string) []string {
+	expanded := make([]string, 0, len(files))
+
+	for _, file := range files {
+		if !strings.ContainsAny(file, globMeta) {
+			expanded = append(expanded, file)
+
+			continue
+		}
+
+		// A file with the literal name takes precedence over anything it would match.
+		if _, err := filesystem.Default.Lstat(file); err == nil {
+			expanded = append(expanded, file)
+
+			continue
+		}
+
+		matches, err := filepath.Glob(file)
+		if err != nil || len(matches) == 0 {
+			expanded = append(expanded, file)
+
+			continue
+		}
+
+		expanded = append(expanded, matches...)
+	}
+
+	return expanded
+}
@@ -0,0 +1,162 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file expands glob patterns in operands the shell left unexpanded.
+package cli
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+func Test_expandGlobs(t *testing.T) {
+	dir := t.TempDir()
+
+	// "[a].txt" is both a file of its own and a pattern matching "a.txt".
+	for _, name := range []string{"a.log", "b.log", "a.txt", "[a].txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{
+			name:  "matching glob expanded in order",
+			files: []string{filepath.Join(dir, "*.log")},
+			want:  []string{filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log")},
+		},
+		{
+			name:  "non-matching glob kept as file name",
+			files: []string{filepath.Join(dir, "*.tmp")},
+			want:  []string{filepath.Join(dir, "*.tmp")},
+		},
+		{
+			name:  "malformed glob kept as file name",
+			files: []string{filepath.Join(dir, "[.log")},
+			want:  []string{filepath.Join(dir, "[.log")},
+		},
+		{
+			name:  "literal file takes precedence",
+			files: []string{filepath.Join(dir, "[a].txt")},
+			want:  []string{filepath.Join(dir, "[a].txt")},
+		},
+		{
+			name:  "plain operands keep their order",
+			files: []string{"new.txt", filepath.Join(dir, "?.log"), "other.txt"},
+			want: []string{
+				"new.txt",
+				filepath.Join(dir, "a.log"),
+				filepath.Join(dir, "b.log"),
+				"other.txt",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem.Default = realFS
+
+			if got := expandGlobs(tt.files); !slices.Equal(got, tt.want) {
+				t.Errorf("expandGlobs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunTouch_glob(t *testing.T) {
+	oldTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		noCreate    bool
+		wantTouched bool
+	}{
+		{name: "pattern expanded", noCreate: false, wantTouched: true},
+		{name: "no expansion with -c", noCreate: true, wantTouched: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.noCreate && runtime.GOOS == osWindows {
+				t.Skip("Windows rejects the unexpanded pattern as a file name")
+			}
+
+			filesystem.Default = realFS
+
+			dir := t.TempDir()
+			matches := []string{filepath.Join(dir, "a.log"), filepath.Join(dir, "b.log")}
+
+			for _, file := range matches {
+				if err := os.WriteFile(file, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.Chtimes(file, oldTime, oldTime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			pattern := filepath.Join(dir, "*.log")
+
+			cmd := createTestCmd(func(cmd *cobra.Command) {
+				cmd.Flags().Set("stamp", "202507131430")
+
+				if tt.noCreate {
+					cmd.Flags().Set("no-create", "true")
+				}
+			})
+			if err := RunTouch(cmd, []string{pattern}); err != nil {
+				t.Fatalf("RunTouch() error = %v", err)
+			}
+
+			want := oldTime
+			if tt.wantTouched {
+				want = stamp
+			}
+
+			for _, file := range matches {
+				info, err := os.Stat(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !info.ModTime().Equal(want) {
+					t.Errorf("%s mtime = %v, want %v", file, info.ModTime(), want)
+				}
+			}
+
+			// Neither run creates a file named after the pattern.
+			if _, err := os.Stat(pattern); runtime.GOOS != osWindows && !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("os.Stat(%s) error = %v, want %v", pattern, err, fs.ErrNotExist)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file collects touched files' final modification times for the --histogram report.
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// histogramBarWidth is the length of the bar drawn for the fullest bucket.
+const histogramBarWidth = 40
+
+// histogramLog collects the final modification time of each successfully touched file,
+// reported as a histogram once all files are processed.
+type histogramLog struct {
+	mtimes []core.Time // Modification times recorded so far.
+	mu     sync.Mutex  // Serializes appends to mtimes.
+}
+
+// histogramBucket is the number of files whose modification time falls in [start, start+width).
+type histogramBucket struct {
+	start core.Time
+	count int
+}
+
+// record adds file's current modification time, without following a final symlink
+// when noDeref is set.
+func (h *histogramLog) record(file string, noDeref bool) error {
+	stat := filesystem.Default.Stat
+	if noDeref {
+		stat = filesystem.Default.Lstat
+	}
+
+	fileInfo, err := stat(file)
+	if err != nil {
+		return fmt.Errorf("read times for histogram: %w", err)
+	}
+
+	h.mu.Lock()
+	h.mtimes = append(h.mtimes, fileInfo.ModTime())
+	h.mu.Unlock()
+
+	return nil
+}
+
+// buckets groups the recorded times into buckets of width, aligned to multiples of width
+// since the zero time, so day buckets start at midnight UTC. Only buckets holding at least
+// one time are returned, oldest first.
+func (h *histogramLog) buckets(width time.Duration) []histogramBucket {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	counts := make(map[core.Time]int)
+	for _, mtime := range h.mtimes {
+		counts[mtime.Truncate(width).UTC()]++
+	}
+
+	buckets := make([]histogramBucket, 0, len(counts))
+	for start, count := range counts {
+		buckets = append(buckets, histogramBucket{start: start, count: count})
+	}
+
+	slices.SortFunc(buckets, func(a, b histogramBucket) int { return a.start.Compare(b.start) })
+
+	return buckets
+}
+
+// write prints one line per bucket to w, giving its start in local time, its count, and a
+// bar scaled to the fullest bucket.
+func (h *histogramLog) write(w io.Writer, width time.Duration) {
+	buckets := h.buckets(width)
+
+	fmt.Fprintf(w, "touch: modification times in %v buckets:\n", width)
+
+	maxCount := 0
+	for _, bucket := range buckets {
+		maxCount = max(maxCount, bucket.count)
+	}
+
+	countWidth := len(strconv.Itoa(maxCount))
+
+	for _, bucket := range buckets {
+		bar := strings.Repeat("#", max(1, bucket.count*histogramBarWidth/maxCount))
+		fmt.Fprintf(
+			w,
+			"touch: %s %*d %s\n",
+			bucket.start.Local().Format(time.RFC3339),
+			countWidth,
+			bucket.count,
+			bar,
+		)
+	}
+}
+
+// finishHistogram prints the --histogram, if one was requested, once all files are processed.
+func finishHistogram(opts touchOptions) {
+	if opts.histLog != nil {
+		opts.histLog.write(os.Stderr, opts.histBucket)
+	}
+}
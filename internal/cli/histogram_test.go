@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file tests the --histogram report of touched files' final modification times.
+package cli
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+func Test_histogramLog_buckets(t *testing.T) {
+	base := time.Date(2025, 7, 13, 14, 0, 0, 0, time.UTC)
+	mtimes := []core.Time{
+		base.Add(5 * time.Minute),
+		base.Add(59 * time.Minute),
+		base.Add(-time.Second),
+		base.Add(3*time.Hour + 30*time.Minute),
+		base,
+	}
+
+	tests := []struct {
+		name  string
+		width time.Duration
+		want  []histogramBucket
+	}{
+		{
+			name:  "hourly",
+			width: time.Hour,
+			want: []histogramBucket{
+				{start: base.Add(-time.Hour), count: 1},
+				{start: base, count: 3},
+				{start: base.Add(3 * time.Hour), count: 1},
+			},
+		},
+		{
+			name:  "daily",
+			width: 24 * time.Hour,
+			want: []histogramBucket{
+				{start: time.Date(2025, 7, 13, 0, 0, 0, 0, time.UTC), count: 5},
+			},
+		},
+		{
+			name:  "every 30 minutes",
+			width: 30 * time.Minute,
+			want: []histogramBucket{
+				{start: base.Add(-30 * time.Minute), count: 1},
+				{start: base, count: 2},
+				{start: base.Add(30 * time.Minute), count: 1},
+				{start: base.Add(3*time.Hour + 30*time.Minute), count: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := filesystem.NewMemFS()
+			filesystem.Default = m // Override default FS with an in-memory one.
+
+			h := &histogramLog{}
+
+			for i, mtime := range mtimes {
+				file := string(rune('a'+i)) + ".txt"
+				memCreate(t, m, file, mtime, mtime)
+
+				if err := h.record(file, false); err != nil {
+					t.Fatalf("histogramLog.record() error = %v", err)
+				}
+			}
+
+			got := h.buckets(tt.width)
+			if !slices.EqualFunc(got, tt.want, func(a, b histogramBucket) bool {
+				return a.start.Equal(b.start) && a.count == b.count
+			}) {
+				t.Errorf("histogramLog.buckets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_histogramLog_write(t *testing.T) {
+	base := time.Date(2025, 7, 13, 14, 0, 0, 0, time.UTC)
+
+	h := &histogramLog{}
+	for _, offset := range []time.Duration{0, time.Minute, 2 * time.Minute, time.Hour} {
+		h.mtimes = append(h.mtimes, base.Add(offset))
+	}
+
+	var buf bytes.Buffer
+
+	h.write(&buf, time.Hour)
+
+	first := base.Local().Format(time.RFC3339)
+	second := base.Add(time.Hour).Local().Format(time.RFC3339)
+
+	want := "touch: modification times in 1h0m0s buckets:\n" +
+		"touch: " + first + " 3 " + strings.Repeat("#", 40) + "\n" +
+		"touch: " + second + " 1 " + strings.Repeat("#", 13) + "\n"
+	if buf.String() != want {
+		t.Errorf("histogramLog.write() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRunTouch_histogram(t *testing.T) {
+	m := filesystem.NewMemFS()
+	filesystem.Default = m // Override default FS with an in-memory one.
+
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	memCreate(t, m, "a.txt", old, old)
+	memCreate(t, m, "b.txt", old, old)
+
+	// Capture stderr.
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	cmd := createTestCmd(func(cmd *cobra.Command) {
+		cmd.Flags().Set("histogram", "true")
+		cmd.Flags().Set("histogram-bucket", "24h")
+		cmd.Flags().Set("date", "2025-07-13T14:30:00Z")
+	})
+	err := RunTouch(cmd, []string{"a.txt", "b.txt", "c.txt"})
+
+	w.Close()
+
+	os.Stderr = oldStderr
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	if err != nil {
+		t.Fatalf("RunTouch() error = %v", err)
+	}
+
+	day := time.Date(2025, 7, 13, 0, 0, 0, 0, time.UTC).Local().Format(time.RFC3339)
+
+	want := "touch: modification times in 24h0m0s buckets:\n" +
+		"touch: " + day + " 3 " + strings.Repeat("#", 40) + "\n"
+	if buf.String() != want {
+		t.Errorf("RunTouch() stderr = %q, want %q", buf.String(), want)
+	}
+}
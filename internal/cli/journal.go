@@ -0,0 +1,67 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file reports per-file time changes to the system journal for --journal.
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+// journalPriority is the syslog priority of journal records, LOG_INFO.
+const journalPriority = "6"
+
+// recordJournal reports the change of file's times from before to their current values
+// to the system journal. When the journal is unavailable the message is written to stderr
+// instead, so the record isn't lost.
+func recordJournal(file string, before auditTimes, noDeref bool) error {
+	after, err := readAuditTimes(file, noDeref)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf(
+		"%s: atime %s -> %s, mtime %s -> %s",
+		core.Quote(file),
+		before.atime,
+		after.atime,
+		before.mtime,
+		after.mtime,
+	)
+
+	fields := []platform.JournalField{
+		{Key: "MESSAGE", Value: message},
+		{Key: "PRIORITY", Value: journalPriority},
+		{Key: "SYSLOG_IDENTIFIER", Value: "touch"},
+		{Key: "TOUCH_FILE", Value: file},
+		{Key: "TOUCH_OLD_ATIME", Value: before.atime},
+		{Key: "TOUCH_NEW_ATIME", Value: after.atime},
+		{Key: "TOUCH_OLD_MTIME", Value: before.mtime},
+		{Key: "TOUCH_NEW_MTIME", Value: after.mtime},
+	}
+
+	if err := platform.WriteJournal(fields); err != nil {
+		fmt.Fprintf(os.Stderr, "touch: %s\n", message)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file reports per-file time changes to the system journal for --journal.
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+func Test_applyToFiles_journal(t *testing.T) {
+	oldTime := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+
+	format := func(t time.Time) string { return t.Format(time.RFC3339Nano) }
+
+	tests := []struct {
+		name       string
+		journalErr error
+		wantStderr bool
+	}{
+		{
+			name:       "journal available",
+			journalErr: nil,
+			wantStderr: false,
+		},
+		{
+			name:       "journal unavailable falls back to stderr",
+			journalErr: errors.New("connect to journal: no such file or directory"),
+			wantStderr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem.Default = realFS
+
+			dir := t.TempDir()
+			existing := filepath.Join(dir, "a.txt")
+			created := filepath.Join(dir, "b.txt")
+
+			if err := os.WriteFile(existing, nil, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.Chtimes(existing, oldTime, oldTime); err != nil {
+				t.Fatal(err)
+			}
+
+			oldWriteJournal := platform.WriteJournal
+
+			defer func() { platform.WriteJournal = oldWriteJournal }()
+
+			var (
+				mu      sync.Mutex
+				entries = map[string][]platform.JournalField{}
+			)
+
+			platform.WriteJournal = func(fields []platform.JournalField) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				entries[fields[3].Value] = fields
+
+				return tt.journalErr
+			}
+
+			// Capture stderr.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			opts := touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				journal:     true,
+			}
+			err := applyToFiles(opts, stamp, stamp, []string{existing, created})
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			if _, copyErr := io.Copy(&buf, r); copyErr != nil {
+				t.Fatal(copyErr)
+			}
+
+			if err != nil {
+				t.Fatalf("applyToFiles() error = %v", err)
+			}
+
+			wantEntries := map[string][]platform.JournalField{
+				existing: {
+					{Key: "MESSAGE", Value: core.Quote(existing) + ": atime " + format(oldTime) + " -> " + format(stamp) +
+						", mtime " + format(oldTime) + " -> " + format(stamp)},
+					{Key: "PRIORITY", Value: "6"},
+					{Key: "SYSLOG_IDENTIFIER", Value: "touch"},
+					{Key: "TOUCH_FILE", Value: existing},
+					{Key: "TOUCH_OLD_ATIME", Value: format(oldTime)},
+					{Key: "TOUCH_NEW_ATIME", Value: format(stamp)},
+					{Key: "TOUCH_OLD_MTIME", Value: format(oldTime)},
+					{Key: "TOUCH_NEW_MTIME", Value: format(stamp)},
+				},
+				created: {
+					{Key: "MESSAGE", Value: core.Quote(created) + ": atime - -> " + format(stamp) +
+						", mtime - -> " + format(stamp)},
+					{Key: "PRIORITY", Value: "6"},
+					{Key: "SYSLOG_IDENTIFIER", Value: "touch"},
+					{Key: "TOUCH_FILE", Value: created},
+					{Key: "TOUCH_OLD_ATIME", Value: "-"},
+					{Key: "TOUCH_NEW_ATIME", Value: format(stamp)},
+					{Key: "TOUCH_OLD_MTIME", Value: "-"},
+					{Key: "TOUCH_NEW_MTIME", Value: format(stamp)},
+				},
+			}
+
+			for file, want := range wantEntries {
+				if got := entries[file]; !slices.Equal(got, want) {
+					t.Errorf("journal entry for %s = %v, want %v", file, got, want)
+				}
+
+				message := "touch: " + want[0].Value + "\n"
+				if gotStderr := strings.Contains(buf.String(), message); gotStderr != tt.wantStderr {
+					t.Errorf("stderr = %q, want fallback message %q: %v", buf.String(), message, tt.wantStderr)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file applies per-file times streamed as JSON Lines.
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/timestamp"
+)
+
+// stdinName is the --jsonl-times value that selects standard input.
+const stdinName = "-"
+
+// maxJSONLLineSize bounds a single JSON Lines record.
+const maxJSONLLineSize = 1024 * 1024
+
+// jsonlTimesRecord is one line of --jsonl-times input.
+// Atime and Mtime accept any format supported by timestamp.ParseDate, read in the location
+// of --utc or --timezone; an omitted component leaves that time unchanged.
+type jsonlTimesRecord struct {
+	Path  string `json:"path"`
+	Mtime string `json:"mtime"`
+	Atime string `json:"atime"`
+}
+
+// openJSONLTimes opens the --jsonl-times source, where "-" selects standard input.
+func openJSONLTimes(name string) (io.ReadCloser, error) {
+	if name == stdinName {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("open JSON Lines times %s: %w", name, err)
+	}
+
+	return file, nil
+}
+
+// applyJSONLTimes reads JSON Lines records from reader one at a time and touches each
+// record's path with its own times, so large inputs are never buffered in full. Records
+// are touched by applyJobsCtx as they are read, so failures, --summary, and cancellation
+// are handled as for operands, and times without an offset are read in the run's location.
+// Like operands, records are touched concurrently unless opts.jobs is 1, which a path
+// repeated in the input needs for its last record to win.
+// Malformed lines are reported with their line number; under strict they stop the run,
+// otherwise they are skipped with a warning unless quiet.
+func applyJSONLTimes(ctx context.Context, opts touchOptions, reader io.Reader) (fileCounts, error) {
+	loc, err := location(opts)
+	if err != nil {
+		return fileCounts{}, err
+	}
+
+	// Read the clock once, so relative dates in every record agree.
+	now := core.NowFrom(opts.clock)
+
+	return applyJobsCtx(ctx, opts, -1, func(yield func(fileJob) bool) error {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxJSONLLineSize)
+
+		lineNumber := 0
+
+		for scanner.Scan() {
+			lineNumber++
+
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+
+			job, err := parseJSONLTimesRecord(opts.changeTimes, line, loc, now)
+			if err != nil {
+				if opts.strict {
+					return fmt.Errorf("line %d: %w", lineNumber, err)
+				}
+
+				fmt.Fprintf(warnWriter(opts), "touch: jsonl-times line %d: %v\n", lineNumber, err)
+
+				continue
+			}
+
+			// Nothing left to change once -a/-m and the record's components are combined.
+			if job.changeTimes == 0 {
+				continue
+			}
+
+			if !yield(job) {
+				return nil
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("read JSON Lines times after line %d: %w", lineNumber, err)
+		}
+
+		return nil
+	})
+}
+
+// parseJSONLTimesRecord decodes a single record into the file to touch, with the change mask
+// narrowed to the components it provides and its access and modification times, parsed in
+// loc and against now.
+func parseJSONLTimesRecord(
+	changeTimes int,
+	line string,
+	loc *time.Location,
+	now core.Time,
+) (fileJob, error) {
+	var record jsonlTimesRecord
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		return fileJob{}, fmt.Errorf("%w: %w", errors.ErrInvalidJSONLRecord, err)
+	}
+
+	if record.Path == "" || (record.Atime == "" && record.Mtime == "") {
+		return fileJob{}, fmt.Errorf(
+			"%w: path and at least one of atime or mtime are required",
+			errors.ErrInvalidJSONLRecord,
+		)
+	}
+
+	job := fileJob{file: record.Path, changeTimes: changeTimes}
+
+	if record.Atime == "" {
+		job.changeTimes &^= core.ChAtime
+	} else {
+		parsed, err := timestamp.ParseDateAt(record.Atime, "", loc, now)
+		if err != nil {
+			return fileJob{}, fmt.Errorf("parse atime: %w", err)
+		}
+
+		job.accessTime = parsed
+	}
+
+	if record.Mtime == "" {
+		job.changeTimes &^= core.ChMtime
+	} else {
+		parsed, err := timestamp.ParseDateAt(record.Mtime, "", loc, now)
+		if err != nil {
+			return fileJob{}, fmt.Errorf("parse mtime: %w", err)
+		}
+
+		job.modTime = parsed
+	}
+
+	return job, nil
+}
@@ -0,0 +1,279 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file applies per-file times streamed as JSON Lines.
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+func Test_applyJSONLTimes(t *testing.T) {
+	input := `{"path":"a.txt","atime":"2025-07-13T10:00:00Z","mtime":"2025-07-13T11:00:00Z"}
+
+{"path":"b.txt","mtime":"2025-07-13T12:00:00Z"}
+{"path":"c.txt",
+{"path":"d.txt","atime":"2025-07-13T13:00:00Z","mtime":"2025-07-13T14:00:00Z"}
+`
+
+	tests := []struct {
+		name        string
+		opts        touchOptions
+		mockFSSetup func(*mocks.MockFS)
+		wantErr     bool
+		wantErrText string
+		wantStderr  string
+	}{
+		{
+			name: "lenient skips malformed line",
+			opts: touchOptions{changeTimes: core.ChAtime | core.ChMtime},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").Return(&mockFileInfo{}, nil)
+				m.On("Chtimes", "a.txt", time.Date(2025, 7, 13, 10, 0, 0, 0, time.UTC), time.Date(2025, 7, 13, 11, 0, 0, 0, time.UTC)).
+					Return(nil)
+				m.On("Stat", "b.txt").Return(&mockFileInfo{}, nil)
+				m.On("Chtimes", "b.txt", mock.AnythingOfType("time.Time"), time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC)).
+					Return(nil)
+				m.On("Stat", "d.txt").Return(&mockFileInfo{}, nil)
+				m.On("Chtimes", "d.txt", time.Date(2025, 7, 13, 13, 0, 0, 0, time.UTC), time.Date(2025, 7, 13, 14, 0, 0, 0, time.UTC)).
+					Return(nil)
+			},
+			wantErr:    false,
+			wantStderr: "touch: jsonl-times line 4: invalid JSON Lines times record: unexpected end of JSON input\n",
+		},
+		{
+			name: "quiet skips malformed line silently",
+			opts: touchOptions{changeTimes: core.ChAtime | core.ChMtime, quiet: true},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", mock.Anything).Return(&mockFileInfo{}, nil)
+				m.On("Chtimes", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			wantErr:    false,
+			wantStderr: "",
+		},
+		{
+			name: "quiet hides per-file errors",
+			opts: touchOptions{changeTimes: core.ChAtime | core.ChMtime, quiet: true},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", mock.Anything).Return(nil, os.ErrPermission)
+			},
+			wantErr:    true,
+			wantStderr: "",
+		},
+		{
+			name: "strict stops at malformed line",
+			opts: touchOptions{changeTimes: core.ChAtime | core.ChMtime, strict: true},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").Return(&mockFileInfo{}, nil)
+				m.On("Chtimes", "a.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+				m.On("Stat", "b.txt").Return(&mockFileInfo{}, nil)
+				m.On("Chtimes", "b.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+			},
+			wantErr:     true,
+			wantErrText: "line 4: invalid JSON Lines times record",
+			wantStderr:  "",
+		},
+		{
+			name: "access only skips records without atime",
+			opts: touchOptions{changeTimes: core.ChAtime},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").Return(&mockFileInfo{}, nil)
+				m.On("Chtimes", "a.txt", time.Date(2025, 7, 13, 10, 0, 0, 0, time.UTC), mock.AnythingOfType("time.Time")).
+					Return(nil)
+				m.On("Stat", "d.txt").Return(&mockFileInfo{}, nil)
+				m.On("Chtimes", "d.txt", time.Date(2025, 7, 13, 13, 0, 0, 0, time.UTC), mock.AnythingOfType("time.Time")).
+					Return(nil)
+			},
+			wantErr:    false,
+			wantStderr: "touch: jsonl-times line 4: invalid JSON Lines times record: unexpected end of JSON input\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			if tt.mockFSSetup != nil {
+				tt.mockFSSetup(mockFS)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			// Capture stderr.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			_, err := applyJSONLTimes(context.Background(), tt.opts, strings.NewReader(input))
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+			stderrOutput := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyJSONLTimes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), tt.wantErrText) {
+				t.Errorf("applyJSONLTimes() error = %v, want containing %q", err, tt.wantErrText)
+			}
+
+			if stderrOutput != tt.wantStderr {
+				t.Errorf("applyJSONLTimes() stderr = %v, want %v", stderrOutput, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRunTouch_jsonlTimes(t *testing.T) {
+	stamp := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+
+	tests := []struct {
+		name        string
+		opts        touchOptions
+		input       string
+		wantMtime   core.Time
+		wantErr     error
+		wantErrText string
+		wantStderr  string
+	}{
+		{
+			name:        "utc",
+			opts:        touchOptions{changeTimes: core.ChAtime | core.ChMtime, utc: true},
+			input:       `{"path":"a.txt","mtime":"2025-07-13 10:00"}` + "\n",
+			wantMtime:   time.Date(2025, 7, 13, 10, 0, 0, 0, time.UTC),
+			wantErr:     nil,
+			wantErrText: "",
+			wantStderr:  "",
+		},
+		{
+			name: "timezone",
+			opts: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				timezone:    "Asia/Kolkata",
+			},
+			input:       `{"path":"a.txt","mtime":"2025-07-13 10:00"}` + "\n",
+			wantMtime:   time.Date(2025, 7, 13, 10, 0, 0, 0, kolkata),
+			wantErr:     nil,
+			wantErrText: "",
+			wantStderr:  "",
+		},
+		{
+			name: "partial failure with summary",
+			opts: touchOptions{changeTimes: core.ChAtime | core.ChMtime, utc: true, summary: true},
+			input: `{"path":"a.txt","mtime":"2025-07-13 10:00"}` + "\n" +
+				`{"path":"missing/b.txt","mtime":"2025-07-13 10:00"}` + "\n",
+			wantMtime:   time.Date(2025, 7, 13, 10, 0, 0, 0, time.UTC),
+			wantErr:     touchErrors.ErrPartialFailure,
+			wantErrText: core.Quote("missing/b.txt"),
+			wantStderr:  "touch: 1 updated, 1 failed\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := filesystem.NewMemFS()
+			memCreate(t, m, "a.txt", stamp, stamp)
+
+			filesystem.Default = m // Override default FS with an in-memory one.
+
+			opts := tt.opts
+			opts.jsonlTimes = filepath.Join(t.TempDir(), "times.jsonl")
+
+			if err := os.WriteFile(opts.jsonlTimes, []byte(tt.input), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			// Capture stderr.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			err := runTouch(context.Background(), opts, nil)
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("runTouch() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err != nil && !strings.Contains(err.Error(), tt.wantErrText) {
+				t.Errorf("runTouch() error = %v, want containing %q", err, tt.wantErrText)
+			}
+
+			if !strings.HasSuffix(buf.String(), tt.wantStderr) {
+				t.Errorf("runTouch() stderr = %q, want ending in %q", buf.String(), tt.wantStderr)
+			}
+
+			info, err := m.Stat("a.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			atime := platform.GetAtime(info)
+			if !atime.Equal(stamp) || !info.ModTime().Equal(tt.wantMtime) {
+				t.Errorf("a.txt times = %v, %v, want %v, %v", atime, info.ModTime(), stamp, tt.wantMtime)
+			}
+		})
+	}
+}
+
+func Test_applyJSONLTimes_cancelled(t *testing.T) {
+	// The mock fails the test on any call, so no record is touched once cancelled.
+	filesystem.Default = mocks.NewMockFS(t) // Override default FS with mock.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	input := `{"path":"a.txt","mtime":"2025-07-13T10:00:00Z"}` + "\n"
+
+	opts := touchOptions{changeTimes: core.ChAtime | core.ChMtime}
+
+	counts, err := applyJSONLTimes(ctx, opts, strings.NewReader(input))
+	if !errors.Is(err, touchErrors.ErrCancelled) {
+		t.Errorf("applyJSONLTimes() error = %v, want %v", err, touchErrors.ErrCancelled)
+	}
+
+	if counts != (fileCounts{}) {
+		t.Errorf("applyJSONLTimes() counts = %+v, want none", counts)
+	}
+}
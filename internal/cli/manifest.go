@@ -0,0 +1,161 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file collects touched files' final times and content hashes for the --manifest file.
+package cli
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+// manifestNoHash stands in for the content hash of a file that isn't a regular file.
+const manifestNoHash = "-"
+
+// manifestLog collects one entry per successfully touched file, written out once all
+// files are processed so the manifest lists each file exactly once, sorted by name.
+type manifestLog struct {
+	entries []manifestEntry // Entries recorded so far.
+	mu      sync.Mutex      // Serializes appends to entries.
+}
+
+// manifestEntry holds a touched file's final times and content hash.
+type manifestEntry struct {
+	file  string
+	hash  string
+	atime core.Time
+	mtime core.Time
+}
+
+// record adds file's current times and the SHA-256 of its content, without following a
+// final symlink when noDeref is set. As reading the content can update the access time,
+// the recorded times are set again afterward.
+func (m *manifestLog) record(file string, noDeref bool) error {
+	stat := filesystem.Default.Stat
+	if noDeref {
+		stat = filesystem.Default.Lstat
+	}
+
+	fileInfo, err := stat(file)
+	if err != nil {
+		return fmt.Errorf("read times for manifest: %w", err)
+	}
+
+	entry := manifestEntry{
+		file:  file,
+		hash:  manifestNoHash,
+		atime: platform.GetAtime(fileInfo),
+		mtime: fileInfo.ModTime(),
+	}
+
+	if fileInfo.Mode().IsRegular() {
+		entry.hash, err = hashFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := filesystem.Default.Chtimes(file, entry.atime, entry.mtime); err != nil {
+			return fmt.Errorf("restore times after hashing %s: %w", file, err)
+		}
+	}
+
+	m.mu.Lock()
+	m.entries = append(m.entries, entry)
+	m.mu.Unlock()
+
+	return nil
+}
+
+// write writes the collected entries to path, one tab-separated line of content hash,
+// access time, modification time, and file name per file, sorted by file name.
+func (m *manifestLog) write(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	slices.SortFunc(m.entries, func(a, b manifestEntry) int { return cmp.Compare(a.file, b.file) })
+
+	var builder strings.Builder
+
+	for _, entry := range m.entries {
+		builder.WriteString(strings.Join([]string{
+			entry.hash,
+			entry.atime.Format(time.RFC3339Nano),
+			entry.mtime.Format(time.RFC3339Nano),
+			entry.file,
+		}, "\t") + "\n")
+	}
+
+	manifestFile, err := filesystem.Default.Create(path)
+	if err != nil {
+		return fmt.Errorf("create manifest: %w", err)
+	}
+
+	if _, err := io.WriteString(manifestFile, builder.String()); err != nil {
+		manifestFile.Close()
+
+		return fmt.Errorf("write manifest %s: %w", path, err)
+	}
+
+	if err := manifestFile.Close(); err != nil {
+		return fmt.Errorf("close manifest %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// finishManifest writes the --manifest, if one was requested, once all files are processed.
+// A failure to write it is joined with runErr, the result of processing the files.
+func finishManifest(opts touchOptions, runErr error) error {
+	if opts.manifestLog == nil {
+		return runErr
+	}
+
+	if err := opts.manifestLog.write(opts.manifest); err != nil {
+		return errors.Join(runErr, err)
+	}
+
+	return runErr
+}
+
+// hashFile returns the hex-encoded SHA-256 of file's content, read through the FS.
+func hashFile(file string) (string, error) {
+	contentFile, err := filesystem.Default.Open(file)
+	if err != nil {
+		return "", fmt.Errorf("open %s for manifest hash: %w", file, err)
+	}
+	defer contentFile.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, contentFile); err != nil {
+		return "", fmt.Errorf("hash %s for manifest: %w", file, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
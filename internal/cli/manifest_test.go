@@ -0,0 +1,94 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file collects touched files' final times and content hashes for the --manifest file.
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+func TestRunTouch_manifest(t *testing.T) {
+	filesystem.Default = realFS
+
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "manifest.tsv")
+	stamp := time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local).Format(time.RFC3339Nano)
+
+	// SHA-256 of "alpha", "beta", and the empty content of a created file.
+	files := []struct {
+		name    string
+		content string
+		hash    string
+	}{
+		{name: "b.txt", content: "beta", hash: "f44e64e75f3948e9f73f8dfa94721c4ce8cbb4f265c4790c702b2d41cfbf2753"},
+		{name: "a.txt", content: "alpha", hash: "8ed3f6ad685b959ead7022518e1af76cd816f8e8ec7ccdda1ed4018e8f2223f8"},
+	}
+
+	args := make([]string, 0, len(files)+2)
+
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if err := os.WriteFile(path, []byte(file.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		args = append(args, path)
+	}
+
+	// A created file is listed with the hash of its empty content; a file in a missing
+	// directory fails and is left out.
+	args = append(args, filepath.Join(dir, "c.txt"), filepath.Join(dir, "missing", "d.txt"))
+
+	cmd := createTestCmd(func(cmd *cobra.Command) {
+		cmd.Flags().Set("date", "2025-07-13")
+		cmd.Flags().Set("manifest", manifestPath)
+	})
+
+	err := RunTouch(cmd, args)
+	if !errors.Is(err, touchErrors.ErrProcessingFiles) {
+		t.Fatalf("RunTouch() error = %v, want %v", err, touchErrors.ErrProcessingFiles)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		strings.Join([]string{files[1].hash, stamp, stamp, filepath.Join(dir, "a.txt")}, "\t"),
+		strings.Join([]string{files[0].hash, stamp, stamp, filepath.Join(dir, "b.txt")}, "\t"),
+		strings.Join([]string{
+			"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", stamp, stamp, filepath.Join(dir, "c.txt"),
+		}, "\t"),
+	}, "\n") + "\n"
+
+	if got := string(data); got != want {
+		t.Errorf("manifest = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file runs whole touch invocations against an in-memory file system.
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+func TestRunTouch_memFS(t *testing.T) {
+	stamped := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+	refAtime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+	refMtime := time.Date(2024, 6, 7, 8, 9, 10, 0, time.Local)
+	oldAtime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+	oldMtime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		setup     func(t *testing.T, m *filesystem.MemFS)
+		flagSetup func(cmd *cobra.Command)
+		file      string
+		wantAtime core.Time
+		wantMtime core.Time
+	}{
+		{
+			name:      "create",
+			setup:     func(*testing.T, *filesystem.MemFS) {},
+			flagSetup: func(cmd *cobra.Command) { cmd.Flags().Set("stamp", "202507131430") },
+			file:      "new.txt",
+			wantAtime: stamped,
+			wantMtime: stamped,
+		},
+		{
+			name: "update only the modification time",
+			setup: func(t *testing.T, m *filesystem.MemFS) {
+				t.Helper()
+				memCreate(t, m, "existing.txt", oldAtime, oldMtime)
+			},
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("stamp", "202507131430")
+				cmd.Flags().Set("modification", "true")
+			},
+			file:      "existing.txt",
+			wantAtime: oldAtime,
+			wantMtime: stamped,
+		},
+		{
+			name: "copy times from a reference",
+			setup: func(t *testing.T, m *filesystem.MemFS) {
+				t.Helper()
+				memCreate(t, m, "ref.txt", refAtime, refMtime)
+				memCreate(t, m, "existing.txt", oldAtime, oldMtime)
+			},
+			flagSetup: func(cmd *cobra.Command) { cmd.Flags().Set("reference", "ref.txt") },
+			file:      "existing.txt",
+			wantAtime: refAtime,
+			wantMtime: refMtime,
+		},
+		{
+			name: "set only the access time",
+			setup: func(t *testing.T, m *filesystem.MemFS) {
+				t.Helper()
+				memCreate(t, m, "existing.txt", oldAtime, oldMtime)
+			},
+			flagSetup: func(cmd *cobra.Command) { cmd.Flags().Set("set-atime", "202507131430") },
+			file:      "existing.txt",
+			wantAtime: stamped,
+			wantMtime: oldMtime,
+		},
+		{
+			name: "set the access and modification times independently",
+			setup: func(t *testing.T, m *filesystem.MemFS) {
+				t.Helper()
+				memCreate(t, m, "existing.txt", oldAtime, oldMtime)
+			},
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("set-atime", "2024-01-02 03:04:05")
+				cmd.Flags().Set("set-mtime", "202406070809.10")
+			},
+			file:      "existing.txt",
+			wantAtime: refAtime,
+			wantMtime: refMtime,
+		},
+		{
+			name: "normalize to a 2-second filesystem",
+			setup: func(t *testing.T, m *filesystem.MemFS) {
+				t.Helper()
+				memCreate(t, m, "existing.txt",
+					oldAtime.Add(1750*time.Millisecond), oldMtime.Add(3500*time.Millisecond))
+
+				m.Granularity = 2 * time.Second
+			},
+			flagSetup: func(cmd *cobra.Command) { cmd.Flags().Set("normalize-to-fs", "true") },
+			file:      "existing.txt",
+			wantAtime: oldAtime,
+			wantMtime: oldMtime.Add(2 * time.Second),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := filesystem.NewMemFS()
+			tt.setup(t, m)
+
+			filesystem.Default = m // Override default FS with an in-memory one.
+
+			if err := RunTouch(createTestCmd(tt.flagSetup), []string{tt.file}); err != nil {
+				t.Fatalf("RunTouch() error = %v", err)
+			}
+
+			info, err := m.Stat(tt.file)
+			if err != nil {
+				t.Fatalf("MemFS.Stat() error = %v", err)
+			}
+
+			atime := platform.GetAtime(info)
+			if !atime.Equal(tt.wantAtime) || !info.ModTime().Equal(tt.wantMtime) {
+				t.Errorf("RunTouch() set times %v, %v, want %v, %v", atime, info.ModTime(), tt.wantAtime, tt.wantMtime)
+			}
+		})
+	}
+}
+
+func TestRunTouch_monotonicNow(t *testing.T) {
+	// Freeze the clock, so only --monotonic-now can tell the files' times apart.
+	fixedNow := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+	clock := core.ClockFunc(func() core.Time { return fixedNow })
+
+	m := &filesystem.MemFS{Clock: clock}
+	filesystem.Default = m // Override default FS with an in-memory one.
+
+	files := []string{"a.txt", "b.txt", "c.txt", "d.txt"}
+
+	opts := touchOptions{
+		changeTimes: core.ChAtime | core.ChMtime,
+		monotonic:   true,
+		jobs:        1,
+		clock:       clock,
+	}
+	if err := runTouch(context.Background(), opts, files); err != nil {
+		t.Fatalf("runTouch() error = %v", err)
+	}
+
+	var prev core.Time
+
+	for i, file := range files {
+		info, err := m.Stat(file)
+		if err != nil {
+			t.Fatalf("MemFS.Stat() error = %v", err)
+		}
+
+		if i > 0 && !info.ModTime().After(prev) {
+			t.Errorf("%s mtime = %v, want after %v", file, info.ModTime(), prev)
+		}
+
+		prev = info.ModTime()
+	}
+}
+
+func TestRunTouch_memFSContent(t *testing.T) {
+	// Freeze the clock; MemFS stamps the directories made by -p from it too.
+	fixedNow := time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC)
+	clock := core.ClockFunc(func() core.Time { return fixedNow })
+
+	m := &filesystem.MemFS{Clock: clock}
+	filesystem.Default = m // Override default FS with an in-memory one.
+
+	file := filepath.Join("dir", "new.txt")
+
+	opts := touchOptions{
+		changeTimes: core.ChAtime | core.ChMtime,
+		parents:     true,
+		content:     "hello",
+		clock:       clock,
+	}
+	if err := runTouch(context.Background(), opts, []string{file}); err != nil {
+		t.Fatalf("runTouch() error = %v", err)
+	}
+
+	if data, err := m.ReadFile(file); err != nil || string(data) != "hello" {
+		t.Errorf("MemFS.ReadFile() = %q, %v, want %q", data, err, "hello")
+	}
+
+	for _, name := range []string{"dir", file} {
+		info, err := m.Stat(name)
+		if err != nil {
+			t.Fatalf("MemFS.Stat(%s) error = %v", name, err)
+		}
+
+		if !info.ModTime().Equal(fixedNow) {
+			t.Errorf("%s mtime = %v, want %v", name, info.ModTime(), fixedNow)
+		}
+	}
+}
+
+func TestRunTouch_dryRunDiff(t *testing.T) {
+	atime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mtime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m := filesystem.NewMemFS()
+	filesystem.Default = m // Override default FS with an in-memory one.
+
+	memCreate(t, m, "a.txt", atime, mtime)
+
+	// Capture stdout.
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	cmd := createTestCmd(func(cmd *cobra.Command) {
+		cmd.Flags().Set("dry-run", "true")
+		cmd.Flags().Set("diff", "true")
+		cmd.Flags().Set("modification", "true")
+		cmd.Flags().Set("date", "2025-07-13T14:30:00Z")
+	})
+	err := RunTouch(cmd, []string{"a.txt"})
+
+	w.Close()
+
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	if err != nil {
+		t.Fatalf("RunTouch() error = %v", err)
+	}
+
+	want := "would set times on \"a.txt\"\n" +
+		"  atime: 2025-01-02T03:04:05Z -> 2025-01-02T03:04:05Z (unchanged)\n" +
+		"  mtime: 2025-01-01T00:00:00Z -> 2025-07-13T14:30:00Z\n"
+	if got := buf.String(); got != want {
+		t.Errorf("RunTouch() stdout = %q, want %q", got, want)
+	}
+
+	// Nothing may change during a dry run.
+	info, err := m.Stat("a.txt")
+	if err != nil {
+		t.Fatalf("MemFS.Stat() error = %v", err)
+	}
+
+	if !info.ModTime().Equal(mtime) {
+		t.Errorf("a.txt mtime = %v, want unchanged %v", info.ModTime(), mtime)
+	}
+}
+
+// memCreate creates name in m with the given times.
+func memCreate(t *testing.T, m *filesystem.MemFS, name string, atime, mtime core.Time) {
+	t.Helper()
+
+	file, err := m.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file.Close()
+
+	if err := m.Chtimes(name, atime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -21,14 +21,15 @@ package cli
 
 import (
 	"fmt"
-	"os"
-	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nicholas-fedor/touch/internal/core"
 	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/platform"
+	"github.com/nicholas-fedor/touch/internal/timestamp"
 )
 
 // Constants for repeated string values.
@@ -38,13 +39,129 @@ const (
 	timeUse    = "use"
 	timeModify = "modify"
 	timeMtime  = "mtime"
+	timeBirth  = "birth"
 	osWindows  = "windows"
 )
 
+// touchOptions holds the validated command-line options for a touch run.
+type touchOptions struct {
+	changeTimes  int          // Mask of timestamps to change (core.ChAtime, core.ChMtime, core.ChBtime).
+	noCreate     bool         // Do not create missing files.
+	ifExists     bool         // Skip missing and vanished files without counting them; implies noCreate.
+	parents      bool         // Create missing parent directories of new files (-p).
+	noDeref      bool         // Affect symlinks instead of the files they reference.
+	recursive    bool         // Touch every entry in the trees of directory operands (-R).
+	noGlob       bool         // Take operands containing *, ?, or [ literally instead of expanding them.
+	keepLinks    bool         // Restore symlinks' own times after touching their targets.
+	refFilePath  string       // Reference file to copy times from (-r).
+	refAtime     string       // Reference file to copy the access time from (--reference-atime).
+	refMtime     string       // Reference file to copy the modification time from (--reference-mtime).
+	reduce       string       // Reduction over comma-separated references (--reduce).
+	preferBirth  bool         // Use the reference's birth time in place of its mtime when available.
+	swap         bool         // Exchange the reference's access and modification times (--swap).
+	truncateTo   string       // Unit (day, hour, minute) the computed times are rounded down to.
+	tStamp       string       // POSIX timestamp (-t).
+	dateStr      string       // Date string (-d).
+	dateFormat   string       // Go time layout for dateStr (--format); empty uses the built-in formats.
+	setAtime     string       // Date or POSIX stamp for the access time alone (--set-atime).
+	setMtime     string       // Date or POSIX stamp for the modification time alone (--set-mtime).
+	utc          bool         // Interpret -t, -d, and obsolete stamps in UTC instead of local time.
+	timezone     string       // IANA zone -t, -d, and obsolete stamps are interpreted in instead.
+	newestUnder  string       // Directory whose newest entry provides the times.
+	newestType   string       // Content type whose newest file under typeDir provides the times.
+	typeDir      string       // Directory searched for files of newestType (--dir).
+	newestAtime  string       // Comma-separated references; the newest by atime provides the times.
+	oldestAtime  string       // Comma-separated references; the oldest by atime provides the times.
+	maxChange    string       // Comma-separated references; their latest mtime or ctime provides the times.
+	oldestCtime  string       // Comma-separated references; their earliest ctime provides the times.
+	refGlob      string       // Glob whose matches' mtimes provide the times at percentile.
+	percentile   float64      // Nearest-rank percentile, 0 to 100, selected from refGlob's mtimes.
+	minAfter     core.Time    // Select refGlob's earliest mtime after this instead; zero uses percentile.
+	modeGlob     string       // Glob whose matches' most common mtime provides the times.
+	mountRef     string       // Path whose filesystem mount time provides the times.
+	sshRef       string       // Remote [user@]host:path reference read over SSH.
+	buildInfo    string       // .buildinfo file whose BuildTime provides the times.
+	rsyncList    string       // rsync --list-only listing whose newest entry provides the times.
+	warc         string       // WARC archive whose record for warcTarget provides the times.
+	warcTarget   string       // Target URI of the WARC record whose WARC-Date is used.
+	ociRef       string       // Image tarball whose config's created date provides the times.
+	fsImage      string       // Squashfs or erofs image whose newest member's mtime provides the times.
+	lockfile     string       // JSON lockfile whose lockEntry records the times.
+	lockEntry    string       // Package in lockfile whose time field is used.
+	lockPath     string       // Dot-separated path of the time field within lockEntry.
+	gitNewest    string       // Git pathspec whose newest commit time provides the times.
+	seed         string       // String whose SHA-256 selects the times (--reference-seed).
+	nextCron     string       // Cron expression whose next occurrence after now provides the times.
+	seedWindow   string       // START,END window for seeded times; empty selects the default.
+	sizeTime     bool         // Map each file's size linearly into [sizeStart, sizeEnd] for its times.
+	sizeStart    core.Time    // Time given to empty files under sizeTime.
+	sizeEnd      core.Time    // Time given to files of sizeMax bytes or more under sizeTime.
+	sizeMax      int64        // File size mapped to sizeEnd under sizeTime.
+	statTime     string       // Expression over each file's stats giving its times in epoch seconds.
+	jsonlTimes   string       // JSON Lines source ("-" for stdin) of per-file times.
+	filesFrom    string       // File ("-" for stdin) listing further file operands, one per line.
+	null         bool         // Split filesFrom on NUL bytes instead of newlines (-z).
+	ancestorRef  bool         // Take each file's times from its nearest existing ancestor directory.
+	normLinks    bool         // Set each symlink's own times to those of its target.
+	normFS       bool         // Truncate each file's times to its filesystem's timestamp granularity.
+	bootRef      bool         // Use the approximate system boot time.
+	procFDs      string       // PID whose newest open file's mtime provides the times (Linux only).
+	unitRef      string       // Systemd unit whose last activation provides the times (Linux only).
+	selfAtime    bool         // Use the access time of this program's executable.
+	clock        core.Clock   // Source of the current time; nil reads core.Now.
+	monotonic    bool         // Use a strictly increasing clock for the current time.
+	perFileNow   bool         // Read each file's times from the monotonic clock, set by runTouch.
+	floorToDir   bool         // Never apply times earlier than the containing directory's mtime.
+	skipNetFS    bool         // Leave files on network filesystems untouched.
+	olderThan    core.Time    // Leave existing files modified at or after this untouched, set by runTouch.
+	uuidTime     bool         // Take each UUIDv1 or UUIDv7 named file's mtime from its name.
+	sidecar      string       // Suffix of per-file sidecars whose time overrides the global time.
+	strict       bool         // Fail on malformed input or unsupported -h instead of warning and continuing.
+	clampNew     bool         // Clamp times of newly created files to now.
+	forwardOnly  bool         // Never move an existing file's access or modification time backward.
+	special      bool         // Touch FIFOs, sockets, and devices instead of rejecting them.
+	content      string       // Initial content for newly created files (--content).
+	contentFile  string       // File ("-" for stdin) holding initial content for new files.
+	execTemplate string       // Command run after each successful touch, with {} as the file name.
+	auditLog     string       // File appended with a record of each file's time changes.
+	audit        *auditLogger // Writer for auditLog, set up by RunTouch.
+	manifest     string       // File written with each touched file's final times and content hash.
+	manifestLog  *manifestLog // Entries for manifest, collected by RunTouch.
+	journal      bool         // Report each file's time changes to the system journal.
+	verbose      bool         // Print each successfully touched file to stdout.
+	quiet        bool         // Suppress per-file error messages and warnings on stderr.
+	summary      bool         // Print the numbers of files updated and failed to stderr at the end.
+	histogram    bool         // Print a histogram of the final modification times to stderr at the end.
+	dryRun       bool         // Report what would change on stdout without modifying anything.
+	diff         bool         // With dryRun, also report each existing file's old and new times.
+	atomic       bool         // Restore every touched file if any file fails.
+	noObsolete   bool         // Never take the first operand for an obsolete timestamp, as with Run.
+	jobs         int          // Maximum number of files touched or comma-separated references read at once; 0 uses runtime.NumCPU, 1 touches in order without goroutines.
+
+	// olderAge, if non-zero, is the age existing files must exceed to be touched, turned into
+	// olderThan against the clock by runTouch.
+	olderAge time.Duration
+	// adjust, if non-zero, shifts each file's existing times instead of setting new ones.
+	adjust time.Duration
+	// histBucket is the width of each --histogram bucket, set only with histogram.
+	histBucket time.Duration
+	// histLog collects the final modification times for histogram, set up by RunTouch.
+	histLog *histogramLog
+	// progress receives each file's outcome for Options.OnFile, set up by Run.
+	progress *progressLog
+	// rollbackEntry records the original state of the file being touched under atomic,
+	// set up per file by applyToFilesCtx.
+	rollbackEntry *rollbackEntry
+	// statExpr is the parsed statTime, set up by RunTouch.
+	statExpr *timestamp.StatExpr
+	// fsGrain caches each filesystem's timestamp granularity for normFS, set up by RunTouch.
+	fsGrain *core.GranularityCache
+}
+
 // processFlags processes and validates command-line flags from the Cobra command.
-// It returns the flags as parameters for the touch operation and checks for invalid combinations.
-// It also emits warnings for platform-specific limitations (e.g., no-dereference on Windows).
-func processFlags(cmd *cobra.Command) (int, bool, bool, string, string, string, error) {
+// It returns the flags as Options for the touch operation and checks for invalid combinations
+// of the flags Options has no field for, leaving the rest to Options.touchOptions.
+func processFlags(cmd *cobra.Command) (Options, error) {
 	// Initialize defaults: change both access and modification times.
 	changeTimes := core.ChAtime | core.ChMtime
 
@@ -61,8 +178,10 @@ func processFlags(cmd *cobra.Command) (int, bool, bool, string, string, string,
 			changeTimes = core.ChAtime
 		case timeModify, timeMtime:
 			changeTimes = core.ChMtime
+		case timeBirth:
+			changeTimes = core.ChBtime
 		default:
-			return 0, false, false, "", "", "", errors.ErrInvalidTimeArg
+			return Options{}, errors.ErrInvalidTimeArg
 		}
 	case access && !modification:
 		changeTimes = core.ChAtime
@@ -73,33 +192,526 @@ func processFlags(cmd *cobra.Command) (int, bool, bool, string, string, string,
 	// Handle -c/--no-create flag.
 	noCreate, _ := cmd.Flags().GetBool("no-create")
 
-	// Handle -h/--no-dereference flag, warn if used on Windows.
+	// Handle --if-exists, which takes precedence over -c for missing files: they are skipped
+	// and left out of the counts rather than counted as touched.
+	ifExists, _ := cmd.Flags().GetBool("if-exists")
+
+	// Handle -p/--parents flag, applied only when a file is created.
+	parents, _ := cmd.Flags().GetBool("parents")
+
+	// Handle -h/--no-dereference flag.
 	noDeref, _ := cmd.Flags().GetBool("no-dereference")
-	if noDeref && runtime.GOOS == osWindows {
-		fmt.Fprintln(
-			os.Stderr,
-			"Warning: -h/--no-dereference is not supported on Windows; symlinks will be followed",
-		)
 
-		noDeref = false
-	}
+	// Handle -R/--recursive flag, which expands directory operands when touching.
+	recursive, _ := cmd.Flags().GetBool("recursive")
+
+	// Handle --no-glob, which keeps operands the shell left unexpanded as literal names.
+	noGlob, _ := cmd.Flags().GetBool("no-glob")
+
+	// Handle --preserve-link-times, which only matters when symlinks are followed.
+	keepLinks, _ := cmd.Flags().GetBool("preserve-link-times")
 
-	// Handle time source flags: -r, -t, -d.
+	// Handle time source flags: -r, -t, -d, and the --reference-* variants.
 	refFilePath, _ := cmd.Flags().GetString("reference")
+	refAtime, _ := cmd.Flags().GetString("reference-atime")
+	refMtime, _ := cmd.Flags().GetString("reference-mtime")
 	tStamp, _ := cmd.Flags().GetString("stamp")
 	dateStr, _ := cmd.Flags().GetString("date")
+	dateFormat, _ := cmd.Flags().GetString("format")
+	utc, _ := cmd.Flags().GetBool("utc")
+	timezone, _ := cmd.Flags().GetString("timezone")
+	newestUnder, _ := cmd.Flags().GetString("reference-newest-under")
+	newestType, _ := cmd.Flags().GetString("reference-newest-type")
+	newestAtime, _ := cmd.Flags().GetString("reference-newest-atime")
+	oldestAtime, _ := cmd.Flags().GetString("reference-oldest-atime")
+	maxChange, _ := cmd.Flags().GetString("reference-max-change")
+	oldestCtime, _ := cmd.Flags().GetString("reference-oldest-ctime")
+	refGlob, _ := cmd.Flags().GetString("reference-glob")
+	modeGlob, _ := cmd.Flags().GetString("reference-mode-glob")
+	mountRef, _ := cmd.Flags().GetString("reference-mount")
+	sshRef, _ := cmd.Flags().GetString("reference-ssh")
+	buildInfo, _ := cmd.Flags().GetString("buildinfo")
+	rsyncList, _ := cmd.Flags().GetString("rsync-list")
+	warc, _ := cmd.Flags().GetString("warc")
+	ociRef, _ := cmd.Flags().GetString("reference-oci")
+	fsImage, _ := cmd.Flags().GetString("reference-image")
+	lockfile, _ := cmd.Flags().GetString("lockfile")
+	gitNewest, _ := cmd.Flags().GetString("reference-git-newest")
+	seed, _ := cmd.Flags().GetString("reference-seed")
+	nextCron, _ := cmd.Flags().GetString("next-cron")
+	jsonlTimes, _ := cmd.Flags().GetString("jsonl-times")
+	ancestorRef, _ := cmd.Flags().GetBool("reference-ancestor")
+	normLinks, _ := cmd.Flags().GetBool("normalize-symlink-times")
+	normFS, _ := cmd.Flags().GetBool("normalize-to-fs")
+	bootRef, _ := cmd.Flags().GetBool("reference-boot")
+	procFDs, _ := cmd.Flags().GetString("reference-proc-fds")
+	unitRef, _ := cmd.Flags().GetString("reference-unit")
+	selfAtime, _ := cmd.Flags().GetBool("reference-self-atime")
+	sizeTime, _ := cmd.Flags().GetBool("size-time")
+	statTime, _ := cmd.Flags().GetString("stat-time")
+
+	// Handle --reference-atime and --reference-mtime, which together form a single time source.
+	if (refAtime == "") != (refMtime == "") {
+		return Options{}, errors.ErrSplitReferenceUnpaired
+	}
+
+	// Handle --set-atime and --set-mtime, which together form a single time source and select
+	// the times to change; -a, -m, or --time may be given too, but only if they agree.
+	setAtime, _ := cmd.Flags().GetString("set-atime")
+	setMtime, _ := cmd.Flags().GetString("set-mtime")
+
+	if setAtime != "" || setMtime != "" {
+		setChange := 0
+		if setAtime != "" {
+			setChange |= core.ChAtime
+		}
+
+		if setMtime != "" {
+			setChange |= core.ChMtime
+		}
+
+		if (timeFlag != "" || access || modification) && changeTimes != setChange {
+			return Options{}, errors.ErrSetTimeConflict
+		}
+
+		changeTimes = setChange
+	}
+
+	// Handle --reduce, which turns -r into a comma-separated list of references.
+	reduce, _ := cmd.Flags().GetString("reduce")
+	if reduce != "" {
+		if refFilePath == "" {
+			return Options{}, errors.ErrReduceWithoutReference
+		}
+
+		if !timestamp.IsValidReduction(reduce) {
+			return Options{}, fmt.Errorf("%w: %s", errors.ErrInvalidReduction, reduce)
+		}
+	}
+
+	// Handle --prefer-birth, which only applies to a single -r reference.
+	preferBirth, _ := cmd.Flags().GetBool("prefer-birth")
+	if preferBirth && (refFilePath == "" || reduce != "") {
+		return Options{}, errors.ErrPreferBirthWithoutReference
+	}
+
+	// Handle --swap, which only applies to -r references.
+	swap, _ := cmd.Flags().GetBool("swap")
+	if swap && refFilePath == "" {
+		return Options{}, errors.ErrSwapWithoutReference
+	}
+
+	// Handle --seed-window, which only bounds --reference-seed.
+	seedWindow, _ := cmd.Flags().GetString("seed-window")
+	if seedWindow != "" && seed == "" {
+		return Options{}, errors.ErrSeedWindowWithoutSeed
+	}
+
+	// Handle --size-window and --size-max, which configure --size-time.
+	sizeWindow, _ := cmd.Flags().GetString("size-window")
+	sizeMax, _ := cmd.Flags().GetInt64("size-max")
+
+	var sizeStart, sizeEnd core.Time
+
+	switch {
+	case !sizeTime && (sizeWindow != "" || sizeMax != 0):
+		return Options{}, errors.ErrSizeOptionsWithoutSizeTime
+	case sizeTime && sizeMax <= 0:
+		return Options{}, fmt.Errorf("%w: %d", errors.ErrInvalidSizeMax, sizeMax)
+	case sizeTime:
+		if sizeWindow == "" {
+			sizeWindow = timestamp.DefaultSeedWindow
+		}
+
+		var err error
+
+		sizeStart, sizeEnd, err = timestamp.ParseSizeWindow(sizeWindow)
+		if err != nil {
+			return Options{}, err
+		}
+	}
+
+	// Handle --dir, which names the directory searched by --reference-newest-type.
+	typeDir, _ := cmd.Flags().GetString("dir")
+	if (typeDir == "") != (newestType == "") {
+		return Options{}, errors.ErrNewestTypeWithoutDir
+	}
+
+	// Handle --lock-entry and --lock-path, which select the time read from --lockfile.
+	lockEntry, _ := cmd.Flags().GetString("lock-entry")
+	if (lockEntry == "") != (lockfile == "") {
+		return Options{}, errors.ErrLockfileWithoutEntry
+	}
+
+	var lockPath string
+
+	switch {
+	case lockfile != "":
+		lockPath, _ = cmd.Flags().GetString("lock-path")
+	case cmd.Flags().Changed("lock-path"):
+		return Options{}, errors.ErrLockPathWithoutLockfile
+	}
+
+	// Handle --warc-target, which names the record read from --warc.
+	warcTarget, _ := cmd.Flags().GetString("warc-target")
+	if (warcTarget == "") != (warc == "") {
+		return Options{}, errors.ErrWARCWithoutTarget
+	}
+
+	// Handle --reference-percentile and --reference-min-after, either of which selects among
+	// the matches of --reference-glob.
+	percentileStr, _ := cmd.Flags().GetString("reference-percentile")
+	minAfterStr, _ := cmd.Flags().GetString("reference-min-after")
+
+	switch {
+	case percentileStr != "" && minAfterStr != "":
+		return Options{}, errors.ErrMultipleTimeSources
+	case minAfterStr != "" && refGlob == "":
+		return Options{}, errors.ErrMinAfterWithoutGlob
+	case minAfterStr == "" && (percentileStr == "") != (refGlob == ""):
+		return Options{}, errors.ErrPercentileWithoutGlob
+	}
+
+	var minAfter core.Time
+
+	if minAfterStr != "" {
+		var err error
+
+		minAfter, err = timestamp.ParseMinAfter(minAfterStr)
+		if err != nil {
+			return Options{}, err
+		}
+	}
+
+	var percentile float64
+
+	if percentileStr != "" {
+		var err error
+
+		percentile, err = timestamp.ParsePercentile(percentileStr)
+		if err != nil {
+			return Options{}, err
+		}
+	}
+
+	// Handle --stat-time, parsed here so an invalid expression fails before any file is touched.
+	if statTime != "" {
+		if _, err := timestamp.ParseStatExpr(statTime); err != nil {
+			return Options{}, err
+		}
+	}
+
+	// Handle --format, which only sets the layout of -d.
+	if dateFormat != "" && dateStr == "" {
+		return Options{}, errors.ErrFormatWithoutDate
+	}
+
+	// Handle --truncate-to, which rounds the computed times down.
+	truncateTo, _ := cmd.Flags().GetString("truncate-to")
+	if truncateTo != "" && !timestamp.IsValidTruncation(truncateTo) {
+		return Options{}, fmt.Errorf("%w: %s", errors.ErrInvalidTruncation, truncateTo)
+	}
+
+	// Handle --monotonic-now, which only affects the default current time.
+	monotonic, _ := cmd.Flags().GetBool("monotonic-now")
+
+	// Handle --floor-to-dir, applied per file when touching.
+	floorToDir, _ := cmd.Flags().GetBool("floor-to-dir")
+
+	// Handle --skip-network-fs, checked per file when touching.
+	skipNetFS, _ := cmd.Flags().GetBool("skip-network-fs")
+
+	// Handle --only-older-than, turned into a cutoff checked against each file's mtime.
+	var olderAge time.Duration
+
+	if age, _ := cmd.Flags().GetString("only-older-than"); age != "" {
+		var err error
+
+		olderAge, err = timestamp.ParseAge(age)
+		if err != nil {
+			return Options{}, err
+		}
+	}
+
+	// Handle --uuid-time, applied per file when touching.
+	uuidTime, _ := cmd.Flags().GetBool("uuid-time")
+
+	// Handle --time-sidecars, applied per file when touching.
+	sidecar, _ := cmd.Flags().GetString("time-sidecars")
+
+	// Handle --strict, which turns recoverable input problems into errors. Without it, -h
+	// where symlink times can't be set follows each link with a warning when touching.
+	strict, _ := cmd.Flags().GetBool("strict")
+	if strict && noDeref && !platform.SetTimesNoDerefSupported {
+		return Options{}, errors.ErrNoDerefUnsupported
+	}
+
+	// Handle --clamp-new-to-now, applied only when a file is created.
+	clampNew, _ := cmd.Flags().GetBool("clamp-new-to-now")
+
+	// Handle --monotonic-components, applied to each of the access and modification times.
+	forwardOnly, _ := cmd.Flags().GetBool("monotonic-components")
+
+	// Handle --special, which allows touching FIFOs, sockets, and devices.
+	special, _ := cmd.Flags().GetBool("special")
+
+	// Handle --content and --content-file, which are mutually exclusive.
+	content, _ := cmd.Flags().GetString("content")
+	contentFile, _ := cmd.Flags().GetString("content-file")
+
+	if content != "" && contentFile != "" {
+		return Options{}, errors.ErrMultipleContentSources
+	}
+
+	// Handle --files-from, which can't share standard input with another input.
+	filesFrom, _ := cmd.Flags().GetString("files-from")
+	if filesFrom == stdinName && (contentFile == stdinName || jsonlTimes == stdinName) {
+		return Options{}, errors.ErrMultipleStdinReaders
+	}
+
+	// Handle -z/--null, which only changes how --files-from is split.
+	null, _ := cmd.Flags().GetBool("null")
+
+	// Handle --exec, run after each successfully touched file.
+	execTemplate, _ := cmd.Flags().GetString("exec")
+
+	// Handle --audit-log, appended after each successfully touched file.
+	auditLog, _ := cmd.Flags().GetString("audit-log")
+
+	// Handle --manifest, written once all files are processed.
+	manifest, _ := cmd.Flags().GetString("manifest")
+
+	// Handle --journal, reported after each successfully touched file.
+	journal, _ := cmd.Flags().GetBool("journal")
+
+	// Handle --verbose, printed after each successfully touched file.
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	// Handle --quiet, which leaves per-file errors and warnings off stderr.
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	// Handle --summary, printed once all files are processed.
+	summary, _ := cmd.Flags().GetBool("summary")
+
+	// Handle --histogram, printed once all files are processed, and its bucket width.
+	histogram, _ := cmd.Flags().GetBool("histogram")
+
+	var histBucket time.Duration
+
+	switch {
+	case histogram:
+		histBucket, _ = cmd.Flags().GetDuration("histogram-bucket")
+		if histBucket <= 0 {
+			return Options{}, fmt.Errorf("%w: %v", errors.ErrInvalidHistogramBucket, histBucket)
+		}
+	case cmd.Flags().Changed("histogram-bucket"):
+		return Options{}, errors.ErrBucketWithoutHistogram
+	}
+
+	// Handle --dry-run, which reports changes instead of making them.
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	// Handle --diff, which details the dry run's changes of times.
+	diff, _ := cmd.Flags().GetBool("diff")
+	if diff && !dryRun {
+		return Options{}, errors.ErrDiffWithoutDryRun
+	}
+
+	// Handle --atomic, which rolls every file back if any fails.
+	atomic, _ := cmd.Flags().GetBool("atomic")
+
+	// Handle -j/--jobs, which bounds how many files are touched at once.
+	jobs, _ := cmd.Flags().GetInt("jobs")
+
+	// Handle --sequential, which is the same as --jobs 1.
+	sequential, _ := cmd.Flags().GetBool("sequential")
+	if sequential {
+		if jobs > 1 {
+			return Options{}, fmt.Errorf("%w: --jobs %d", errors.ErrSequentialWithJobs, jobs)
+		}
+
+		if jobs == 0 {
+			jobs = 1
+		}
+	}
+
+	// Handle --adjust, which shifts existing times and so can't take them from any source.
+	var adjust time.Duration
+
+	if adjustStr, _ := cmd.Flags().GetString("adjust"); adjustStr != "" {
+		var err error
 
-	// Check for multiple time sources, which is invalid.
-	timeSources := core.BoolToInt(
-		refFilePath != "",
+		adjust, err = timestamp.ParseAdjustment(adjustStr)
+		if err != nil {
+			return Options{}, err
+		}
+	}
+
+	return Options{
+		ChangeTimes: changeTimes,
+		NoCreate:    noCreate,
+		IfExists:    ifExists,
+		NoDeref:     noDeref,
+		Parents:     parents,
+		Reference:   refFilePath,
+		Stamp:       tStamp,
+		Date:        dateStr,
+		UTC:         utc,
+		Timezone:    timezone,
+		DryRun:      dryRun,
+		Verbose:     verbose,
+		Special:     special,
+		Jobs:        jobs,
+		OlderThan:   olderAge,
+		flags: &touchOptions{
+			recursive:    recursive,
+			noGlob:       noGlob,
+			keepLinks:    keepLinks,
+			refAtime:     refAtime,
+			refMtime:     refMtime,
+			reduce:       reduce,
+			preferBirth:  preferBirth,
+			swap:         swap,
+			truncateTo:   truncateTo,
+			dateFormat:   dateFormat,
+			setAtime:     setAtime,
+			setMtime:     setMtime,
+			newestUnder:  newestUnder,
+			newestType:   newestType,
+			typeDir:      typeDir,
+			newestAtime:  newestAtime,
+			oldestAtime:  oldestAtime,
+			maxChange:    maxChange,
+			oldestCtime:  oldestCtime,
+			refGlob:      refGlob,
+			percentile:   percentile,
+			minAfter:     minAfter,
+			modeGlob:     modeGlob,
+			mountRef:     mountRef,
+			sshRef:       sshRef,
+			buildInfo:    buildInfo,
+			rsyncList:    rsyncList,
+			warc:         warc,
+			warcTarget:   warcTarget,
+			ociRef:       ociRef,
+			fsImage:      fsImage,
+			lockfile:     lockfile,
+			lockEntry:    lockEntry,
+			lockPath:     lockPath,
+			gitNewest:    gitNewest,
+			seed:         seed,
+			nextCron:     nextCron,
+			seedWindow:   seedWindow,
+			sizeTime:     sizeTime,
+			sizeStart:    sizeStart,
+			sizeEnd:      sizeEnd,
+			sizeMax:      sizeMax,
+			statTime:     statTime,
+			jsonlTimes:   jsonlTimes,
+			filesFrom:    filesFrom,
+			null:         null,
+			ancestorRef:  ancestorRef,
+			normLinks:    normLinks,
+			normFS:       normFS,
+			bootRef:      bootRef,
+			procFDs:      procFDs,
+			unitRef:      unitRef,
+			selfAtime:    selfAtime,
+			monotonic:    monotonic,
+			floorToDir:   floorToDir,
+			skipNetFS:    skipNetFS,
+			uuidTime:     uuidTime,
+			sidecar:      sidecar,
+			strict:       strict,
+			clampNew:     clampNew,
+			forwardOnly:  forwardOnly,
+			content:      content,
+			contentFile:  contentFile,
+			execTemplate: execTemplate,
+			auditLog:     auditLog,
+			manifest:     manifest,
+			journal:      journal,
+			quiet:        quiet,
+			summary:      summary,
+			histogram:    histogram,
+			diff:         diff,
+			atomic:       atomic,
+			adjust:       adjust,
+			histBucket:   histBucket,
+		},
+	}, nil
+}
+
+// timeSources returns how many sources of times opts selects, counting a split reference pair
+// or a --set-atime and --set-mtime pair once.
+func (opts touchOptions) timeSources() int {
+	return core.BoolToInt(
+		opts.refFilePath != "",
+	) + core.BoolToInt(
+		opts.refAtime != "",
+	) + core.BoolToInt(
+		opts.tStamp != "",
+	) + core.BoolToInt(
+		opts.dateStr != "",
+	) + core.BoolToInt(
+		opts.setAtime != "" || opts.setMtime != "",
+	) + core.BoolToInt(
+		opts.newestUnder != "",
+	) + core.BoolToInt(
+		opts.newestType != "",
+	) + core.BoolToInt(
+		opts.newestAtime != "",
+	) + core.BoolToInt(
+		opts.oldestAtime != "",
+	) + core.BoolToInt(
+		opts.maxChange != "",
+	) + core.BoolToInt(
+		opts.oldestCtime != "",
+	) + core.BoolToInt(
+		opts.refGlob != "",
+	) + core.BoolToInt(
+		opts.modeGlob != "",
+	) + core.BoolToInt(
+		opts.mountRef != "",
+	) + core.BoolToInt(
+		opts.sshRef != "",
 	) + core.BoolToInt(
-		tStamp != "",
+		opts.buildInfo != "",
 	) + core.BoolToInt(
-		dateStr != "",
+		opts.rsyncList != "",
+	) + core.BoolToInt(
+		opts.warc != "",
+	) + core.BoolToInt(
+		opts.ociRef != "",
+	) + core.BoolToInt(
+		opts.fsImage != "",
+	) + core.BoolToInt(
+		opts.lockfile != "",
+	) + core.BoolToInt(
+		opts.gitNewest != "",
+	) + core.BoolToInt(
+		opts.seed != "",
+	) + core.BoolToInt(
+		opts.nextCron != "",
+	) + core.BoolToInt(
+		opts.jsonlTimes != "",
+	) + core.BoolToInt(
+		opts.ancestorRef,
+	) + core.BoolToInt(
+		opts.normLinks,
+	) + core.BoolToInt(
+		opts.normFS,
+	) + core.BoolToInt(
+		opts.bootRef,
+	) + core.BoolToInt(
+		opts.procFDs != "",
+	) + core.BoolToInt(
+		opts.unitRef != "",
+	) + core.BoolToInt(
+		opts.selfAtime,
+	) + core.BoolToInt(
+		opts.sizeTime,
+	) + core.BoolToInt(
+		opts.statTime != "",
 	)
-	if timeSources > 1 {
-		return 0, false, false, "", "", "", errors.ErrMultipleTimeSources
-	}
-
-	return changeTimes, noCreate, noDeref, refFilePath, tStamp, dateStr, nil
 }
@@ -21,261 +21,1729 @@ package cli
 
 import (
 	"bytes"
+	"fmt"
 	"os"
-	"runtime"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/nicholas-fedor/touch/internal/core"
 	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/platform"
 )
 
 func Test_processFlags(t *testing.T) {
 	tests := []struct {
-		name         string
-		flagSetup    func(*cobra.Command)
-		wantChange   int
-		wantNoCreate bool
-		wantNoDeref  bool
-		wantRef      string
-		wantStamp    string
-		wantDate     string
-		wantErr      error
-		wantStderr   string
+		name       string
+		flagSetup  func(*cobra.Command)
+		want       touchOptions
+		wantErr    error
+		wantStderr string
 	}{
 		{
-			name:         "default no flags",
-			flagSetup:    func(_ *cobra.Command) {},
-			wantChange:   core.ChAtime | core.ChMtime,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			name:      "default no flags",
+			flagSetup: func(_ *cobra.Command) {},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "access only",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("access", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "modification only",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("modification", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChMtime,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "time access",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("time", "access")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "time modify",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("time", "modify")
+			},
+			want: touchOptions{
+				changeTimes: core.ChMtime,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "invalid time",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("time", "invalid")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrInvalidTimeArg,
+			wantStderr: "",
+		},
+		{
+			name: "no create",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("no-create", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				noCreate:    true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "no deref",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("no-dereference", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				noDeref:     true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "parents",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("parents", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				parents:     true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "preserve link times",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("preserve-link-times", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				keepLinks:   true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "recursive",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("recursive", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				recursive:   true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "if exists",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("if-exists", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				ifExists:    true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "special",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("special", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				special:     true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "no glob",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("no-glob", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				noGlob:      true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference file",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference", "ref.txt")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				refFilePath: "ref.txt",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "split access and modification references",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-atime", "a.txt")
+				cmd.Flags().Set("reference-mtime", "m.txt")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				refAtime:    "a.txt",
+				refMtime:    "m.txt",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference atime without reference mtime",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-atime", "a.txt")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrSplitReferenceUnpaired,
+			wantStderr: "",
+		},
+		{
+			name: "reference mtime without reference atime",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-mtime", "m.txt")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrSplitReferenceUnpaired,
+			wantStderr: "",
+		},
+		{
+			name: "multiple time sources split references and stamp",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-atime", "a.txt")
+				cmd.Flags().Set("reference-mtime", "m.txt")
+				cmd.Flags().Set("stamp", "2507131430")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "multiple time sources split references and date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-atime", "a.txt")
+				cmd.Flags().Set("reference-mtime", "m.txt")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "multiple time sources split references and ref",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference", "ref.txt")
+				cmd.Flags().Set("reference-atime", "a.txt")
+				cmd.Flags().Set("reference-mtime", "m.txt")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "size time",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("size-time", "true")
+				cmd.Flags().Set("size-window", "2020-01-01T00:00:00Z,2021-01-01T00:00:00Z")
+				cmd.Flags().Set("size-max", "4096")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				sizeTime:    true,
+				sizeStart:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				sizeEnd:     time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				sizeMax:     4096,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "size time without size max",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("size-time", "true")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: %d", errors.ErrInvalidSizeMax, 0),
+			wantStderr: "",
+		},
+		{
+			name: "size max without size time",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("size-max", "4096")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrSizeOptionsWithoutSizeTime,
+			wantStderr: "",
+		},
+		{
+			name: "multiple time sources size time and stamp",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("size-time", "true")
+				cmd.Flags().Set("size-max", "4096")
+				cmd.Flags().Set("stamp", "2507131430")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "adjust forward",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("adjust", "+1h")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				adjust:      time.Hour,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "adjust backward by offset",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("adjust", "-2 days")
+				cmd.Flags().Set("modification", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChMtime,
+				adjust:      -48 * time.Hour,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "invalid adjust",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("adjust", "soon")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: %q", errors.ErrInvalidAdjustment, "soon"),
+			wantStderr: "",
+		},
+		{
+			name: "adjust with reference",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("adjust", "+1h")
+				cmd.Flags().Set("reference", "ref.txt")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrAdjustWithTimeSource,
+			wantStderr: "",
+		},
+		{
+			name: "adjust with stamp",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("adjust", "+1h")
+				cmd.Flags().Set("stamp", "2507131430")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrAdjustWithTimeSource,
+			wantStderr: "",
+		},
+		{
+			name: "adjust with date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("adjust", "+1h")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrAdjustWithTimeSource,
+			wantStderr: "",
+		},
+		{
+			name: "stamp",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("stamp", "2507131430")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				tStamp:      "2507131430",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				dateStr:     "2025-07-13",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "multiple time sources ref and date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference", "ref.txt")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "multiple time sources stamp and date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("stamp", "2507131430")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "multiple time sources ref and stamp",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference", "ref.txt")
+				cmd.Flags().Set("stamp", "2507131430")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "all time sources",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference", "ref.txt")
+				cmd.Flags().Set("stamp", "2507131430")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "reference newest under",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-newest-under", "build")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				newestUnder: "build",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference newest type",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-newest-type", "image/jpeg")
+				cmd.Flags().Set("dir", "photos")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				newestType:  "image/jpeg",
+				typeDir:     "photos",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference newest type without dir",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-newest-type", "image/jpeg")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrNewestTypeWithoutDir,
+			wantStderr: "",
+		},
+		{
+			name: "dir without reference newest type",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("dir", "photos")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrNewestTypeWithoutDir,
+			wantStderr: "",
+		},
+		{
+			name: "multiple time sources ref and newest under",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference", "ref.txt")
+				cmd.Flags().Set("reference-newest-under", "build")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "multiple time sources date and mount",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("date", "2025-07-13")
+				cmd.Flags().Set("reference-mount", "/")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "buildinfo",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("buildinfo", "app.buildinfo")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				buildInfo:   "app.buildinfo",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "rsync list",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("rsync-list", "listing.txt")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				rsyncList:   "listing.txt",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "rsync list with buildinfo",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("rsync-list", "listing.txt")
+				cmd.Flags().Set("buildinfo", "app.buildinfo")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "reduce references",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference", "a.txt,b.txt")
+				cmd.Flags().Set("reduce", "median")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				refFilePath: "a.txt,b.txt",
+				reduce:      "median",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reduce without reference",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reduce", "max")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrReduceWithoutReference,
+			wantStderr: "",
+		},
+		{
+			name: "invalid reduce",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference", "a.txt,b.txt")
+				cmd.Flags().Set("reduce", "sum")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: sum", errors.ErrInvalidReduction),
+			wantStderr: "",
+		},
+		{
+			name: "reference newest atime",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-newest-atime", "a.txt,b.txt")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				newestAtime: "a.txt,b.txt",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference ancestor",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-ancestor", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				ancestorRef: true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference ancestor with date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-ancestor", "true")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "normalize symlink times",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("normalize-symlink-times", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				normLinks:   true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "normalize symlink times with reference",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("normalize-symlink-times", "true")
+				cmd.Flags().Set("reference", "ref.txt")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "normalize to filesystem",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("normalize-to-fs", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				normFS:      true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "normalize to filesystem with date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("normalize-to-fs", "true")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "utc",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("date", "2025-07-13")
+				cmd.Flags().Set("utc", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				dateStr:     "2025-07-13",
+				utc:         true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "timezone",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("date", "2025-07-13 14:30")
+				cmd.Flags().Set("timezone", "UTC")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				dateStr:     "2025-07-13 14:30",
+				timezone:    "UTC",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "date format",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("date", "13.07.2025")
+				cmd.Flags().Set("format", "02.01.2006")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				dateStr:     "13.07.2025",
+				dateFormat:  "02.01.2006",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "date format without date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("format", "02.01.2006")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrFormatWithoutDate,
+			wantStderr: "",
+		},
+		{
+			name: "invalid timezone",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("timezone", "Mars/Olympus_Mons")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: %q", errors.ErrInvalidTimezone, "Mars/Olympus_Mons"),
+			wantStderr: "",
+		},
+		{
+			name: "timezone with utc",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("timezone", "UTC")
+				cmd.Flags().Set("utc", "true")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrTimezoneWithUTC,
+			wantStderr: "",
+		},
+		{
+			name: "stat time",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("stat-time", "1700000000 + size")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				statTime:    "1700000000 + size",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "invalid stat time",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("stat-time", "1700000000 + inode")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: %q: %s", errors.ErrInvalidStatTime, "1700000000 + inode", `unknown number or variable "inode"`),
+			wantStderr: "",
+		},
+		{
+			name: "stat time with date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("stat-time", "1700000000 + size")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "reference boot",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-boot", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				bootRef:     true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "time sidecars default suffix",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Parse([]string{"--time-sidecars"})
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				sidecar:     ".time",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "time sidecars custom suffix",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Parse([]string{"--time-sidecars=.stamp"})
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				sidecar:     ".stamp",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference oldest atime",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-oldest-atime", "a.txt,b.txt")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				oldestAtime: "a.txt,b.txt",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference max change",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-max-change", "a.txt,b.txt")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				maxChange:   "a.txt,b.txt",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference oldest ctime",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-oldest-ctime", "a.txt,b.txt")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				oldestCtime: "a.txt,b.txt",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference percentile",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-glob", "build/*.o")
+				cmd.Flags().Set("reference-percentile", "90")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				refGlob:     "build/*.o",
+				percentile:  90,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference percentile without glob",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-percentile", "90")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrPercentileWithoutGlob,
+			wantStderr: "",
+		},
+		{
+			name: "reference glob without percentile",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-glob", "build/*.o")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrPercentileWithoutGlob,
+			wantStderr: "",
+		},
+		{
+			name: "reference min after",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-glob", "src/*.go")
+				cmd.Flags().Set("reference-min-after", "2025-07-13T12:00:00Z")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				refGlob:     "src/*.go",
+				minAfter:    time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC),
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference min after without glob",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-min-after", "2025-07-13T12:00:00Z")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMinAfterWithoutGlob,
+			wantStderr: "",
+		},
+		{
+			name: "reference min after with percentile",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-glob", "src/*.go")
+				cmd.Flags().Set("reference-percentile", "90")
+				cmd.Flags().Set("reference-min-after", "2025-07-13T12:00:00Z")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "reference min after not RFC3339",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-glob", "src/*.go")
+				cmd.Flags().Set("reference-min-after", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: %q", errors.ErrInvalidMinAfter, "2025-07-13"),
+			wantStderr: "",
+		},
+		{
+			name: "reference percentile out of range",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-glob", "build/*.o")
+				cmd.Flags().Set("reference-percentile", "150")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: %q", errors.ErrInvalidPercentile, "150"),
+			wantStderr: "",
+		},
+		{
+			name: "reference mode glob",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-mode-glob", "build/*.o")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				modeGlob:    "build/*.o",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference mode glob with percentile glob",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-mode-glob", "build/*.o")
+				cmd.Flags().Set("reference-glob", "build/*.o")
+				cmd.Flags().Set("reference-percentile", "90")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "reference max change with date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-max-change", "a.txt,b.txt")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "files from",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("files-from", "-")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				filesFrom:   "-",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "files from NUL-delimited",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("files-from", "list.txt")
+				cmd.Flags().Set("null", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				filesFrom:   "list.txt",
+				null:        true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "files from and content file both on stdin",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("files-from", "-")
+				cmd.Flags().Set("content-file", "-")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleStdinReaders,
+			wantStderr: "",
+		},
+		{
+			name: "warc",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("warc", "crawl.warc.gz")
+				cmd.Flags().Set("warc-target", "https://example.com/")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				warc:        "crawl.warc.gz",
+				warcTarget:  "https://example.com/",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "warc without target",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("warc", "crawl.warc.gz")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrWARCWithoutTarget,
+			wantStderr: "",
+		},
+		{
+			name: "reference proc fds",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-proc-fds", "4242")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				procFDs:     "4242",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference unit",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-unit", "sshd.service")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				unitRef:     "sshd.service",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference unit with boot",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-unit", "sshd.service")
+				cmd.Flags().Set("reference-boot", "true")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "reference proc fds with boot",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-proc-fds", "4242")
+				cmd.Flags().Set("reference-boot", "true")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "summary",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("summary", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				summary:     true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "invalid only older than",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("only-older-than", "soon")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: %q", errors.ErrInvalidAge, "soon"),
+			wantStderr: "",
+		},
+		{
+			name: "reference oci",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-oci", "image.tar")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				ociRef:      "image.tar",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference oci with warc",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-oci", "image.tar")
+				cmd.Flags().Set("warc", "crawl.warc.gz")
+				cmd.Flags().Set("warc-target", "https://example.com/")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "reference image",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-image", "rootfs.squashfs")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				fsImage:     "rootfs.squashfs",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "lockfile",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("lockfile", "package-lock.json")
+				cmd.Flags().Set("lock-entry", "left-pad")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				lockfile:    "package-lock.json",
+				lockEntry:   "left-pad",
+				lockPath:    "time",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "lockfile with path",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("lockfile", "package-lock.json")
+				cmd.Flags().Set("lock-entry", "left-pad")
+				cmd.Flags().Set("lock-path", "meta.published")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				lockfile:    "package-lock.json",
+				lockEntry:   "left-pad",
+				lockPath:    "meta.published",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "lockfile without entry",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("lockfile", "package-lock.json")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrLockfileWithoutEntry,
+			wantStderr: "",
+		},
+		{
+			name: "lock entry without lockfile",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("lock-entry", "left-pad")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrLockfileWithoutEntry,
+			wantStderr: "",
 		},
 		{
-			name: "access only",
+			name: "lock path without lockfile",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("lock-path", "meta.published")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrLockPathWithoutLockfile,
+			wantStderr: "",
+		},
+		{
+			name: "lockfile with date",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("lockfile", "package-lock.json")
+				cmd.Flags().Set("lock-entry", "left-pad")
+				cmd.Flags().Set("date", "2025-07-13")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "reference image with oci",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-image", "rootfs.squashfs")
+				cmd.Flags().Set("reference-oci", "image.tar")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "exec",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("exec", "chmod 600 {}")
+			},
+			want: touchOptions{
+				changeTimes:  core.ChAtime | core.ChMtime,
+				execTemplate: "chmod 600 {}",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "journal",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("journal", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				journal:     true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "verbose",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("verbose", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				verbose:     true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "quiet",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("quiet", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				quiet:       true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "dry run",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("dry-run", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				dryRun:      true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "dry run with diff",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("dry-run", "true")
+				cmd.Flags().Set("diff", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				dryRun:      true,
+				diff:        true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "diff without dry run",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("diff", "true")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrDiffWithoutDryRun,
+			wantStderr: "",
+		},
+		{
+			name: "set access time",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("set-atime", "2024-01-02")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime,
+				setAtime:    "2024-01-02",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "set modification time",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("set-mtime", "202406070809")
+			},
+			want: touchOptions{
+				changeTimes: core.ChMtime,
+				setMtime:    "202406070809",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "set both times",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("set-atime", "2024-01-02")
+				cmd.Flags().Set("set-mtime", "202406070809")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				setAtime:    "2024-01-02",
+				setMtime:    "202406070809",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "set access time with matching -a",
 			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("set-atime", "2024-01-02")
 				cmd.Flags().Set("access", "true")
 			},
-			wantChange:   core.ChAtime,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			want: touchOptions{
+				changeTimes: core.ChAtime,
+				setAtime:    "2024-01-02",
+			},
+			wantErr:    nil,
+			wantStderr: "",
 		},
 		{
-			name: "modification only",
+			name: "set access time with -m",
 			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("set-atime", "2024-01-02")
 				cmd.Flags().Set("modification", "true")
 			},
-			wantChange:   core.ChMtime,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			want:       touchOptions{},
+			wantErr:    errors.ErrSetTimeConflict,
+			wantStderr: "",
 		},
 		{
-			name: "time access",
+			name: "set both times with --time",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("time", "access")
+				cmd.Flags().Set("set-atime", "2024-01-02")
+				cmd.Flags().Set("set-mtime", "202406070809")
+				cmd.Flags().Set("time", "mtime")
 			},
-			wantChange:   core.ChAtime,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			want:       touchOptions{},
+			wantErr:    errors.ErrSetTimeConflict,
+			wantStderr: "",
 		},
 		{
-			name: "time modify",
+			name: "set access time with reference",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("time", "modify")
+				cmd.Flags().Set("set-atime", "2024-01-02")
+				cmd.Flags().Set("reference", "ref.txt")
 			},
-			wantChange:   core.ChMtime,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
 		},
 		{
-			name: "invalid time",
+			name: "set modification time with stamp",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("time", "invalid")
+				cmd.Flags().Set("set-mtime", "202406070809")
+				cmd.Flags().Set("stamp", "202401020304")
 			},
-			wantChange:   0,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      errors.ErrInvalidTimeArg,
-			wantStderr:   "",
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
 		},
 		{
-			name: "no create",
+			name: "set both times with date",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("no-create", "true")
+				cmd.Flags().Set("set-atime", "2024-01-02")
+				cmd.Flags().Set("set-mtime", "202406070809")
+				cmd.Flags().Set("date", "2025-07-13")
 			},
-			wantChange:   core.ChAtime | core.ChMtime,
-			wantNoCreate: true,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
 		},
 		{
-			name: "no deref non-windows",
+			name: "histogram",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("no-dereference", "true")
+				cmd.Flags().Set("histogram", "true")
 			},
-			wantChange:   core.ChAtime | core.ChMtime,
-			wantNoCreate: false,
-			wantNoDeref:  runtime.GOOS != osWindows,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr: func() string {
-				if runtime.GOOS == osWindows {
-					return "Warning: -h/--no-dereference is not supported on Windows; symlinks will be followed\n"
-				}
-
-				return ""
-			}(),
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				histogram:   true,
+				histBucket:  time.Hour,
+			},
+			wantErr:    nil,
+			wantStderr: "",
 		},
 		{
-			name: "reference file",
+			name: "histogram with bucket",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("histogram", "true")
+				cmd.Flags().Set("histogram-bucket", "15m")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				histogram:   true,
+				histBucket:  15 * time.Minute,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "histogram with zero bucket",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("histogram", "true")
+				cmd.Flags().Set("histogram-bucket", "0s")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: %v", errors.ErrInvalidHistogramBucket, time.Duration(0)),
+			wantStderr: "",
+		},
+		{
+			name: "histogram bucket without histogram",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("histogram-bucket", "15m")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrBucketWithoutHistogram,
+			wantStderr: "",
+		},
+		{
+			name: "atomic",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("atomic", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				atomic:      true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "jobs",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("jobs", "4")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				jobs:        4,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "negative jobs",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("jobs", "-1")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: %d", errors.ErrInvalidJobs, -1),
+			wantStderr: "",
+		},
+		{
+			name: "sequential",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("sequential", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				jobs:        1,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "sequential with one job",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("sequential", "true")
+				cmd.Flags().Set("jobs", "1")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				jobs:        1,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "sequential with jobs",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("sequential", "true")
+				cmd.Flags().Set("jobs", "4")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: --jobs %d", errors.ErrSequentialWithJobs, 4),
+			wantStderr: "",
+		},
+		{
+			name: "audit log",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("audit-log", "touch.log")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				auditLog:    "touch.log",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "prefer birth with reference",
 			flagSetup: func(cmd *cobra.Command) {
 				cmd.Flags().Set("reference", "ref.txt")
+				cmd.Flags().Set("prefer-birth", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				refFilePath: "ref.txt",
+				preferBirth: true,
 			},
-			wantChange:   core.ChAtime | core.ChMtime,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "ref.txt",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			wantErr:    nil,
+			wantStderr: "",
 		},
 		{
-			name: "stamp",
+			name: "prefer birth without reference",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("stamp", "2507131430")
+				cmd.Flags().Set("prefer-birth", "true")
 			},
-			wantChange:   core.ChAtime | core.ChMtime,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "2507131430",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			want:       touchOptions{},
+			wantErr:    errors.ErrPreferBirthWithoutReference,
+			wantStderr: "",
 		},
 		{
-			name: "date",
+			name: "truncate to day",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("date", "2025-07-13")
+				cmd.Flags().Set("truncate-to", "day")
 			},
-			wantChange:   core.ChAtime | core.ChMtime,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "2025-07-13",
-			wantErr:      nil,
-			wantStderr:   "",
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				truncateTo:  "day",
+			},
+			wantErr:    nil,
+			wantStderr: "",
 		},
 		{
-			name: "multiple time sources ref and date",
+			name: "truncate to unknown unit",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("truncate-to", "week")
+			},
+			want:       touchOptions{},
+			wantErr:    fmt.Errorf("%w: week", errors.ErrInvalidTruncation),
+			wantStderr: "",
+		},
+		{
+			name: "swap with reference",
 			flagSetup: func(cmd *cobra.Command) {
 				cmd.Flags().Set("reference", "ref.txt")
-				cmd.Flags().Set("date", "2025-07-13")
+				cmd.Flags().Set("swap", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				refFilePath: "ref.txt",
+				swap:        true,
 			},
-			wantChange:   0,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      errors.ErrMultipleTimeSources,
-			wantStderr:   "",
+			wantErr:    nil,
+			wantStderr: "",
 		},
 		{
-			name: "multiple time sources stamp and date",
+			name: "swap without reference",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("stamp", "2507131430")
+				cmd.Flags().Set("swap", "true")
 				cmd.Flags().Set("date", "2025-07-13")
 			},
-			wantChange:   0,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      errors.ErrMultipleTimeSources,
-			wantStderr:   "",
+			want:       touchOptions{},
+			wantErr:    errors.ErrSwapWithoutReference,
+			wantStderr: "",
 		},
 		{
-			name: "multiple time sources ref and stamp",
+			name: "next cron",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("reference", "ref.txt")
-				cmd.Flags().Set("stamp", "2507131430")
+				cmd.Flags().Set("next-cron", "0 * * * *")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				nextCron:    "0 * * * *",
 			},
-			wantChange:   0,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      errors.ErrMultipleTimeSources,
-			wantStderr:   "",
+			wantErr:    nil,
+			wantStderr: "",
 		},
 		{
-			name: "all time sources",
+			name: "next cron with date",
 			flagSetup: func(cmd *cobra.Command) {
-				cmd.Flags().Set("reference", "ref.txt")
-				cmd.Flags().Set("stamp", "2507131430")
+				cmd.Flags().Set("next-cron", "0 * * * *")
 				cmd.Flags().Set("date", "2025-07-13")
 			},
-			wantChange:   0,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      errors.ErrMultipleTimeSources,
-			wantStderr:   "",
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleTimeSources,
+			wantStderr: "",
+		},
+		{
+			name: "reference seed with window",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-seed", "project-a")
+				cmd.Flags().Set("seed-window", "2020-01-01T00:00:00Z,2021-01-01T00:00:00Z")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				seed:        "project-a",
+				seedWindow:  "2020-01-01T00:00:00Z,2021-01-01T00:00:00Z",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "seed window without seed",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("seed-window", "2020-01-01T00:00:00Z,2021-01-01T00:00:00Z")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrSeedWindowWithoutSeed,
+			wantStderr: "",
+		},
+		{
+			name: "reference git newest whole repository",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Parse([]string{"--reference-git-newest"})
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				gitNewest:   ":/",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference git newest subtree",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Parse([]string{"--reference-git-newest=internal"})
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				gitNewest:   "internal",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "clamp new to now",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("clamp-new-to-now", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				clampNew:    true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "monotonic components",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("monotonic-components", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				forwardOnly: true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference self atime",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-self-atime", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				selfAtime:   true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference ssh",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("reference-ssh", "deploy@build01:/srv/app/VERSION")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				sshRef:      "deploy@build01:/srv/app/VERSION",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "content",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("content", "# header")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				content:     "# header",
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "content and content file",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("content", "# header")
+				cmd.Flags().Set("content-file", "template.txt")
+			},
+			want:       touchOptions{},
+			wantErr:    errors.ErrMultipleContentSources,
+			wantStderr: "",
+		},
+		{
+			name: "monotonic now",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("monotonic-now", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				monotonic:   true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "floor to dir",
+			flagSetup: func(cmd *cobra.Command) {
+				cmd.Flags().Set("floor-to-dir", "true")
+			},
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+				floorToDir:  true,
+			},
+			wantErr:    nil,
+			wantStderr: "",
 		},
 		{
 			name: "ignored f flag",
 			flagSetup: func(cmd *cobra.Command) {
 				cmd.Flags().Set("f", "true")
 			},
-			wantChange:   core.ChAtime | core.ChMtime,
-			wantNoCreate: false,
-			wantNoDeref:  false,
-			wantRef:      "",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			want: touchOptions{
+				changeTimes: core.ChAtime | core.ChMtime,
+			},
+			wantErr:    nil,
+			wantStderr: "",
 		},
 		{
 			name: "combined flags",
@@ -284,42 +1752,25 @@ func Test_processFlags(t *testing.T) {
 				cmd.Flags().Set("no-create", "true")
 				cmd.Flags().Set("reference", "ref.txt")
 			},
-			wantChange:   core.ChAtime,
-			wantNoCreate: true,
-			wantNoDeref:  false,
-			wantRef:      "ref.txt",
-			wantStamp:    "",
-			wantDate:     "",
-			wantErr:      nil,
-			wantStderr:   "",
+			want: touchOptions{
+				changeTimes: core.ChAtime,
+				noCreate:    true,
+				refFilePath: "ref.txt",
+			},
+			wantErr:    nil,
+			wantStderr: "",
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cmd := &cobra.Command{}
-			// Define all flags as in init().
-			cmd.Flags().Bool("help", false, "")
-			cmd.Flags().BoolP("access", "a", false, "")
-			cmd.Flags().BoolP("modification", "m", false, "")
-			cmd.Flags().String("time", "", "")
-			cmd.Flags().BoolP("no-create", "c", false, "")
-			cmd.Flags().BoolP("no-dereference", "h", false, "")
-			cmd.Flags().Bool("f", false, "")
-			cmd.Flags().StringP("reference", "r", "", "")
-			cmd.Flags().StringP("stamp", "t", "", "")
-			cmd.Flags().StringP("date", "d", "", "")
-			cmd.Flags().BoolP("version", "v", false, "")
-
-			if tt.flagSetup != nil {
-				tt.flagSetup(cmd)
-			}
+			cmd := createTestCmd(tt.flagSetup)
 
 			// Capture stderr for warnings.
 			oldStderr := os.Stderr
 			r, w, _ := os.Pipe()
 			os.Stderr = w
 
-			got, got1, got2, got3, got4, got5, err := processFlags(cmd)
+			got, err := flagOptions(cmd)
 
 			w.Close()
 
@@ -335,33 +1786,123 @@ func Test_processFlags(t *testing.T) {
 				t.Errorf("processFlags() error = %v, want %v", err, tt.wantErr)
 			}
 
-			if got != tt.wantChange {
-				t.Errorf("processFlags() got = %v, want %v", got, tt.wantChange)
+			if got != tt.want {
+				t.Errorf("processFlags() got = %+v, want %+v", got, tt.want)
 			}
 
-			if got1 != tt.wantNoCreate {
-				t.Errorf("processFlags() got1 = %v, want %v", got1, tt.wantNoCreate)
+			if stderrOutput != tt.wantStderr {
+				t.Errorf("processFlags() stderr = %v, want %v", stderrOutput, tt.wantStderr)
 			}
+		})
+	}
+}
 
-			if got2 != tt.wantNoDeref {
-				t.Errorf("processFlags() got2 = %v, want %v", got2, tt.wantNoDeref)
-			}
+func Test_processFlags_birthTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		supported bool
+		want      touchOptions
+		wantErr   error
+	}{
+		{
+			name:      "supported platform",
+			supported: true,
+			want:      touchOptions{changeTimes: core.ChBtime},
+			wantErr:   nil,
+		},
+		{
+			name:      "unsupported platform",
+			supported: false,
+			want:      touchOptions{},
+			wantErr:   errors.ErrBirthTimeUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldSupported := platform.SetBtimeSupported
+
+			defer func() { platform.SetBtimeSupported = oldSupported }()
+
+			platform.SetBtimeSupported = tt.supported
 
-			if got3 != tt.wantRef {
-				t.Errorf("processFlags() got3 = %v, want %v", got3, tt.wantRef)
+			cmd := createTestCmd(func(cmd *cobra.Command) {
+				cmd.Flags().Set("time", "birth")
+			})
+
+			got, err := flagOptions(cmd)
+			if err != tt.wantErr { //nolint:errorlint // The sentinel is returned unwrapped.
+				t.Errorf("processFlags() error = %v, want %v", err, tt.wantErr)
 			}
 
-			if got4 != tt.wantStamp {
-				t.Errorf("processFlags() got4 = %v, want %v", got4, tt.wantStamp)
+			if got != tt.want {
+				t.Errorf("processFlags() got = %+v, want %+v", got, tt.want)
 			}
+		})
+	}
+}
+
+func Test_processFlags_strictNoDeref(t *testing.T) {
+	tests := []struct {
+		name      string
+		supported bool
+		strict    bool
+		want      touchOptions
+		wantErr   error
+	}{
+		{
+			name:      "supported platform",
+			supported: true,
+			strict:    true,
+			want:      touchOptions{changeTimes: core.ChAtime | core.ChMtime, noDeref: true, strict: true},
+			wantErr:   nil,
+		},
+		{
+			name:      "unsupported platform under strict",
+			supported: false,
+			strict:    true,
+			want:      touchOptions{},
+			wantErr:   errors.ErrNoDerefUnsupported,
+		},
+		{
+			// Links are followed with a warning when touching instead.
+			name:      "unsupported platform without strict",
+			supported: false,
+			strict:    false,
+			want:      touchOptions{changeTimes: core.ChAtime | core.ChMtime, noDeref: true},
+			wantErr:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldSupported := platform.SetTimesNoDerefSupported
+
+			defer func() { platform.SetTimesNoDerefSupported = oldSupported }()
+
+			platform.SetTimesNoDerefSupported = tt.supported
+
+			cmd := createTestCmd(func(cmd *cobra.Command) {
+				cmd.Flags().Set("no-dereference", "true")
+				cmd.Flags().Set("strict", strconv.FormatBool(tt.strict))
+			})
 
-			if got5 != tt.wantDate {
-				t.Errorf("processFlags() got5 = %v, want %v", got5, tt.wantDate)
+			got, err := flagOptions(cmd)
+			if err != tt.wantErr { //nolint:errorlint // The sentinel is returned unwrapped.
+				t.Errorf("processFlags() error = %v, want %v", err, tt.wantErr)
 			}
 
-			if stderrOutput != tt.wantStderr {
-				t.Errorf("processFlags() stderr = %v, want %v", stderrOutput, tt.wantStderr)
+			if got != tt.want {
+				t.Errorf("processFlags() got = %+v, want %+v", got, tt.want)
 			}
 		})
 	}
 }
+
+// flagOptions processes the flags of cmd and validates them into options, as RunTouch does.
+func flagOptions(cmd *cobra.Command) (touchOptions, error) {
+	opts, err := processFlags(cmd)
+	if err != nil {
+		return touchOptions{}, err
+	}
+
+	return opts.touchOptions()
+}
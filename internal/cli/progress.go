@@ -0,0 +1,48 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file reports each file's outcome to the callback set in Options.OnFile.
+package cli
+
+import "sync"
+
+// FileResult is the outcome of one file of a Run, passed to Options.OnFile once the file
+// is done.
+type FileResult struct {
+	File    string // File as given in Options.Files.
+	Skipped bool   // The file was left alone, as a missing file under IfExists is.
+	Err     error  // Why the file failed, or nil if it was touched or skipped.
+}
+
+// progressLog passes each file's FileResult to a callback, one call at a time.
+type progressLog struct {
+	onFile func(FileResult) // Callback receiving each result.
+	mu     sync.Mutex       // Serializes calls to onFile.
+}
+
+// report passes result to the callback. It is safe to call on a nil progressLog.
+func (p *progressLog) report(result FileResult) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.onFile(result)
+}
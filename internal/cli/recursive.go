@@ -0,0 +1,78 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file expands directory operands into their trees for -R/--recursive.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// expandRecursive replaces each directory in files with the directory followed by every
+// subdirectory, regular file, and symlink beneath it; other operands are kept as given.
+// Symlinks are listed rather than descended into, so touching them honors noDeref, which
+// also decides whether a symlinked operand counts as a directory. Errors for individual
+// entries are reported on warn without stopping the walk, and returned joined, each
+// prefixed with its quoted path.
+func expandRecursive(warn io.Writer, files []string, noDeref bool) ([]string, error) {
+	stat := filesystem.Default.Stat
+	if noDeref {
+		stat = filesystem.Default.Lstat
+	}
+
+	expanded := make([]string, 0, len(files))
+	var walkErrs []error
+
+	for _, file := range files {
+		fileInfo, err := stat(file)
+		if err != nil || !fileInfo.IsDir() {
+			expanded = append(expanded, file) // Touched as today, including creation.
+
+			continue
+		}
+
+		walkErr := filesystem.Default.WalkDir(file, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				fmt.Fprintf(warn, "touch: %s: %v\n", core.Quote(path), err)
+
+				walkErrs = append(walkErrs, fmt.Errorf("%s: %w", core.Quote(path), err))
+
+				return nil // Keep walking the rest of the tree.
+			}
+
+			if entry.IsDir() || entry.Type().IsRegular() || entry.Type()&fs.ModeSymlink != 0 {
+				expanded = append(expanded, path)
+			}
+
+			return nil
+		})
+		if walkErr != nil {
+			fmt.Fprintf(warn, "touch: %s: %v\n", core.Quote(file), walkErr)
+
+			walkErrs = append(walkErrs, fmt.Errorf("%s: %w", core.Quote(file), walkErr))
+		}
+	}
+
+	return expanded, errors.Join(walkErrs...)
+}
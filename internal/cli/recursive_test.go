@@ -0,0 +1,200 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file expands directory operands into their trees for -R/--recursive.
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
+)
+
+func Test_expandRecursive(t *testing.T) {
+	if runtime.GOOS == osWindows {
+		t.Skip("creating symlinks needs elevated privileges on Windows")
+	}
+
+	root := t.TempDir()
+	tree := filepath.Join(root, "tree")
+
+	if err := os.MkdirAll(filepath.Join(tree, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a.txt", filepath.Join("sub", "b.txt"), "plain.txt"} {
+		path := filepath.Join(tree, name)
+		if name == "plain.txt" {
+			path = filepath.Join(root, name)
+		}
+
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.Symlink(filepath.Join(tree, "sub"), filepath.Join(tree, "sublink")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(tree, filepath.Join(root, "treelink")); err != nil {
+		t.Fatal(err)
+	}
+
+	treeEntries := []string{
+		tree,
+		filepath.Join(tree, "a.txt"),
+		filepath.Join(tree, "sub"),
+		filepath.Join(tree, "sub", "b.txt"),
+		filepath.Join(tree, "sublink"), // Listed, not descended into.
+	}
+
+	tests := []struct {
+		name    string
+		files   []string
+		noDeref bool
+		want    []string
+	}{
+		{
+			name:    "directory expanded",
+			files:   []string{tree},
+			noDeref: false,
+			want:    treeEntries,
+		},
+		{
+			name:    "non-directory operands kept",
+			files:   []string{filepath.Join(root, "plain.txt"), filepath.Join(root, "missing.txt")},
+			noDeref: false,
+			want:    []string{filepath.Join(root, "plain.txt"), filepath.Join(root, "missing.txt")},
+		},
+		{
+			name:    "mixed operands keep their order",
+			files:   []string{filepath.Join(root, "plain.txt"), tree},
+			noDeref: false,
+			want:    append([]string{filepath.Join(root, "plain.txt")}, treeEntries...),
+		},
+		{
+			name:    "symlinked directory operand kept under no-dereference",
+			files:   []string{filepath.Join(root, "treelink")},
+			noDeref: true,
+			want:    []string{filepath.Join(root, "treelink")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem.Default = realFS
+
+			got, err := expandRecursive(io.Discard, tt.files, tt.noDeref)
+			if err != nil {
+				t.Errorf("expandRecursive() error = %v, want nil", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expandRecursive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_expandRecursive_walkError(t *testing.T) {
+	tests := []struct {
+		name       string
+		quiet      bool
+		wantStderr string
+	}{
+		{
+			name:       "reported",
+			quiet:      false,
+			wantStderr: "touch: \"tree/locked\": permission denied\n",
+		},
+		{
+			name:       "quiet",
+			quiet:      true,
+			wantStderr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			mockFS.On("Stat", "tree").Return(&mockFileInfo{dir: true}, nil)
+			mockFS.On("WalkDir", "tree", mock.Anything).
+				Return(func(_ string, fn fs.WalkDirFunc) error {
+					entries := []struct {
+						path string
+						info os.FileInfo
+						err  error
+					}{
+						{path: "tree", info: &mockFileInfo{dir: true}, err: nil},
+						{path: "tree/locked", info: &mockFileInfo{dir: true}, err: nil},
+						{path: "tree/locked", info: &mockFileInfo{dir: true}, err: os.ErrPermission},
+						{path: "tree/a.txt", info: &mockFileInfo{}, err: nil},
+					}
+
+					for _, entry := range entries {
+						err := fn(entry.path, fs.FileInfoToDirEntry(entry.info), entry.err)
+						if err != nil {
+							return err
+						}
+					}
+
+					return nil
+				})
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			// Capture stderr.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			warn := warnWriter(touchOptions{quiet: tt.quiet})
+			got, err := expandRecursive(warn, []string{"tree"}, false)
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if !errors.Is(err, os.ErrPermission) || !strings.Contains(err.Error(), `"tree/locked"`) {
+				t.Errorf("expandRecursive() error = %v, want %q permission denied", err, "tree/locked")
+			}
+
+			if want := []string{"tree", "tree/locked", "tree/a.txt"}; !slices.Equal(got, want) {
+				t.Errorf("expandRecursive() = %v, want %v", got, want)
+			}
+
+			if buf.String() != tt.wantStderr {
+				t.Errorf("expandRecursive() stderr = %q, want %q", buf.String(), tt.wantStderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file provides Run, the entry point for running touch from Go code without Cobra.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/platform"
+	"github.com/nicholas-fedor/touch/internal/timestamp"
+)
+
+// Options selects what Run does, mirroring the most common command-line flags.
+// The zero value, given Files, behaves like running touch with those operands alone.
+type Options struct {
+	ChangeTimes int        // Mask of core.ChAtime, ChMtime, and ChBtime; zero changes atime and mtime.
+	NoCreate    bool       // Do not create missing files (-c).
+	IfExists    bool       // Skip missing or vanished files without error or count (--if-exists).
+	NoDeref     bool       // Affect symlinks instead of the files they reference (-h).
+	Parents     bool       // Create missing parent directories of new files (-p).
+	Reference   string     // File to copy times from (-r).
+	Stamp       string     // POSIX timestamp [[CC]YY]MMDDhhmm[.ss] to use (-t).
+	Date        string     // Date string to use (-d).
+	UTC         bool       // Interpret Stamp and Date in UTC instead of local time (--utc).
+	Timezone    string     // IANA zone Stamp and Date are interpreted in instead (--timezone).
+	DryRun      bool       // Report what would change on stdout without modifying anything (--dry-run).
+	Verbose     bool       // Print each successfully touched file to stdout (--verbose).
+	Special     bool       // Touch FIFOs, sockets, and devices instead of rejecting them (--special).
+	Jobs        int        // Maximum number of files touched at once; 0 uses runtime.NumCPU (--jobs).
+	Clock       core.Clock // Source of the current time for defaults and relative dates; nil reads core.Now.
+	Files       []string   // Files to touch. Unlike on the command line, none is taken for a timestamp or glob.
+
+	// OlderThan, if positive, leaves existing files modified less than this long before
+	// Clock's time untouched (--only-older-than).
+	OlderThan time.Duration
+
+	// OnFile, if set, is called with the outcome of each file as it is done, such as to show
+	// progress. Calls are never concurrent, but may come from any goroutine and in any order.
+	// Files not started because the run was cancelled are not reported.
+	OnFile func(FileResult)
+
+	// flags holds the options only the command line can set, parsed by processFlags. It is
+	// nil for Run, which takes no obsolete timestamp or glob operands.
+	flags *touchOptions
+}
+
+// Run touches opts.Files as the touch command would with the equivalent flags, going through
+// the filesystem.Default FS. Errors are reported as by RunTouch: each failed file is printed to
+// stderr, and the returned error wraps ErrProcessingFiles, and ErrPartialFailure when other
+// files were touched. Invalid combinations of options are rejected before anything is touched.
+func Run(opts Options) error {
+	return RunCtx(context.Background(), opts)
+}
+
+// RunCtx behaves like Run, but stops before any files not yet started once ctx is cancelled.
+func RunCtx(ctx context.Context, opts Options) error {
+	touchOpts, err := opts.touchOptions()
+	if err != nil {
+		return err
+	}
+
+	return runTouch(ctx, touchOpts, opts.Files)
+}
+
+// touchOptions validates opts, including any flags processFlags parsed into it, and returns
+// them as the options of a touch run. It is the single check of the options Options has
+// fields for, whether they came from Go code or the command line.
+func (opts Options) touchOptions() (touchOptions, error) {
+	// Options from Go code have no obsolete timestamp or glob operands.
+	touchOpts := touchOptions{noObsolete: true, noGlob: true}
+	if opts.flags != nil {
+		touchOpts = *opts.flags
+	}
+
+	changeTimes := opts.ChangeTimes
+
+	switch {
+	case changeTimes == 0:
+		changeTimes = core.ChAtime | core.ChMtime
+	case changeTimes&^(core.ChAtime|core.ChMtime|core.ChBtime) != 0:
+		return touchOptions{}, fmt.Errorf("%w: change mask %#x", errors.ErrInvalidTimeArg, changeTimes)
+	case changeTimes&core.ChBtime != 0 && !platform.SetBtimeSupported:
+		return touchOptions{}, errors.ErrBirthTimeUnsupported
+	}
+
+	if opts.Timezone != "" {
+		if opts.UTC {
+			return touchOptions{}, errors.ErrTimezoneWithUTC
+		}
+
+		if _, err := timestamp.LoadTimezone(opts.Timezone); err != nil {
+			return touchOptions{}, err
+		}
+	}
+
+	if opts.OlderThan < 0 {
+		return touchOptions{}, fmt.Errorf("%w: %v", errors.ErrInvalidAge, opts.OlderThan)
+	}
+
+	if opts.Jobs < 0 {
+		return touchOptions{}, fmt.Errorf("%w: %d", errors.ErrInvalidJobs, opts.Jobs)
+	}
+
+	touchOpts.changeTimes = changeTimes
+	touchOpts.noCreate = opts.NoCreate
+	touchOpts.ifExists = opts.IfExists
+	touchOpts.parents = opts.Parents
+	touchOpts.noDeref = opts.NoDeref
+	touchOpts.refFilePath = opts.Reference
+	touchOpts.tStamp = opts.Stamp
+	touchOpts.dateStr = opts.Date
+	touchOpts.utc = opts.UTC
+	touchOpts.timezone = opts.Timezone
+	touchOpts.dryRun = opts.DryRun
+	touchOpts.verbose = opts.Verbose
+	touchOpts.special = opts.Special
+	touchOpts.olderAge = opts.OlderThan
+	touchOpts.jobs = opts.Jobs
+	touchOpts.clock = opts.Clock
+
+	if opts.OnFile != nil {
+		touchOpts.progress = &progressLog{onFile: opts.OnFile}
+	}
+
+	// Check for multiple time sources; --adjust shifts existing times, so it can't take any.
+	switch sources := touchOpts.timeSources(); {
+	case sources > 1:
+		return touchOptions{}, errors.ErrMultipleTimeSources
+	case sources > 0 && touchOpts.adjust != 0:
+		return touchOptions{}, errors.ErrAdjustWithTimeSource
+	}
+
+	return touchOpts, nil
+}
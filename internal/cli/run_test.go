@@ -0,0 +1,325 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package cli handles CLI-specific logic, separated from core touch functionality for modularity.
+// This file tests Run, the entry point for running touch from Go code without Cobra.
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nicholas-fedor/touch/internal/core"
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
+)
+
+// sameTime matches a time argument equal to want, whatever its location.
+func sameTime(want core.Time) any {
+	return mock.MatchedBy(func(got core.Time) bool { return got.Equal(want) })
+}
+
+func TestRun(t *testing.T) {
+	existing := time.Date(2025, 7, 12, 9, 0, 0, 0, time.Local)
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+	refTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		opts        Options
+		mockFSSetup func(*mocks.MockFS)
+		wantErr     error
+		wantStderr  string
+	}{
+		{
+			name: "date on existing file",
+			opts: Options{Date: "2025-07-13 14:30", Files: []string{"a.txt"}},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: existing}, nil)
+				m.On("Chtimes", "a.txt", sameTime(stamp), sameTime(stamp)).Return(nil)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "stamp in UTC",
+			opts: Options{Stamp: "202507131430", UTC: true, Files: []string{"a.txt"}},
+			mockFSSetup: func(m *mocks.MockFS) {
+				utcStamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC)
+
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: existing}, nil)
+				m.On("Chtimes", "a.txt", sameTime(utcStamp), sameTime(utcStamp)).Return(nil)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "reference",
+			opts: Options{Reference: "ref.txt", Files: []string{"a.txt"}},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "ref.txt").Return(&mockFileInfo{access: refTime, mod: refTime}, nil)
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: existing}, nil)
+				m.On("Chtimes", "a.txt", mock.AnythingOfType("time.Time"), sameTime(refTime)).Return(nil)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "modification time only",
+			opts: Options{ChangeTimes: core.ChMtime, Date: "2025-07-13 14:30", Files: []string{"a.txt"}},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: existing}, nil)
+				m.On("Chtimes", "a.txt", mock.AnythingOfType("time.Time"), sameTime(stamp)).Return(nil)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "missing file is created",
+			opts: Options{Date: "2025-07-13 14:30", Files: []string{"new.txt"}},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "new.txt").Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", "new.txt").Return(&os.File{}, true, nil)
+				m.On("Chtimes", "new.txt", sameTime(stamp), sameTime(stamp)).Return(nil)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "missing file with no create",
+			opts: Options{NoCreate: true, Files: []string{"new.txt"}},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "new.txt").Return(nil, os.ErrNotExist)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "clock supplies the default time",
+			opts: Options{
+				Clock: core.ClockFunc(func() core.Time { return stamp }),
+				Files: []string{"a.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: existing}, nil)
+				m.On("Chtimes", "a.txt", sameTime(stamp), sameTime(stamp)).Return(nil)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "clock resolves relative dates",
+			opts: Options{
+				Date:  "-1 hour",
+				Clock: core.ClockFunc(func() core.Time { return stamp }),
+				Files: []string{"a.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				earlier := stamp.Add(-time.Hour)
+
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: existing}, nil)
+				m.On("Chtimes", "a.txt", sameTime(earlier), sameTime(earlier)).Return(nil)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "clock sets the only-older-than cutoff",
+			opts: Options{
+				Date:      "2025-07-13 14:30",
+				OlderThan: 48 * time.Hour,
+				Clock:     core.ClockFunc(func() core.Time { return stamp }),
+				Files:     []string{"old.txt", "a.txt"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				// Only old.txt was modified more than two days before the clock's time.
+				m.On("Stat", "old.txt").Return(&mockFileInfo{mod: refTime}, nil)
+				m.On("Chtimes", "old.txt", sameTime(stamp), sameTime(stamp)).Return(nil)
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: existing}, nil)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "stamp-shaped file name is touched",
+			opts: Options{Files: []string{"202507131430"}},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "202507131430").Return(&mockFileInfo{mod: existing}, nil)
+				m.On("Chtimes", "202507131430", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+			},
+			wantErr:    nil,
+			wantStderr: "",
+		},
+		{
+			name: "some files fail",
+			opts: Options{Date: "2025-07-13 14:30", Jobs: 1, Files: []string{"a.txt", "locked.txt"}},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").Return(&mockFileInfo{mod: existing}, nil)
+				m.On("Chtimes", "a.txt", sameTime(stamp), sameTime(stamp)).Return(nil)
+				m.On("Stat", "locked.txt").Return(nil, os.ErrPermission)
+			},
+			wantErr:    touchErrors.ErrPartialFailure,
+			wantStderr: "touch: \"locked.txt\": stat file locked.txt: permission denied\n",
+		},
+		{
+			name:        "no files",
+			opts:        Options{},
+			mockFSSetup: nil,
+			wantErr:     touchErrors.ErrMissingOperands,
+			wantStderr:  "",
+		},
+		{
+			name:        "multiple time sources",
+			opts:        Options{Reference: "ref.txt", Date: "2025-07-13", Files: []string{"a.txt"}},
+			mockFSSetup: nil,
+			wantErr:     touchErrors.ErrMultipleTimeSources,
+			wantStderr:  "",
+		},
+		{
+			name:        "time zone with UTC",
+			opts:        Options{UTC: true, Timezone: "Europe/Paris", Files: []string{"a.txt"}},
+			mockFSSetup: nil,
+			wantErr:     touchErrors.ErrTimezoneWithUTC,
+			wantStderr:  "",
+		},
+		{
+			name:        "unknown change bits",
+			opts:        Options{ChangeTimes: 1 << 7, Files: []string{"a.txt"}},
+			mockFSSetup: nil,
+			wantErr:     touchErrors.ErrInvalidTimeArg,
+			wantStderr:  "",
+		},
+		{
+			name:        "negative age",
+			opts:        Options{OlderThan: -time.Hour, Files: []string{"a.txt"}},
+			mockFSSetup: nil,
+			wantErr:     touchErrors.ErrInvalidAge,
+			wantStderr:  "",
+		},
+		{
+			name:        "negative jobs",
+			opts:        Options{Jobs: -1, Files: []string{"a.txt"}},
+			mockFSSetup: nil,
+			wantErr:     touchErrors.ErrInvalidJobs,
+			wantStderr:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mock fails the test on any call not set up, so rejected options touch nothing.
+			mockFS := mocks.NewMockFS(t)
+			if tt.mockFSSetup != nil {
+				tt.mockFSSetup(mockFS)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			// Capture stderr.
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			err := Run(tt.opts)
+
+			w.Close()
+
+			os.Stderr = oldStderr
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if buf.String() != tt.wantStderr {
+				t.Errorf("Run() stderr = %q, want %q", buf.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRun_onFile(t *testing.T) {
+	existing := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	mockFS := mocks.NewMockFS(t)
+	mockFS.On("Stat", "a.txt").Return(&mockFileInfo{mod: existing}, nil)
+	mockFS.On("Chtimes", "a.txt", sameTime(stamp), sameTime(stamp)).Return(nil)
+	mockFS.On("Stat", "b.txt").Return(&mockFileInfo{mod: existing}, nil)
+	mockFS.On("Chtimes", "b.txt", sameTime(stamp), sameTime(stamp)).Return(nil)
+	mockFS.On("Stat", "gone.txt").Return(nil, os.ErrNotExist)
+	mockFS.On("Stat", "locked.txt").Return(nil, os.ErrPermission)
+
+	filesystem.Default = mockFS // Override default FS with mock.
+
+	// Several jobs, so the callback is called from several goroutines.
+	var results []FileResult
+
+	err := Run(Options{
+		Date:     "2025-07-13 14:30",
+		IfExists: true,
+		Jobs:     4,
+		Files:    []string{"a.txt", "b.txt", "gone.txt", "locked.txt"},
+		OnFile:   func(result FileResult) { results = append(results, result) },
+	})
+	if !errors.Is(err, touchErrors.ErrPartialFailure) {
+		t.Errorf("Run() error = %v, want %v", err, touchErrors.ErrPartialFailure)
+	}
+
+	slices.SortFunc(results, func(a, b FileResult) int { return strings.Compare(a.File, b.File) })
+
+	want := []FileResult{
+		{File: "a.txt", Skipped: false, Err: nil},
+		{File: "b.txt", Skipped: false, Err: nil},
+		{File: "gone.txt", Skipped: true, Err: nil},
+	}
+	if len(results) != len(want)+1 {
+		t.Fatalf("OnFile called %d times, want %d: %+v", len(results), len(want)+1, results)
+	}
+
+	if !slices.Equal(results[:len(want)], want) {
+		t.Errorf("OnFile results = %+v, want %+v", results[:len(want)], want)
+	}
+
+	if got := results[len(want)]; got.File != "locked.txt" || !errors.Is(got.Err, os.ErrPermission) {
+		t.Errorf("OnFile result = %+v, want locked.txt failing with %v", got, os.ErrPermission)
+	}
+}
+
+func TestRunCtx_cancelled(t *testing.T) {
+	mockFS := mocks.NewMockFS(t)
+	filesystem.Default = mockFS // Override default FS with mock; nothing may be touched.
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := RunCtx(ctx, Options{Jobs: 1, Files: []string{"a.txt", "b.txt"}})
+	if !errors.Is(err, touchErrors.ErrCancelled) {
+		t.Errorf("RunCtx() error = %v, want %v", err, touchErrors.ErrCancelled)
+	}
+}
@@ -20,50 +20,178 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
-	"runtime"
 
 	"github.com/spf13/cobra"
 
+	"github.com/nicholas-fedor/touch/internal/core"
 	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/timestamp"
 )
 
 // RunTouch is the entry point for the root command's RunE function.
-// It processes flags, calculates timestamps, and applies changes to files.
+// It processes flags into options and hands them, with the operands in args, to the same
+// run that Run performs, which calculates timestamps and applies changes to files.
 // It handles warnings for obsolete usage or platform-specific limitations.
+// Cancelling the command's context stops the run before any files not yet started.
+// When some files are touched and others fail, the error also wraps ErrPartialFailure.
 func RunTouch(cmd *cobra.Command, args []string) error {
 	// Process and validate command-line flags.
-	changeTimes, noCreate, noDeref, refFilePath, tStamp, dateStr, err := processFlags(cmd)
+	opts, err := processFlags(cmd)
 	if err != nil {
 		return err
 	}
 
-	// Warn if -h/--no-dereference is used on Windows, where it's unsupported.
-	if noDeref && runtime.GOOS == "windows" {
-		fmt.Fprintln(
-			os.Stderr,
-			"Warning: -h/--no-dereference is not supported on Windows; symlinks will be followed",
-		)
+	opts.Files = args
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background() // Commands run without Execute have no context.
+	}
+
+	return RunCtx(ctx, opts)
+}
+
+// runTouch touches the operands in args as selected by opts, until ctx is cancelled.
+// It is reached through RunCtx by both RunTouch and Run, so both behave alike once their options
+// are validated.
+func runTouch(ctx context.Context, opts touchOptions, args []string) error {
+	var err error
+
+	// Record each file's time changes in --audit-log, stamped with the time of this run.
+	if opts.auditLog != "" {
+		opts.audit = newAuditLogger(opts.auditLog, core.NowFrom(opts.clock))
+	}
+
+	// Leave files modified within --only-older-than of now untouched.
+	if opts.olderAge != 0 {
+		opts.olderThan = core.NowFrom(opts.clock).Add(-opts.olderAge)
+	}
+
+	// Collect each touched file's final times and hash for --manifest, written at the end.
+	if opts.manifest != "" {
+		opts.manifestLog = &manifestLog{}
+	}
+
+	// Collect each touched file's final modification time for --histogram, printed at the end.
+	if opts.histogram {
+		opts.histLog = &histogramLog{}
+	}
+
+	// Parse --stat-time once, to be evaluated against each file's stats.
+	if opts.statTime != "" {
+		opts.statExpr, err = timestamp.ParseStatExpr(opts.statTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Share granularity probes between files on the same filesystem for --normalize-to-fs.
+	if opts.normFS {
+		opts.fsGrain = &core.GranularityCache{}
+	}
+
+	// Load initial content for new files from --content-file.
+	if opts.contentFile != "" {
+		opts.content, err = readContentFile(opts.contentFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Apply per-file times streamed as JSON Lines instead of computing shared times.
+	if opts.jsonlTimes != "" {
+		if len(args) > 0 || opts.filesFrom != "" {
+			return errors.ErrOperandsWithJSONL
+		}
+
+		reader, err := openJSONLTimes(opts.jsonlTimes)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		counts, err := applyJSONLTimes(ctx, opts, reader)
+		err = counts.wrapPartial(err)
+
+		finishHistogram(opts)
+
+		return finishManifest(opts, err)
+	}
+
+	// Read further operands from --files-from.
+	var listedFiles []string
+
+	if opts.filesFrom != "" {
+		listedFiles, err = readFilesFrom(opts.filesFrom, opts.null)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Calculate timestamps and update args if using obsolete format (e.g., `touch 202507131430 file.txt`).
-	accessTime, modTime, files, err := calculateTimestamps(
-		noDeref,
-		refFilePath,
-		tStamp,
-		dateStr,
-		args,
-	)
+	// Only positional arguments are considered, so a listed name is never taken for a timestamp.
+	accessTime, modTime, files, isNow, err := calculateTimestamps(opts, args)
 	if err != nil {
 		return err
 	}
 
+	// Under --monotonic-now, the current time is read again for each file as it is touched.
+	opts.perFileNow = opts.monotonic && isNow
+
+	// Expand patterns the shell left unexpanded, such as on Windows. Listed names are literal,
+	// and so are all operands under -c.
+	if !opts.noGlob && !opts.noCreate {
+		files = expandGlobs(files)
+	}
+
+	files = append(files, listedFiles...)
+
 	// If no files are provided, return an error (will trigger usage display).
 	if len(files) == 0 {
 		return errors.ErrMissingOperands
 	}
 
-	// Apply the touch operation to the list of files concurrently.
-	return applyToFiles(changeTimes, noCreate, noDeref, accessTime, modTime, files)
+	// Expand directory operands into their trees for -R/--recursive.
+	var walkErr error
+	if opts.recursive {
+		files, walkErr = expandRecursive(warnWriter(opts), files, opts.noDeref)
+	}
+
+	// Apply the touch operation to the list of files concurrently, until cancelled.
+	counts, err := applyToFilesCtx(ctx, opts, accessTime, modTime, files)
+	if err == nil && walkErr != nil {
+		err = fmt.Errorf("%w: %w", errors.ErrProcessingFiles, walkErr)
+		counts.failed++ // The directory that couldn't be walked.
+	}
+
+	// Distinguish files left updated alongside failures from a run where nothing succeeded.
+	err = counts.wrapPartial(err)
+
+	finishHistogram(opts)
+
+	return finishManifest(opts, err)
+}
+
+// readContentFile reads the initial content for new files, where "-" selects standard input.
+func readContentFile(name string) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	if name == stdinName {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("read content file %s: %w", name, err)
+	}
+
+	return string(data), nil
 }
@@ -22,7 +22,6 @@ package cli
 import (
 	"bytes"
 	"os"
-	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -33,6 +32,7 @@ import (
 	"github.com/nicholas-fedor/touch/internal/filesystem"
 	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
 	"github.com/nicholas-fedor/touch/internal/platform"
+	"github.com/nicholas-fedor/touch/internal/timestamp"
 )
 
 func TestRunTouch(t *testing.T) {
@@ -137,7 +137,40 @@ func TestRunTouch(t *testing.T) {
 			wantStderr: "warning: 'touch 2507131430' is obsolete; use 'touch -t'\n",
 		},
 		{
-			name: "no deref on windows warning",
+			name: "lone stamp-shaped file name is touched",
+			args: args{
+				cmd:  createTestCmd(),
+				args: []string{"2507131430"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "2507131430").Return(&mockFileInfo{mod: time.Now()}, nil)
+				m.On("Chtimes", "2507131430", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+			},
+			setupEnv:   nil,
+			wantErr:    false,
+			wantStdout: "",
+			wantStderr: "",
+		},
+		{
+			name: "lone stamp-shaped file name is created",
+			args: args{
+				cmd:  createTestCmd(),
+				args: []string{"07131430.15"},
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "07131430.15").Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", "07131430.15").Return(&os.File{}, true, nil)
+				m.On("Chtimes", "07131430.15", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+			},
+			setupEnv:   nil,
+			wantErr:    false,
+			wantStdout: "",
+			wantStderr: "",
+		},
+		{
+			name: "no deref",
 			args: args{
 				cmd: createTestCmd(
 					func(cmd *cobra.Command) { cmd.Flags().Set("no-dereference", "true") },
@@ -145,28 +178,21 @@ func TestRunTouch(t *testing.T) {
 				args: []string{"file.txt"},
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
-				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
-				m.On("Create", "file.txt").Return(&os.File{}, nil)
+				// No-dereference inspects the link itself with Lstat.
+				m.On("Lstat", "file.txt").Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", "file.txt").Return(&os.File{}, true, nil)
 				m.On("Chtimes", "file.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
 					Return(nil)
 			},
 			setupEnv: func() {
-				if runtime.GOOS != osWindows {
-					// On non-Windows, mock SetTimesNoDeref for no-dereference behavior.
-					platform.SetTimesNoDeref = func(_ string, _, _ time.Time) error {
-						return nil
-					}
+				// Mock SetTimesNoDeref for no-dereference behavior.
+				platform.SetTimesNoDeref = func(_ string, _, _ time.Time) error {
+					return nil
 				}
 			},
 			wantErr:    false,
 			wantStdout: "",
-			wantStderr: func() string {
-				if runtime.GOOS == osWindows {
-					return "Warning: -h/--no-dereference is not supported on Windows; symlinks will be followed\n"
-				}
-
-				return ""
-			}(),
+			wantStderr: "",
 		},
 	}
 	for _, tt := range tests {
@@ -230,14 +256,177 @@ func createTestCmd(flagSetup ...func(*cobra.Command)) *cobra.Command {
 	cmd.Flags().BoolP("access", "a", false, "change only the access time")
 	cmd.Flags().BoolP("modification", "m", false, "change only the modification time")
 	cmd.Flags().
-		String("time", "", "change the specified time: access, atime, use (like -a); modify, mtime (like -m)")
+		String("time", "", "change the specified time: access, atime, use (like -a); modify, mtime (like -m); birth")
 	cmd.Flags().BoolP("no-create", "c", false, "do not create any files")
 	cmd.Flags().
-		BoolP("no-dereference", "h", false, "affect each symbolic link instead of any referenced file (unsupported on Windows)")
+		Bool("if-exists", false, "only touch files that exist, skipping missing or vanished files without error (overrides -c)")
+	cmd.Flags().BoolP("parents", "p", false, "create missing parent directories of files that are created")
+	cmd.Flags().
+		Bool("clamp-new-to-now", false, "never give files that are created times later than the current time")
+	cmd.Flags().
+		Bool("monotonic-components", false, "never move an existing file's access or modification time backward")
+	cmd.Flags().
+		Bool("special", false, "touch FIFOs, sockets, and device files instead of reporting an error")
+	cmd.Flags().String("content", "", "write this content to files that are created")
+	cmd.Flags().
+		String("content-file", "", "write the contents of this file (- for stdin) to files that are created")
+	cmd.Flags().
+		String("exec", "", "run this command after touching each file, with {} replaced by the file name")
+	cmd.Flags().
+		String("audit-log", "", "append a tab-separated record of each changed file's old and new times to this file")
+	cmd.Flags().
+		String("manifest", "", "after processing, write each touched file's final times and SHA-256 content hash to this file")
+	cmd.Flags().
+		Bool("journal", false, "report each changed file's old and new times to the systemd journal (Linux; stderr elsewhere)")
+	cmd.Flags().
+		Bool("verbose", false, "print a line to stdout for each file that is touched")
+	cmd.Flags().
+		BoolP("quiet", "q", false, "do not print per-file error messages or warnings to stderr")
+	cmd.Flags().
+		Bool("summary", false, "print the numbers of files updated and failed to stderr at the end")
+	cmd.Flags().
+		Bool("histogram", false, "print a histogram of the final modification times to stderr at the end")
+	cmd.Flags().
+		Duration("histogram-bucket", time.Hour, "with --histogram, the width of each bucket, such as 1h or 24h")
+	cmd.Flags().
+		Bool("dry-run", false, "report what would be created or changed without modifying anything")
+	cmd.Flags().
+		Bool("diff", false, "with --dry-run, also show each existing file's current and new access and modification times")
+	cmd.Flags().
+		IntP("jobs", "j", 0, "touch at most this many files, or read this many comma-separated references, at once; 0 uses the number of CPUs")
+	cmd.Flags().
+		Bool("sequential", false, "touch files one at a time in argument order, for filesystems that mishandle concurrent updates; same as --jobs 1")
+	cmd.Flags().
+		Bool("atomic", false, "restore every file to its original times, removing any it created, if any file fails")
+	cmd.Flags().
+		BoolP("no-dereference", "h", false, "affect each symbolic link instead of any referenced file")
+	cmd.Flags().
+		BoolP("recursive", "R", false, "touch directories and every file and subdirectory beneath them")
+	cmd.Flags().
+		Bool("no-glob", false, "do not expand *, ?, and [ in file operands that the shell left unexpanded; implied by -c")
+	cmd.Flags().
+		Bool("preserve-link-times", false, "keep each symbolic link's own times when touching the file it references")
 	cmd.Flags().Bool("f", false, "(ignored for compatibility)")
 	cmd.Flags().StringP("reference", "r", "", "use this file's times instead of current time")
+	cmd.Flags().String("reference-atime", "", "use this file's access time, with --reference-mtime")
+	cmd.Flags().String("reference-mtime", "", "use this file's modification time, with --reference-atime")
+	cmd.Flags().
+		String("reduce", "", "treat -r as comma-separated files and reduce their times: min, max, mean, median")
+	cmd.Flags().
+		Bool("prefer-birth", false, "with -r, use the reference's birth time instead of its modification time when available")
+	cmd.Flags().
+		Bool("swap", false, "with -r, use the reference's access time as the modification time and vice versa")
+	cmd.Flags().
+		String("reference-newest-under", "", "use the times of the newest entry anywhere under this directory")
+	cmd.Flags().
+		String("reference-newest-type", "", "with --dir, use the times of the newest file there whose sniffed content type is this, e.g. image/jpeg")
+	cmd.Flags().
+		String("dir", "", "directory searched by --reference-newest-type")
+	cmd.Flags().
+		String("reference-newest-atime", "", "use the times of the most recently accessed of these comma-separated files")
+	cmd.Flags().
+		String("reference-oldest-atime", "", "use the times of the least recently accessed of these comma-separated files")
+	cmd.Flags().
+		String("reference-max-change", "", "use the latest modification or change time of these comma-separated files")
+	cmd.Flags().
+		String("reference-oldest-ctime", "", "use the earliest change time of these comma-separated files")
+	cmd.Flags().
+		String("reference-glob", "", "with --reference-percentile or --reference-min-after, select among the modification times of files matching this glob")
+	cmd.Flags().
+		String("reference-percentile", "", "use this percentile, 0 to 100, of the --reference-glob modification times")
+	cmd.Flags().
+		String("reference-min-after", "", "use the earliest --reference-glob modification time later than this RFC3339 time")
+	cmd.Flags().
+		String("reference-mode-glob", "", "use the most common modification time of files matching this glob, ties going to the newest")
+	cmd.Flags().
+		String("reference-mount", "", "use the mount time of the filesystem containing this path (Linux only)")
+	cmd.Flags().
+		String("reference-ssh", "", "use the times of the remote file [user@]host:path, read with ssh and stat")
+	cmd.Flags().
+		Bool("reference-ancestor", false, "use the times of each file's nearest existing ancestor directory")
+	cmd.Flags().
+		Bool("normalize-symlink-times", false, "set each symlink's own times to those of its target, skipping other files")
+	cmd.Flags().
+		Bool("normalize-to-fs", false, "truncate each file's existing times to the timestamp granularity its filesystem stores")
+	cmd.Flags().
+		Bool("reference-boot", false, "use the approximate system boot time, now minus uptime (Linux only)")
+	cmd.Flags().
+		String("reference-proc-fds", "", "use the newest modification time among the files this PID has open (Linux only)")
+	cmd.Flags().
+		String("reference-unit", "", "use the time this systemd unit last became active, its ActiveEnterTimestamp (Linux only)")
+	cmd.Flags().
+		Bool("reference-self-atime", false, "use the access time of this program's executable")
+	cmd.Flags().
+		String("buildinfo", "", "use the BuildTime recorded in this key=value .buildinfo file")
+	cmd.Flags().
+		String("rsync-list", "", "use the newest time in this rsync --list-only listing")
+	cmd.Flags().
+		String("warc", "", "with --warc-target, use the WARC-Date of that URL's record in this WARC archive")
+	cmd.Flags().
+		String("warc-target", "", "target URL of the --warc record whose date is used")
+	cmd.Flags().
+		String("reference-oci", "", "use the created date of this Docker or OCI image tarball, as written by docker save")
+	cmd.Flags().
+		String("reference-image", "", "use the newest modification time among the members of this squashfs or erofs image, extracted with unsquashfs or fsck.erofs")
+	cmd.Flags().
+		String("lockfile", "", "with --lock-entry, use the time recorded for that package in this package-lock.json or other JSON lockfile")
+	cmd.Flags().
+		String("lock-entry", "", "package in the --lockfile whose recorded time is used")
+	cmd.Flags().
+		String("lock-path", timestamp.DefaultLockPath, "dot-separated path of the time field within the --lock-entry, such as meta.published")
+	cmd.Flags().
+		String("reference-git-newest", "", "use the time of the newest commit touching this path, or the whole repository if omitted")
+	cmd.Flags().Lookup("reference-git-newest").NoOptDefVal = timestamp.GitRepoRoot
+	cmd.Flags().
+		String("reference-seed", "", "use a time derived deterministically from the SHA-256 of this string")
+	cmd.Flags().
+		String("next-cron", "", "use the next occurrence of this 5-field cron expression, e.g. '0 * * * *'")
+	cmd.Flags().
+		String("seed-window", "", "START,END window for --reference-seed times (default "+timestamp.DefaultSeedWindow+")")
+	cmd.Flags().
+		Bool("size-time", false, "use a time mapped linearly from each file's size into --size-window, reaching its end at --size-max")
+	cmd.Flags().
+		String("size-window", "", "START,END window for --size-time times (default "+timestamp.DefaultSeedWindow+")")
+	cmd.Flags().Int64("size-max", 0, "file size in bytes that --size-time maps to the end of --size-window")
+	cmd.Flags().
+		String("stat-time", "", "use epoch seconds computed from each file's size, mode, and lines, such as '1700000000 + size'")
+	cmd.Flags().
+		String("jsonl-times", "", "apply per-file times from JSON Lines records read from this file (- for stdin)")
+	cmd.Flags().
+		String("files-from", "", "also touch the files named one per line in this file (- for stdin)")
+	cmd.Flags().
+		BoolP("null", "z", false, "with --files-from, separate file names with NUL bytes instead of newlines")
 	cmd.Flags().StringP("stamp", "t", "", "use [[CC]YY]MMDDhhmm[.ss] instead of current time")
 	cmd.Flags().StringP("date", "d", "", "parse ARG and use it instead of current time")
+	cmd.Flags().
+		String("format", "", "parse -d with this Go time layout, such as 02.01.2006, instead of the built-in formats")
+	cmd.Flags().
+		String("set-atime", "", "set only the access time, to this date or [[CC]YY]MMDDhhmm[.ss] stamp; with --set-mtime, set both independently")
+	cmd.Flags().
+		String("set-mtime", "", "set only the modification time, to this date or [[CC]YY]MMDDhhmm[.ss] stamp; with --set-atime, set both independently")
+	cmd.Flags().
+		Bool("utc", false, "interpret -t and -d values without an explicit offset as UTC instead of local time")
+	cmd.Flags().
+		String("timezone", "", "interpret -t and -d values without an explicit offset in this IANA time zone, such as America/New_York")
+	cmd.Flags().
+		String("adjust", "", "shift each file's existing times by this duration, such as +1h or -2 days")
+	cmd.Flags().
+		Bool("monotonic-now", false, "use a current time that strictly increases across touches in this process")
+	cmd.Flags().
+		String("truncate-to", "", "round the computed times down to the start of their day, hour, or minute")
+	cmd.Flags().
+		Bool("floor-to-dir", false, "never set times earlier than the containing directory's modification time")
+	cmd.Flags().
+		Bool("skip-network-fs", false, "skip files on network filesystems such as NFS, CIFS, and FUSE (Linux only)")
+	cmd.Flags().
+		String("only-older-than", "", "only touch existing files last modified longer ago than this, such as 36h or 7d")
+	cmd.Flags().
+		String("time-sidecars", "", "use the RFC3339 time in each file's sidecar with this suffix when present")
+	cmd.Flags().Lookup("time-sidecars").NoOptDefVal = ".time"
+	cmd.Flags().
+		Bool("uuid-time", false, "use the time embedded in each file's UUIDv1 or UUIDv7 name as its modification time")
+	cmd.Flags().
+		Bool("strict", false, "fail on malformed input, or on -h where symlink times can't be set, instead of warning and continuing")
 	cmd.Flags().BoolP("version", "v", false, "output version information and exit")
 
 	for _, setup := range flagSetup {
@@ -29,11 +29,12 @@ import (
 type mockFileInfo struct {
 	access core.Time
 	mod    core.Time
+	dir    bool
 }
 
 func (m mockFileInfo) Name() string       { return "" }
 func (m mockFileInfo) Size() int64        { return 0 }
 func (m mockFileInfo) Mode() os.FileMode  { return 0 }
 func (m mockFileInfo) ModTime() core.Time { return m.mod }
-func (m mockFileInfo) IsDir() bool        { return false }
+func (m mockFileInfo) IsDir() bool        { return m.dir }
 func (m mockFileInfo) Sys() any           { return nil }
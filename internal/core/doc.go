@@ -6,12 +6,21 @@
 // Main Functions:
 //   - Touch: Applies specified timestamps to a file, creating it if necessary (unless noCreate is true).
 //     Supports partial updates by preserving existing times and handles no-dereference mode.
+//   - TouchWithOptions: Like Touch, with optional behaviors such as initial content for new files, clamping new files to now, creating missing parent directories, preserving a symlink's own times, skipping recently modified files, dry runs that only report changes, or a post-touch hook.
+//   - TouchCtx, TouchWithOptionsCtx: Like Touch and TouchWithOptions, but skip the file once a context is cancelled.
+//   - NormalizeSymlinkTimes: Sets a symlink's own times to those of its target, leaving other files untouched.
+//   - NormalizeToFS: Rewrites a file's existing times truncated to its filesystem's timestamp granularity.
+//   - GranularityCache: Detects the timestamp granularity of each device once, by writing and reading back a probe time.
+//   - TruncateToGranularity: Rounds a time down to a multiple of a granularity since the Unix epoch.
 //   - Now: A variable holding the function to get the current time, allowing mocking in tests.
+//   - Clock, ClockFunc: A source of the current time passed in Options, and an adapter for functions.
+//   - NowFrom: Returns the current time from a Clock, or from Now if it is nil.
+//   - MonotonicNow, MonotonicNowFrom: Return the current time, from Now or a Clock, guaranteed to advance by at least 1ns per call.
 //   - BoolToInt: Converts a boolean to an integer (1 for true, 0 for false), used for flag counting.
 //   - Quote: Wraps a string in quotes for safe display in error messages.
 //
 // Constants:
-// - ChAtime, ChMtime: Bit flags to determine which timestamps to update.
+// - ChAtime, ChMtime, ChBtime: Bit flags to determine which timestamps to update.
 //
 // This package is designed to be platform-agnostic, delegating OS-specific logic to the platform package.
 // It is used by the cli package to perform the actual touch operations on files.
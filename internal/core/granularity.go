@@ -0,0 +1,201 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package core provides the main Touch function and utilities, orchestrating file timestamp changes.
+// This file normalizes existing times to the granularity their filesystem stores.
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+// granularityProbeTime is written and read back to detect a filesystem's timestamp
+// granularity: an odd second with every nanosecond digit set, so any coarsening shows.
+var granularityProbeTime = time.Unix(1_000_000_001, 999_999_999)
+
+// granularities are the timestamp resolutions that can be detected, coarsest first: FAT's
+// 2 seconds, whole seconds, exFAT's 10 milliseconds, milliseconds, microseconds, NTFS's
+// 100 nanoseconds, and nanoseconds.
+var granularities = []time.Duration{
+	2 * time.Second,
+	time.Second,
+	10 * time.Millisecond,
+	time.Millisecond,
+	time.Microsecond,
+	100 * time.Nanosecond,
+	time.Nanosecond,
+}
+
+// GranularityCache remembers the timestamp granularity detected for each device, so each
+// filesystem is probed only once. Files whose device can't be determined, such as those
+// of an in-memory file system, share a probe per directory instead. It is safe for
+// concurrent use, and the zero value is ready to use.
+type GranularityCache struct {
+	mu     sync.Mutex
+	probes map[string]*granularityProbe
+}
+
+// granularityProbe is the outcome of probing one device, computed once.
+type granularityProbe struct {
+	once        sync.Once
+	granularity time.Duration
+	err         error
+}
+
+// Granularity returns the timestamp granularity of the filesystem holding file, whose current
+// info is fileInfo. The first file seen on each device is probed by setting its times to a
+// known sub-second time and reading them back, then restoring fileInfo's times on failure;
+// on success the caller must set the file's times again.
+func (c *GranularityCache) Granularity(file string, fileInfo os.FileInfo) (time.Duration, error) {
+	key := "dir:" + filepath.Dir(file)
+	if device, ok := platform.GetDevice(fileInfo); ok {
+		key = fmt.Sprintf("dev:%d", device)
+	} else if abs, err := filepath.Abs(file); err == nil {
+		key = "dir:" + filepath.Dir(abs)
+	}
+
+	c.mu.Lock()
+
+	if c.probes == nil {
+		c.probes = make(map[string]*granularityProbe)
+	}
+
+	probe, ok := c.probes[key]
+	if !ok {
+		probe = &granularityProbe{}
+		c.probes[key] = probe
+	}
+
+	c.mu.Unlock()
+
+	probe.once.Do(func() {
+		probe.granularity, probe.err = probeGranularity(file, fileInfo)
+	})
+
+	return probe.granularity, probe.err
+}
+
+// probeGranularity writes granularityProbeTime to file and detects the granularity from
+// the modification time read back, restoring fileInfo's times if that fails.
+func probeGranularity(file string, fileInfo os.FileInfo) (time.Duration, error) {
+	err := filesystem.Default.Chtimes(file, granularityProbeTime, granularityProbeTime)
+	if err != nil {
+		return 0, fmt.Errorf("probe time granularity of %s: %w", file, err)
+	}
+
+	probed, err := filesystem.Default.Stat(file)
+	if err == nil {
+		var granularity time.Duration
+
+		granularity, err = detectGranularity(probed.ModTime())
+		if err == nil {
+			return granularity, nil
+		}
+	}
+
+	// Put the original times back, as the caller won't rewrite them after a failure.
+	restoreErr := filesystem.Default.Chtimes(file, platform.GetAtime(fileInfo), fileInfo.ModTime())
+	if restoreErr != nil {
+		return 0, fmt.Errorf("probe time granularity of %s: %w; restore times: %w", file, err, restoreErr)
+	}
+
+	return 0, fmt.Errorf("probe time granularity of %s: %w", file, err)
+}
+
+// detectGranularity returns the coarsest granularity that stored, a read-back of
+// granularityProbeTime, is a multiple of. Filesystems may truncate or round the probe
+// time, but one further off than the coarsest granularity didn't keep it at all.
+func detectGranularity(stored Time) (time.Duration, error) {
+	if drift := stored.Sub(granularityProbeTime).Abs(); drift >= granularities[0] {
+		return 0, fmt.Errorf(
+			"%w: wrote %v, read %v",
+			errors.ErrTimeProbeMismatch,
+			granularityProbeTime,
+			stored,
+		)
+	}
+
+	for _, granularity := range granularities {
+		if stored.UnixNano()%int64(granularity) == 0 {
+			return granularity, nil
+		}
+	}
+
+	return time.Nanosecond, nil
+}
+
+// TruncateToGranularity rounds t down to a multiple of granularity since the Unix epoch.
+func TruncateToGranularity(t Time, granularity time.Duration) Time {
+	if granularity <= 0 {
+		return t
+	}
+
+	excess := time.Duration(t.UnixNano() % int64(granularity))
+	if excess < 0 {
+		excess += granularity // Round times before the epoch down too.
+	}
+
+	return t.Add(-excess)
+}
+
+// NormalizeToFS rewrites the existing times of file, limited to the times selected by change,
+// truncated to the timestamp granularity of its filesystem as detected through cache, so they
+// match what the filesystem can store. Symlinks are followed, and a missing file is an error.
+// The AfterTouch hook in opts runs once the file is updated, and DryRun reports the update
+// instead of making it, without probing; other options are ignored.
+func NormalizeToFS(file string, change int, cache *GranularityCache, opts Options) error {
+	fileInfo, err := filesystem.Default.Stat(file)
+	if err != nil {
+		return fmt.Errorf("stat file %s: %w", file, err)
+	}
+
+	if opts.DryRun {
+		reportDryRun("would normalize times on", file)
+
+		return nil
+	}
+
+	granularity, err := cache.Granularity(file, fileInfo)
+	if err != nil {
+		return err
+	}
+
+	accessTime := platform.GetAtime(fileInfo)
+	modTime := fileInfo.ModTime()
+
+	if change&ChAtime != 0 {
+		accessTime = TruncateToGranularity(accessTime, granularity)
+	}
+
+	if change&ChMtime != 0 {
+		modTime = TruncateToGranularity(modTime, granularity)
+	}
+
+	if err := filesystem.Default.Chtimes(file, accessTime, modTime); err != nil {
+		return fmt.Errorf("chtimes %s: %w", file, err)
+	}
+
+	return opts.afterTouch(file)
+}
@@ -0,0 +1,230 @@
+//go:build !windows
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package core provides the main Touch function and utilities, orchestrating file timestamp changes.
+// This file normalizes existing times to the granularity their filesystem stores.
+package core
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+// probeCountingFS is an in-memory file system that counts the granularity probes written to it.
+type probeCountingFS struct {
+	*filesystem.MemFS
+
+	probes atomic.Int32
+}
+
+// Chtimes counts writes of the probe time before storing the times.
+func (p *probeCountingFS) Chtimes(path string, atime, mtime Time) error {
+	if mtime.Equal(granularityProbeTime) {
+		p.probes.Add(1)
+	}
+
+	return p.MemFS.Chtimes(path, atime, mtime)
+}
+
+func TestNormalizeToFS(t *testing.T) {
+	// Times as applied on a filesystem with nanosecond timestamps.
+	preciseAtime := time.Date(2025, 7, 13, 14, 30, 1, 750_000_000, time.UTC)
+	preciseMtime := time.Date(2025, 7, 13, 14, 30, 2, 500_000_000, time.UTC)
+
+	tests := []struct {
+		name       string
+		dryRun     bool
+		wantAtime  Time
+		wantMtime  Time
+		wantStdout string
+	}{
+		{
+			name:      "truncated to 2 seconds",
+			dryRun:    false,
+			wantAtime: time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+			wantMtime: time.Date(2025, 7, 13, 14, 30, 2, 0, time.UTC),
+		},
+		{
+			name:       "dry run",
+			dryRun:     true,
+			wantAtime:  preciseAtime,
+			wantMtime:  preciseMtime,
+			wantStdout: "would normalize times on \"a.txt\"\nwould normalize times on \"b.txt\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memFS := &probeCountingFS{MemFS: filesystem.NewMemFS()}
+			filesystem.Default = memFS
+
+			files := []string{"a.txt", "b.txt"}
+			for _, file := range files {
+				newFile, err := memFS.Create(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				newFile.Close()
+
+				if err := memFS.Chtimes(file, preciseAtime, preciseMtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// From now on, the file system stores times at FAT's 2-second granularity.
+			memFS.Granularity = 2 * time.Second
+
+			cache := &GranularityCache{}
+
+			var hooked []string
+
+			opts := Options{
+				DryRun: tt.dryRun,
+				AfterTouch: func(file string) error {
+					hooked = append(hooked, file)
+
+					return nil
+				},
+			}
+
+			// Capture stdout.
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			for _, file := range files {
+				if err := NormalizeToFS(file, ChAtime|ChMtime, cache, opts); err != nil {
+					t.Errorf("NormalizeToFS(%s) error = %v", file, err)
+				}
+			}
+
+			w.Close()
+
+			os.Stdout = oldStdout
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if got := buf.String(); got != tt.wantStdout {
+				t.Errorf("NormalizeToFS() stdout = %q, want %q", got, tt.wantStdout)
+			}
+
+			for _, file := range files {
+
+				info, err := memFS.Stat(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				atime := platform.GetAtime(info)
+				if !atime.Equal(tt.wantAtime) || !info.ModTime().Equal(tt.wantMtime) {
+					t.Errorf("NormalizeToFS(%s) set times %v, %v, want %v, %v",
+						file, atime, info.ModTime(), tt.wantAtime, tt.wantMtime)
+				}
+			}
+
+			wantProbes, wantHooked := 1, len(files)
+			if tt.dryRun {
+				wantProbes, wantHooked = 0, 0
+			}
+
+			if got := int(memFS.probes.Load()); got != wantProbes {
+				t.Errorf("NormalizeToFS() probed %d times, want %d", got, wantProbes)
+			}
+
+			if len(hooked) != wantHooked {
+				t.Errorf("NormalizeToFS() ran AfterTouch for %v, want %d files", hooked, wantHooked)
+			}
+		})
+	}
+}
+
+func TestNormalizeToFS_missingFile(t *testing.T) {
+	filesystem.Default = filesystem.NewMemFS()
+
+	err := NormalizeToFS("missing.txt", ChAtime|ChMtime, &GranularityCache{}, Options{})
+	if err == nil {
+		t.Error("NormalizeToFS() error = nil, want an error for a missing file")
+	}
+}
+
+func Test_detectGranularity(t *testing.T) {
+	tests := []struct {
+		name    string
+		stored  Time
+		want    time.Duration
+		wantErr error
+	}{
+		{
+			name:   "nanoseconds",
+			stored: granularityProbeTime,
+			want:   time.Nanosecond,
+		},
+		{
+			name:   "100 nanoseconds",
+			stored: time.Unix(1_000_000_001, 999_999_900),
+			want:   100 * time.Nanosecond,
+		},
+		{
+			name:   "microseconds",
+			stored: time.Unix(1_000_000_001, 999_999_000),
+			want:   time.Microsecond,
+		},
+		{
+			name:   "10 milliseconds",
+			stored: time.Unix(1_000_000_001, 990_000_000),
+			want:   10 * time.Millisecond,
+		},
+		{
+			name:   "seconds",
+			stored: time.Unix(1_000_000_001, 0),
+			want:   time.Second,
+		},
+		{
+			name:   "2 seconds",
+			stored: time.Unix(1_000_000_000, 0),
+			want:   2 * time.Second,
+		},
+		{
+			name:    "probe time not kept",
+			stored:  time.Unix(1_700_000_000, 0),
+			wantErr: touchErrors.ErrTimeProbeMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := detectGranularity(tt.stored)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("detectGranularity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("detectGranularity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package core provides the main Touch function and utilities, orchestrating file timestamp changes.
+// This file normalizes and preserves the times of symlinks themselves.
+package core
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+// NormalizeSymlinkTimes sets the times of the symlink file itself to those of its target,
+// limited to the times selected by change; unselected times keep the link's own values.
+// Files that aren't symlinks are left untouched, and a dangling symlink is an error.
+// The AfterTouch hook in opts runs once the link is updated, and DryRun reports the update
+// instead of making it, detailed by Diff; other options are ignored.
+func NormalizeSymlinkTimes(file string, change int, opts Options) error {
+	linkInfo, err := filesystem.Default.Lstat(file)
+	if err != nil {
+		return fmt.Errorf("lstat file %s: %w", file, err)
+	}
+
+	if linkInfo.Mode()&os.ModeSymlink == 0 {
+		return nil // Not a symlink; nothing to normalize.
+	}
+
+	targetInfo, err := filesystem.Default.Stat(file)
+	if err != nil {
+		return fmt.Errorf("stat symlink target %s: %w", file, err)
+	}
+
+	accessTime := platform.GetAtime(targetInfo)
+	modTime := targetInfo.ModTime()
+
+	if change&ChAtime == 0 {
+		accessTime = platform.GetAtime(linkInfo)
+	}
+
+	if change&ChMtime == 0 {
+		modTime = linkInfo.ModTime()
+	}
+
+	if opts.DryRun {
+		reportDryRun("would set times on", file)
+
+		if opts.Diff {
+			reportDiff(linkInfo, accessTime, modTime)
+		}
+
+		return nil
+	}
+
+	if err := platform.SetTimesNoDeref(file, accessTime, modTime); err != nil {
+		return fmt.Errorf("set times no deref %s: %w", file, err)
+	}
+
+	return opts.afterTouch(file)
+}
+
+// touchPreservingLink touches the target of file and, when file is a symlink, then restores
+// the link's own times, read before touching, before running any AfterTouch hook.
+// Files that aren't symlinks, including missing ones, are touched as usual.
+func touchPreservingLink(
+	file string,
+	change int,
+	noCreate bool,
+	accessTimeParam, modTimeParam Time,
+	opts Options,
+) error {
+	opts.PreserveLinkTimes = false
+
+	linkInfo, err := filesystem.Default.Lstat(file)
+	if err != nil || linkInfo.Mode()&os.ModeSymlink == 0 {
+		return TouchWithOptions(file, change, noCreate, false, accessTimeParam, modTimeParam, opts)
+	}
+
+	linkAccess := platform.GetAtime(linkInfo)
+	linkMod := linkInfo.ModTime()
+	afterTouch := opts.AfterTouch
+
+	opts.AfterTouch = func(touched string) error {
+		if err := platform.SetTimesNoDeref(file, linkAccess, linkMod); err != nil {
+			return fmt.Errorf("restore symlink times %s: %w", file, err)
+		}
+
+		if afterTouch == nil {
+			return nil
+		}
+
+		return afterTouch(touched)
+	}
+
+	return TouchWithOptions(file, change, noCreate, false, accessTimeParam, modTimeParam, opts)
+}
@@ -0,0 +1,300 @@
+//go:build !windows
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package core provides the main Touch function and utilities, orchestrating file timestamp changes.
+// This file normalizes and preserves the times of symlinks themselves.
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+func TestNormalizeSymlinkTimes(t *testing.T) {
+	targetAtime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.Local)
+	targetMtime := time.Date(2020, 6, 7, 8, 9, 10, 0, time.Local)
+	linkTime := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		change    int
+		dangling  bool
+		wantAtime Time
+		wantMtime Time
+		wantErr   bool
+	}{
+		{
+			name:      "both times",
+			change:    ChAtime | ChMtime,
+			dangling:  false,
+			wantAtime: targetAtime,
+			wantMtime: targetMtime,
+			wantErr:   false,
+		},
+		{
+			name:      "modification time only",
+			change:    ChMtime,
+			dangling:  false,
+			wantAtime: linkTime,
+			wantMtime: targetMtime,
+			wantErr:   false,
+		},
+		{
+			name:      "access time only",
+			change:    ChAtime,
+			dangling:  false,
+			wantAtime: targetAtime,
+			wantMtime: linkTime,
+			wantErr:   false,
+		},
+		{
+			name:      "dangling symlink",
+			change:    ChAtime | ChMtime,
+			dangling:  true,
+			wantAtime: Time{},
+			wantMtime: Time{},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem.Default = realFS
+
+			dir := t.TempDir()
+			target := filepath.Join(dir, "target.txt")
+			link := filepath.Join(dir, "link.txt")
+
+			if !tt.dangling {
+				if err := os.WriteFile(target, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.Chtimes(target, targetAtime, targetMtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := os.Symlink(target, link); err != nil {
+				t.Fatal(err)
+			}
+
+			// Desynchronize the link from its target.
+			if err := platform.SetTimesNoDeref(link, linkTime, linkTime); err != nil {
+				t.Fatal(err)
+			}
+
+			var hooked []string
+
+			opts := Options{AfterTouch: func(file string) error {
+				hooked = append(hooked, file)
+
+				return nil
+			}}
+
+			err := NormalizeSymlinkTimes(link, tt.change, opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeSymlinkTimes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if wantHooked := !tt.wantErr; (len(hooked) == 1) != wantHooked {
+				t.Errorf("NormalizeSymlinkTimes() AfterTouch calls = %v, want called %v", hooked, wantHooked)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			linkInfo, err := os.Lstat(link)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := platform.GetAtime(linkInfo); !got.Equal(tt.wantAtime) {
+				t.Errorf("NormalizeSymlinkTimes() link atime = %v, want %v", got, tt.wantAtime)
+			}
+
+			if got := linkInfo.ModTime(); !got.Equal(tt.wantMtime) {
+				t.Errorf("NormalizeSymlinkTimes() link mtime = %v, want %v", got, tt.wantMtime)
+			}
+		})
+	}
+}
+
+func TestNormalizeSymlinkTimes_regularFile(t *testing.T) {
+	filesystem.Default = realFS
+
+	file := filepath.Join(t.TempDir(), "file.txt")
+	fileTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.Local)
+
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(file, fileTime, fileTime); err != nil {
+		t.Fatal(err)
+	}
+
+	hooked := false
+
+	opts := Options{AfterTouch: func(string) error {
+		hooked = true
+
+		return nil
+	}}
+
+	if err := NormalizeSymlinkTimes(file, ChAtime|ChMtime, opts); err != nil {
+		t.Fatalf("NormalizeSymlinkTimes() error = %v", err)
+	}
+
+	if hooked {
+		t.Error("NormalizeSymlinkTimes() ran AfterTouch for a regular file")
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !info.ModTime().Equal(fileTime) || !platform.GetAtime(info).Equal(fileTime) {
+		t.Errorf("NormalizeSymlinkTimes() changed a regular file's times")
+	}
+}
+
+func TestTouchWithOptions_preserveLinkTimes(t *testing.T) {
+	linkAtime := time.Date(2021, 3, 4, 5, 6, 7, 0, time.Local)
+	linkMtime := time.Date(2021, 8, 9, 10, 11, 12, 0, time.Local)
+	targetTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.Local)
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		targetGone bool
+	}{
+		{
+			name:       "existing target",
+			targetGone: false,
+		},
+		{
+			name:       "target created through a dangling link",
+			targetGone: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem.Default = realFS
+
+			dir := t.TempDir()
+			target := filepath.Join(dir, "target.txt")
+			link := filepath.Join(dir, "link.txt")
+
+			if !tt.targetGone {
+				if err := os.WriteFile(target, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.Chtimes(target, targetTime, targetTime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := os.Symlink(target, link); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := platform.SetTimesNoDeref(link, linkAtime, linkMtime); err != nil {
+				t.Fatal(err)
+			}
+
+			var linkAtHook os.FileInfo
+
+			opts := Options{
+				PreserveLinkTimes: true,
+				AfterTouch: func(file string) error {
+					var err error
+
+					linkAtHook, err = os.Lstat(file)
+
+					return err
+				},
+			}
+
+			if err := TouchWithOptions(link, ChAtime|ChMtime, false, false, stamp, stamp, opts); err != nil {
+				t.Fatalf("TouchWithOptions() error = %v", err)
+			}
+
+			targetInfo, err := os.Stat(target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !targetInfo.ModTime().Equal(stamp) || !platform.GetAtime(targetInfo).Equal(stamp) {
+				t.Errorf("TouchWithOptions() target times = %v, %v, want %v",
+					platform.GetAtime(targetInfo), targetInfo.ModTime(), stamp)
+			}
+
+			linkInfo, err := os.Lstat(link)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := platform.GetAtime(linkInfo); !got.Equal(linkAtime) {
+				t.Errorf("TouchWithOptions() link atime = %v, want preserved %v", got, linkAtime)
+			}
+
+			if got := linkInfo.ModTime(); !got.Equal(linkMtime) {
+				t.Errorf("TouchWithOptions() link mtime = %v, want preserved %v", got, linkMtime)
+			}
+
+			// The hook runs after the link's times are restored.
+			if linkAtHook == nil || !linkAtHook.ModTime().Equal(linkMtime) {
+				t.Errorf("TouchWithOptions() AfterTouch saw link info %v, want restored times", linkAtHook)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_preserveLinkTimesRegularFile(t *testing.T) {
+	filesystem.Default = realFS
+
+	file := filepath.Join(t.TempDir(), "file.txt")
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := Options{PreserveLinkTimes: true}
+	if err := TouchWithOptions(file, ChAtime|ChMtime, false, false, stamp, stamp, opts); err != nil {
+		t.Fatalf("TouchWithOptions() error = %v", err)
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !info.ModTime().Equal(stamp) {
+		t.Errorf("TouchWithOptions() mtime = %v, want %v", info.ModTime(), stamp)
+	}
+}
@@ -20,28 +20,89 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
 	"time"
 
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
 	"github.com/nicholas-fedor/touch/internal/filesystem"
 	"github.com/nicholas-fedor/touch/internal/platform"
 )
 
 // Constants for timestamp change masks.
-// These bit flags determine which timestamps (access, modification) to update.
+// These bit flags determine which timestamps (access, modification, birth) to update.
 const (
 	ChAtime = 1 << iota // Flag to change access time.
 	ChMtime             // Flag to change modification time.
+	ChBtime             // Flag to change birth time, set to the modification time argument.
 )
 
+// parentDirPerm is the permission of parent directories created for new files, before umask.
+const parentDirPerm = 0o755
+
 // Time is an alias for time.Time, used for clarity in function signatures.
 type Time = time.Time
 
 // Now is a variable holding the function to get current time, allowing mocking in tests.
+// It is the default for a nil Clock; callers that need their own time source pass a Clock.
 var Now = time.Now
 
+// Clock is a source of the current time, passed to calls that need one instead of Now.
+type Clock interface {
+	Now() Time
+}
+
+// ClockFunc adapts a function returning the current time, such as a fixed time in tests, to Clock.
+type ClockFunc func() Time
+
+// Now returns the result of calling f.
+func (f ClockFunc) Now() Time {
+	return f()
+}
+
+// NowFrom returns the current time from clock, or from Now if clock is nil.
+func NowFrom(clock Clock) Time {
+	if clock == nil {
+		return Now()
+	}
+
+	return clock.Now()
+}
+
+// monotonicClock tracks the last time handed out by MonotonicNow.
+var monotonicClock struct {
+	mu   sync.Mutex
+	last Time
+}
+
+// MonotonicNow returns the current time from Now, advanced as needed so that each
+// call returns a wall-clock time at least 1ns later than the previous call.
+// This keeps sequential touches strictly increasing even if the clock steps backward.
+func MonotonicNow() Time {
+	return MonotonicNowFrom(nil)
+}
+
+// MonotonicNowFrom is like MonotonicNow, but reads the current time from clock.
+func MonotonicNowFrom(clock Clock) Time {
+	monotonicClock.mu.Lock()
+	defer monotonicClock.mu.Unlock()
+
+	// Strip the monotonic reading so comparisons use the wall clock written to files.
+	now := NowFrom(clock).Round(0)
+	if !now.After(monotonicClock.last) {
+		now = monotonicClock.last.Add(time.Nanosecond)
+	}
+
+	monotonicClock.last = now
+
+	return now
+}
+
 // BoolToInt converts a boolean to an integer (1 for true, 0 for false).
 // Used for counting active flags in validation.
 func BoolToInt(b bool) int {
@@ -58,41 +119,197 @@ func Quote(s string) string {
 	return fmt.Sprintf("%q", s)
 }
 
+// Options holds optional behaviors for TouchWithOptions.
+// The zero value matches the behavior of Touch.
+type Options struct {
+	Content       []byte // Written to newly created files before their times are set.
+	ClampNewToNow bool   // Newly created files never receive times later than Clock's time.
+	Clock         Clock  // Source of the current time; nil reads Now.
+	CreateParents bool   // Missing parent directories of a new file are created first.
+	DryRun        bool   // Report the change on stdout instead of making it; AfterTouch is not called.
+	ForwardOnly   bool   // Keep an existing file's access or modification time if it is later.
+	Special       bool   // Touch FIFOs, sockets, and devices instead of failing with ErrSpecialFile.
+	// IfExists leaves a missing file uncreated, whatever noCreate says, and returns an error
+	// wrapping os.ErrNotExist so callers can tell it apart from a file that was touched.
+	IfExists bool
+	// Diff, with DryRun, follows the report for an existing file with its current and new
+	// access and modification times, each marked "(unchanged)" if it would keep its value.
+	Diff bool
+	// PreserveLinkTimes restores a symlink's own times after touching its target.
+	// It has no effect under noDeref, where the link itself is touched.
+	PreserveLinkTimes bool
+	// OnlyOlderThan, if non-zero, leaves existing files whose modification time isn't
+	// before it untouched, returning an error wrapping ErrNotOlder so callers can tell them
+	// apart from files that were touched. Missing files are still created unless noCreate is set.
+	OnlyOlderThan Time
+	// Adjust, if non-zero, shifts the file's existing access and modification times by this
+	// duration instead of setting the given times. Missing files can't be adjusted.
+	Adjust time.Duration
+
+	// OnCreate, if set, is called with the file name as soon as this call has created it.
+	// A file someone else created since it was found missing is opened instead, without it.
+	OnCreate func(file string)
+
+	// AfterTouch, if set, is called with the file name once its times have been set.
+	// It is not called for files skipped because of noCreate. Its error is returned.
+	AfterTouch func(file string) error
+}
+
 // Touch updates the access and/or modification times of the file at path.
 // If the file does not exist and noCreate is false, it creates an empty file.
-// The change mask determines which times to update (ChAtime, ChMtime).
-// If noDeref is true, it affects symlinks without following them (unsupported on Windows).
-// Returns an error if the operation fails.
+// The change mask determines which times to update (ChAtime, ChMtime, ChBtime);
+// ChBtime sets the birth time to modTimeParam where the platform supports it.
+// If noDeref is true, it affects symlinks without following them;
+// a dangling symlink is touched itself rather than replaced by a new file.
+// Existing directories have their times updated like files. Returns an error if the
+// operation fails, wrapping ErrParentNotDirectory if one of path's parents is a regular file.
+// FIFOs, sockets, and devices are left untouched with an error wrapping ErrSpecialFile,
+// unless Options.Special is set.
 func Touch(
 	file string,
 	change int,
 	noCreate, noDeref bool,
 	accessTimeParam, modTimeParam Time,
 ) error {
-	fileInfo, err := filesystem.Default.Stat(file)
+	return TouchCtx(context.Background(), file, change, noCreate, noDeref, accessTimeParam, modTimeParam)
+}
+
+// TouchCtx behaves like Touch, but returns ctx's error without touching file if ctx is done.
+func TouchCtx(
+	ctx context.Context,
+	file string,
+	change int,
+	noCreate, noDeref bool,
+	accessTimeParam, modTimeParam Time,
+) error {
+	return TouchWithOptionsCtx(ctx, file, change, noCreate, noDeref, accessTimeParam, modTimeParam, Options{})
+}
+
+// TouchWithOptions behaves like Touch, additionally applying the behaviors selected in opts.
+func TouchWithOptions(
+	file string,
+	change int,
+	noCreate, noDeref bool,
+	accessTimeParam, modTimeParam Time,
+	opts Options,
+) error {
+	return TouchWithOptionsCtx(
+		context.Background(), file, change, noCreate, noDeref, accessTimeParam, modTimeParam, opts,
+	)
+}
+
+// TouchWithOptionsCtx behaves like TouchWithOptions, but returns ctx's error without
+// touching file if ctx is done.
+func TouchWithOptionsCtx(
+	ctx context.Context,
+	file string,
+	change int,
+	noCreate, noDeref bool,
+	accessTimeParam, modTimeParam Time,
+	opts Options,
+) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("touch %s: %w", file, err)
+	}
+
+	if opts.PreserveLinkTimes && !noDeref {
+		return touchPreservingLink(file, change, noCreate, accessTimeParam, modTimeParam, opts)
+	}
+
+	// Under noDeref, inspect the link itself so existence checks and preserved times match
+	// the no-dereference update, and a dangling symlink is not replaced by a new file.
+	var (
+		fileInfo os.FileInfo
+		err      error
+	)
+
+	if noDeref {
+		fileInfo, err = filesystem.Default.Lstat(file)
+	} else {
+		fileInfo, err = filesystem.Default.Stat(file)
+	}
+
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
+			if opts.IfExists {
+				return fmt.Errorf("stat file %s: %w", file, err)
+			}
+
 			if noCreate {
 				return nil // No creation requested; silently succeed.
 			}
 
-			newFile, err := filesystem.Default.Create(file)
+			if opts.Adjust != 0 {
+				return fmt.Errorf("adjust times of %s: %w", file, err)
+			}
+
+			if opts.DryRun {
+				reportDryRun("would create", file)
+
+				return nil
+			}
+
+			newFile, created, err := createFile(file, opts.CreateParents)
 			if err != nil {
-				return fmt.Errorf("create file %s: %w", file, err)
+				return err
 			}
 			defer newFile.Close()
+
+			if created && opts.OnCreate != nil {
+				opts.OnCreate(file)
+			}
+
+			// Write initial content, if any, before setting times so the write doesn't bump them.
+			if len(opts.Content) > 0 {
+				if err := writeContent(newFile, file, opts.Content); err != nil {
+					return err
+				}
+			}
+
+			if opts.ClampNewToNow {
+				now := NowFrom(opts.Clock)
+				accessTimeParam = earliest(accessTimeParam, now)
+				modTimeParam = earliest(modTimeParam, now)
+			}
+
 			// Set times on the newly created file.
 			if err := filesystem.Default.Chtimes(file, accessTimeParam, modTimeParam); err != nil {
 				return fmt.Errorf("chtimes new file %s: %w", file, err)
 			}
 
-			return nil
+			if change&ChBtime != 0 {
+				if err := platform.SetBtime(file, modTimeParam, false); err != nil {
+					return fmt.Errorf("set birth time %s: %w", file, err)
+				}
+			}
+
+			return opts.afterTouch(file)
+		}
+
+		if parentErr := parentNotDirectory(file, err); parentErr != nil {
+			return parentErr
 		}
 
 		return fmt.Errorf("stat file %s: %w", file, err)
 	}
 
-	// File exists; determine times to set, preserving unchanged ones.
+	// Leave FIFOs, sockets, and devices alone, as a glob can pick them up by accident.
+	if !opts.Special && isSpecial(fileInfo.Mode()) {
+		return fmt.Errorf("touch %s: %w", file, touchErrors.ErrSpecialFile)
+	}
+
+	// File exists; skip it if it was modified at or after OnlyOlderThan.
+	if !opts.OnlyOlderThan.IsZero() && !fileInfo.ModTime().Before(opts.OnlyOlderThan) {
+		return fmt.Errorf("touch %s: %w", file, touchErrors.ErrNotOlder)
+	}
+
+	// Shift the existing times rather than setting the given ones.
+	if opts.Adjust != 0 {
+		accessTimeParam = platform.GetAtime(fileInfo).Add(opts.Adjust)
+		modTimeParam = fileInfo.ModTime().Add(opts.Adjust)
+	}
+
+	// Determine times to set, preserving unchanged ones.
 	accessTime := accessTimeParam
 	modTime := modTimeParam
 
@@ -106,7 +323,40 @@ func Touch(
 		modTime = fileInfo.ModTime()
 	}
 
-	// Apply the times.
+	// Never move either time backward, deciding access and modification times independently.
+	if opts.ForwardOnly {
+		accessTime = latest(accessTime, platform.GetAtime(fileInfo))
+		modTime = latest(modTime, fileInfo.ModTime())
+	}
+
+	if opts.DryRun {
+		reportDryRun("would set times on", file)
+
+		if opts.Diff {
+			reportDiff(fileInfo, accessTime, modTime)
+		}
+
+		return nil
+	}
+
+	// Apply the times, leaving access and modification times alone if only birth time changes.
+	if change&(ChAtime|ChMtime) != 0 {
+		if err := setTimes(file, noDeref, accessTime, modTime); err != nil {
+			return err
+		}
+	}
+
+	if change&ChBtime != 0 {
+		if err := platform.SetBtime(file, modTimeParam, noDeref); err != nil {
+			return fmt.Errorf("set birth time %s: %w", file, err)
+		}
+	}
+
+	return opts.afterTouch(file)
+}
+
+// setTimes sets the access and modification times of file, or of a symlink itself under noDeref.
+func setTimes(file string, noDeref bool, accessTime, modTime Time) error {
 	if noDeref {
 		if err := platform.SetTimesNoDeref(file, accessTime, modTime); err != nil {
 			return fmt.Errorf("set times no deref %s: %w", file, err)
@@ -121,3 +371,144 @@ func Touch(
 
 	return nil
 }
+
+// createFile creates file, first creating its missing parent directories when
+// createParents is set and the initial attempt fails because they don't exist.
+// A file that appeared since it was found missing is opened without being truncated, and
+// created reports whether the file was made here.
+func createFile(file string, createParents bool) (filesystem.File, bool, error) {
+	newFile, created, err := filesystem.Default.OpenForCreate(file)
+	if err == nil {
+		return newFile, created, nil
+	}
+
+	if parentErr := parentNotDirectory(file, err); parentErr != nil {
+		return nil, false, parentErr
+	}
+
+	if !createParents || !errors.Is(err, os.ErrNotExist) {
+		return nil, false, fmt.Errorf("create file %s: %w", file, err)
+	}
+
+	parent := filepath.Dir(file)
+	if err := filesystem.Default.MkdirAll(parent, parentDirPerm); err != nil {
+		if parentErr := parentNotDirectory(file, err); parentErr != nil {
+			return nil, false, parentErr
+		}
+
+		return nil, false, fmt.Errorf("create parent directories of %s: %w", file, err)
+	}
+
+	newFile, created, err = filesystem.Default.OpenForCreate(file)
+	if err != nil {
+		return nil, false, fmt.Errorf("create file %s: %w", file, err)
+	}
+
+	return newFile, created, nil
+}
+
+// parentNotDirectory returns an ErrParentNotDirectory error naming the regular file among
+// file's ancestors when err reports that one of them isn't a directory, or nil otherwise.
+func parentNotDirectory(file string, err error) error {
+	if !errors.Is(err, syscall.ENOTDIR) && !errors.Is(err, touchErrors.ErrNotDirectory) {
+		return nil
+	}
+
+	for dir := filepath.Dir(file); ; dir = filepath.Dir(dir) {
+		info, statErr := filesystem.Default.Stat(dir)
+		if statErr == nil {
+			if info.IsDir() {
+				return nil
+			}
+
+			return fmt.Errorf(
+				"create file %s: %w: %s is a file",
+				file,
+				touchErrors.ErrParentNotDirectory,
+				Quote(dir),
+			)
+		}
+
+		if parent := filepath.Dir(dir); parent == dir {
+			return nil
+		}
+	}
+}
+
+// writeContent writes content to newFile, the handle createFile opened for file, unless the
+// file already holds data because it appeared since it was found missing.
+func writeContent(newFile filesystem.File, file string, content []byte) error {
+	info, err := newFile.Stat()
+	if err != nil {
+		return fmt.Errorf("stat new file %s: %w", file, err)
+	}
+
+	if info.Size() > 0 {
+		return nil
+	}
+
+	if _, err := newFile.Write(content); err != nil {
+		return fmt.Errorf("write content to %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// isSpecial reports whether mode describes a FIFO, socket, or device file.
+func isSpecial(mode os.FileMode) bool {
+	return mode&(os.ModeNamedPipe|os.ModeSocket|os.ModeDevice|os.ModeCharDevice) != 0
+}
+
+// earliest returns the earlier of a and b.
+func earliest(a, b Time) Time {
+	if b.Before(a) {
+		return b
+	}
+
+	return a
+}
+
+// latest returns the later of a and b.
+func latest(a, b Time) Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
+
+// reportDryRun prints the action a dry run would have taken on file to stdout.
+func reportDryRun(action, file string) {
+	fmt.Fprintf(os.Stdout, "%s %s\n", action, Quote(file))
+}
+
+// reportDiff prints the change of a file's times from those in fileInfo to accessTime and
+// modTime to stdout, marking any time that would keep its value.
+func reportDiff(fileInfo os.FileInfo, accessTime, modTime Time) {
+	reportTimeDiff("atime", platform.GetAtime(fileInfo), accessTime)
+	reportTimeDiff("mtime", fileInfo.ModTime(), modTime)
+}
+
+// reportTimeDiff prints one line of reportDiff, for the time called name.
+func reportTimeDiff(name string, oldTime, newTime Time) {
+	line := fmt.Sprintf(
+		"  %s: %s -> %s",
+		name,
+		oldTime.Format(time.RFC3339Nano),
+		newTime.Format(time.RFC3339Nano),
+	)
+	if oldTime.Equal(newTime) {
+		line += " (unchanged)"
+	}
+
+	fmt.Fprintln(os.Stdout, line)
+}
+
+// afterTouch runs the AfterTouch hook for file, if one is set.
+func (o Options) afterTouch(file string) error {
+	if o.AfterTouch == nil {
+		return nil
+	}
+
+	return o.AfterTouch(file)
+}
@@ -20,13 +20,21 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package core
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/mock"
 
-	"github.com/nicholas-fedor/touch/internal/errors"
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
 	"github.com/nicholas-fedor/touch/internal/filesystem"
 	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
 	"github.com/nicholas-fedor/touch/internal/platform"
@@ -112,6 +120,45 @@ func TestQuote(t *testing.T) {
 	}
 }
 
+func TestMonotonicNow(t *testing.T) {
+	base := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name  string
+		clock []Time
+	}{
+		{
+			name:  "frozen clock",
+			clock: []Time{base, base, base, base},
+		},
+		{
+			name:  "clock stepped backward",
+			clock: []Time{base.Add(time.Second), base, base.Add(-time.Minute), base.Add(2 * time.Second)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			clock := ClockFunc(func() Time {
+				now := tt.clock[calls]
+				calls++
+
+				return now
+			})
+
+			prev := MonotonicNowFrom(clock)
+			for range len(tt.clock) - 1 {
+				got := MonotonicNowFrom(clock)
+				if !got.After(prev) {
+					t.Errorf("MonotonicNowFrom() = %v, want after %v", got, prev)
+				}
+
+				prev = got
+			}
+		})
+	}
+}
+
 func TestTouch(t *testing.T) {
 	type args struct {
 		file            string
@@ -204,7 +251,7 @@ func TestTouch(t *testing.T) {
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "new.txt").Return(nil, os.ErrNotExist)
-				m.On("Create", "new.txt").Return(&os.File{}, nil)
+				m.On("OpenForCreate", "new.txt").Return(&os.File{}, true, nil)
 				m.On("Chtimes", "new.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
 					Return(nil)
 			},
@@ -240,15 +287,61 @@ func TestTouch(t *testing.T) {
 				modTimeParam:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
-				m.On("Stat", "symlink.txt").
+				m.On("Lstat", "symlink.txt").
 					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)}, nil)
 			},
 			mockGetAtime: nil,
 			mockSetNoDeref: func(string, Time, Time) error {
-				return errors.ErrNoDerefUnsupported
+				return touchErrors.ErrNoDerefUnsupported
 			},
 			wantErr: true,
 		},
+		{
+			name: "no deref dangling symlink",
+			args: args{
+				file:            "dangling.txt",
+				change:          ChAtime | ChMtime,
+				noCreate:        false,
+				noDeref:         true,
+				accessTimeParam: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTimeParam:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Lstat", "dangling.txt").
+					Return(&mockFileInfo{mod: time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local), mode: os.ModeSymlink}, nil)
+			},
+			mockGetAtime: nil,
+			mockSetNoDeref: func(file string, atime, mtime Time) error {
+				if file != "dangling.txt" ||
+					!atime.Equal(time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)) ||
+					!mtime.Equal(time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local)) {
+					return os.ErrInvalid
+				}
+
+				return nil
+			},
+			wantErr: false,
+		},
+		{
+			name: "no deref missing file is created",
+			args: args{
+				file:            "new.txt",
+				change:          ChAtime | ChMtime,
+				noCreate:        false,
+				noDeref:         true,
+				accessTimeParam: time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local),
+				modTimeParam:    time.Date(2025, 7, 13, 13, 0, 0, 0, time.Local),
+			},
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Lstat", "new.txt").Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", "new.txt").Return(&os.File{}, true, nil)
+				m.On("Chtimes", "new.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+			},
+			mockGetAtime:   nil,
+			mockSetNoDeref: nil,
+			wantErr:        false,
+		},
 		{
 			name: "error on stat",
 			args: args{
@@ -278,7 +371,7 @@ func TestTouch(t *testing.T) {
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "new_error.txt").Return(nil, os.ErrNotExist)
-				m.On("Create", "new_error.txt").Return(nil, os.ErrPermission)
+				m.On("OpenForCreate", "new_error.txt").Return(nil, false, os.ErrPermission)
 			},
 			mockGetAtime:   nil,
 			mockSetNoDeref: nil,
@@ -316,7 +409,7 @@ func TestTouch(t *testing.T) {
 			},
 			mockFSSetup: func(m *mocks.MockFS) {
 				m.On("Stat", "new_chtimes_error.txt").Return(nil, os.ErrNotExist)
-				m.On("Create", "new_chtimes_error.txt").Return(&os.File{}, nil)
+				m.On("OpenForCreate", "new_chtimes_error.txt").Return(&os.File{}, true, nil)
 				m.On("Chtimes", "new_chtimes_error.txt", mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
 					Return(os.ErrPermission)
 			},
@@ -363,14 +456,1327 @@ func TestTouch(t *testing.T) {
 	}
 }
 
-// mockFileInfo is a simple mock for os.FileInfo in tests.
-type mockFileInfo struct {
-	mod Time
+func TestTouchWithOptions_content(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		existing    *string
+		content     string
+		wantContent string
+	}{
+		{
+			name:        "new file receives content",
+			existing:    nil,
+			content:     "# placeholder\n",
+			wantContent: "# placeholder\n",
+		},
+		{
+			name:        "existing file is not written",
+			existing:    func() *string { s := "original"; return &s }(),
+			content:     "# placeholder\n",
+			wantContent: "original",
+		},
+		{
+			name:        "no content creates empty file",
+			existing:    nil,
+			content:     "",
+			wantContent: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem.Default = realFS
+
+			path := filepath.Join(t.TempDir(), "file.txt")
+			if tt.existing != nil {
+				if err := os.WriteFile(path, []byte(*tt.existing), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := TouchWithOptions(
+				path,
+				ChAtime|ChMtime,
+				false,
+				false,
+				stamp,
+				stamp,
+				Options{Content: []byte(tt.content)},
+			)
+			if err != nil {
+				t.Fatalf("TouchWithOptions() error = %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != tt.wantContent {
+				t.Errorf("TouchWithOptions() content = %q, want %q", data, tt.wantContent)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !info.ModTime().Equal(stamp) {
+				t.Errorf("TouchWithOptions() mtime = %v, want %v", info.ModTime(), stamp)
+			}
+		})
+	}
 }
 
-func (m mockFileInfo) Name() string      { return "" }
-func (m mockFileInfo) Size() int64       { return 0 }
-func (m mockFileInfo) Mode() os.FileMode { return 0 }
-func (m mockFileInfo) ModTime() Time     { return m.mod }
-func (m mockFileInfo) IsDir() bool       { return false }
-func (m mockFileInfo) Sys() any          { return nil }
+// lateFS reports missing as not existing, like a file created by someone else just after
+// Touch looked for it, while every other operation sees the real file.
+type lateFS struct {
+	filesystem.FS
+
+	missing string
+}
+
+func (l lateFS) Stat(path string) (os.FileInfo, error) {
+	if path == l.missing {
+		return nil, fmt.Errorf("stat %s: %w", path, os.ErrNotExist)
+	}
+
+	return l.FS.Stat(path)
+}
+
+func TestTouchWithOptions_createRace(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "no content", content: ""},
+		{name: "with content", content: "# placeholder\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "file.txt")
+			if err := os.WriteFile(path, []byte("original"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			filesystem.Default = lateFS{FS: realFS, missing: path}
+			t.Cleanup(func() { filesystem.Default = realFS })
+
+			created := false
+			err := TouchWithOptions(
+				path,
+				ChAtime|ChMtime,
+				false,
+				false,
+				stamp,
+				stamp,
+				Options{Content: []byte(tt.content), OnCreate: func(string) { created = true }},
+			)
+			if err != nil {
+				t.Fatalf("TouchWithOptions() error = %v", err)
+			}
+
+			// The file was made by someone else, so it isn't reported as created.
+			if created {
+				t.Error("TouchWithOptions() called OnCreate for a file it didn't create")
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != "original" {
+				t.Errorf("TouchWithOptions() content = %q, want %q", data, "original")
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !info.ModTime().Equal(stamp) {
+				t.Errorf("TouchWithOptions() mtime = %v, want %v", info.ModTime(), stamp)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_onCreate(t *testing.T) {
+	filesystem.Default = realFS
+
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+	path := filepath.Join(t.TempDir(), "new.txt")
+
+	var created []string
+
+	opts := Options{OnCreate: func(file string) { created = append(created, file) }}
+
+	// Only the first touch creates the file.
+	for range 2 {
+		if err := TouchWithOptions(path, ChAtime|ChMtime, false, false, stamp, stamp, opts); err != nil {
+			t.Fatalf("TouchWithOptions() error = %v", err)
+		}
+	}
+
+	if len(created) != 1 || created[0] != path {
+		t.Errorf("OnCreate calls = %q, want [%q]", created, path)
+	}
+}
+
+func TestTouchWithOptions_afterTouch(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+	errHook := errors.New("hook failed")
+
+	tests := []struct {
+		name        string
+		noCreate    bool
+		mockFSSetup func(*mocks.MockFS)
+		hookErr     error
+		wantCalls   []string
+		wantErr     error
+	}{
+		{
+			name:     "called for existing file",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(nil)
+			},
+			hookErr:   nil,
+			wantCalls: []string{"file.txt"},
+			wantErr:   nil,
+		},
+		{
+			name:     "not called for skipped missing file",
+			noCreate: true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
+			},
+			hookErr:   nil,
+			wantCalls: nil,
+			wantErr:   nil,
+		},
+		{
+			name:     "not called when setting times fails",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(os.ErrPermission)
+			},
+			hookErr:   nil,
+			wantCalls: nil,
+			wantErr:   os.ErrPermission,
+		},
+		{
+			name:     "hook error returned",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: stamp}, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(nil)
+			},
+			hookErr:   errHook,
+			wantCalls: []string{"file.txt"},
+			wantErr:   errHook,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			var calls []string
+
+			err := TouchWithOptions(
+				"file.txt",
+				ChAtime|ChMtime,
+				tt.noCreate,
+				false,
+				stamp,
+				stamp,
+				Options{
+					AfterTouch: func(file string) error {
+						calls = append(calls, file)
+
+						return tt.hookErr
+					},
+				},
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TouchWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("TouchWithOptions() AfterTouch calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_dryRun(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		noCreate    bool
+		noDeref     bool
+		mockFSSetup func(*mocks.MockFS)
+		wantStdout  string
+		wantErr     error
+	}{
+		{
+			name:     "existing file",
+			noCreate: false,
+			noDeref:  false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: stamp}, nil)
+			},
+			wantStdout: "would set times on \"file.txt\"\n",
+			wantErr:    nil,
+		},
+		{
+			name:     "missing file",
+			noCreate: false,
+			noDeref:  false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
+			},
+			wantStdout: "would create \"file.txt\"\n",
+			wantErr:    nil,
+		},
+		{
+			name:     "missing file with no create",
+			noCreate: true,
+			noDeref:  false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
+			},
+			wantStdout: "",
+			wantErr:    nil,
+		},
+		{
+			name:     "symlink itself",
+			noCreate: false,
+			noDeref:  true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Lstat", "file.txt").Return(&mockFileInfo{mod: stamp}, nil)
+			},
+			wantStdout: "would set times on \"file.txt\"\n",
+			wantErr:    nil,
+		},
+		{
+			name:     "stat error",
+			noCreate: false,
+			noDeref:  false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrPermission)
+			},
+			wantStdout: "",
+			wantErr:    os.ErrPermission,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mock fails the test on any Create or Chtimes call.
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			oldSetTimesNoDeref := platform.SetTimesNoDeref
+
+			defer func() { platform.SetTimesNoDeref = oldSetTimesNoDeref }()
+
+			platform.SetTimesNoDeref = func(file string, _, _ Time) error {
+				t.Errorf("SetTimesNoDeref(%q) called during a dry run", file)
+
+				return nil
+			}
+
+			// Capture stdout.
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			hookCalled := false
+			opts := Options{
+				DryRun: true,
+				AfterTouch: func(string) error {
+					hookCalled = true
+
+					return nil
+				},
+			}
+			err := TouchWithOptions("file.txt", ChAtime|ChMtime, tt.noCreate, tt.noDeref, stamp, stamp, opts)
+
+			w.Close()
+
+			os.Stdout = oldStdout
+
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TouchWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := buf.String(); got != tt.wantStdout {
+				t.Errorf("TouchWithOptions() stdout = %q, want %q", got, tt.wantStdout)
+			}
+
+			if hookCalled {
+				t.Error("TouchWithOptions() called AfterTouch during a dry run")
+			}
+		})
+	}
+}
+
+func TestTouch_noDerefDanglingSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks needs elevated privileges on Windows")
+	}
+
+	filesystem.Default = realFS
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "missing-target.txt")
+	link := filepath.Join(dir, "dangling.txt")
+
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	if err := Touch(link, ChAtime|ChMtime, false, true, stamp, stamp); err != nil {
+		t.Fatalf("Touch() error = %v", err)
+	}
+
+	linkInfo, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if linkInfo.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Touch() replaced the symlink with mode %v", linkInfo.Mode())
+	}
+
+	if !linkInfo.ModTime().Equal(stamp) {
+		t.Errorf("Touch() link mtime = %v, want %v", linkInfo.ModTime(), stamp)
+	}
+
+	if _, err := os.Lstat(target); !os.IsNotExist(err) {
+		t.Errorf("Touch() created the symlink target, Lstat error = %v", err)
+	}
+}
+
+func TestTouch_noDerefSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks needs elevated privileges on Windows")
+	}
+
+	filesystem.Default = realFS
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	link := filepath.Join(dir, "link.txt")
+	targetTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.Local)
+
+	if err := os.WriteFile(target, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(target, targetTime, targetTime); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	linkBefore, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	// Change only the mtime, so the preserved atime must come from the link, not the target.
+	if err := Touch(link, ChMtime, false, true, stamp, stamp); err != nil {
+		t.Fatalf("Touch() error = %v", err)
+	}
+
+	linkAfter, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !linkAfter.ModTime().Equal(stamp) {
+		t.Errorf("Touch() link mtime = %v, want %v", linkAfter.ModTime(), stamp)
+	}
+
+	if got, want := platform.GetAtime(linkAfter), platform.GetAtime(linkBefore); !got.Equal(want) {
+		t.Errorf("Touch() link atime = %v, want preserved %v", got, want)
+	}
+
+	targetInfo, err := os.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !targetInfo.ModTime().Equal(targetTime) {
+		t.Errorf("Touch() target mtime = %v, want unchanged %v", targetInfo.ModTime(), targetTime)
+	}
+
+	if got := platform.GetAtime(targetInfo); !got.Equal(targetTime) {
+		t.Errorf("Touch() target atime = %v, want unchanged %v", got, targetTime)
+	}
+}
+
+func TestTouchWithOptions_clampNewToNow(t *testing.T) {
+	fixedNow := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	future := fixedNow.Add(48 * time.Hour)
+	past := fixedNow.Add(-48 * time.Hour)
+	clock := ClockFunc(func() Time { return fixedNow })
+
+	tests := []struct {
+		name        string
+		atime       Time
+		mtime       Time
+		mockFSSetup func(*mocks.MockFS)
+	}{
+		{
+			name:  "new file with future time is clamped",
+			atime: future,
+			mtime: future,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "new.txt").Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", "new.txt").Return(&os.File{}, true, nil)
+				m.On("Chtimes", "new.txt", fixedNow, fixedNow).Return(nil)
+			},
+		},
+		{
+			name:  "new file with past time is kept",
+			atime: past,
+			mtime: future,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "new.txt").Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", "new.txt").Return(&os.File{}, true, nil)
+				m.On("Chtimes", "new.txt", past, fixedNow).Return(nil)
+			},
+		},
+		{
+			name:  "existing file keeps future time",
+			atime: future,
+			mtime: future,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "new.txt").Return(&mockFileInfo{mod: past}, nil)
+				m.On("Chtimes", "new.txt", future, future).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			err := TouchWithOptions(
+				"new.txt",
+				ChAtime|ChMtime,
+				false,
+				false,
+				tt.atime,
+				tt.mtime,
+				Options{ClampNewToNow: true, Clock: clock},
+			)
+			if err != nil {
+				t.Errorf("TouchWithOptions() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_createParents(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 0, 0, 0, time.Local)
+	file := filepath.Join("dir1", "dir2", "file.txt")
+	parent := filepath.Join("dir1", "dir2")
+	missingParent := fmt.Errorf("create %s: %w", file, os.ErrNotExist)
+
+	tests := []struct {
+		name          string
+		createParents bool
+		mockFSSetup   func(*mocks.MockFS)
+		wantErr       bool
+	}{
+		{
+			name:          "missing parents created",
+			createParents: true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", file).Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", file).Return(nil, false, missingParent).Once()
+				m.On("MkdirAll", parent, os.FileMode(0o755)).Return(nil)
+				m.On("OpenForCreate", file).Return(&os.File{}, true, nil).Once()
+				m.On("Chtimes", file, stamp, stamp).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:          "existing parent needs no directories",
+			createParents: true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", file).Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", file).Return(&os.File{}, true, nil).Once()
+				m.On("Chtimes", file, stamp, stamp).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:          "missing parents without the option",
+			createParents: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", file).Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", file).Return(nil, false, missingParent).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:          "other create errors are not retried",
+			createParents: true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", file).Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", file).Return(nil, false, os.ErrPermission).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name:          "parent creation failure",
+			createParents: true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", file).Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", file).Return(nil, false, missingParent).Once()
+				m.On("MkdirAll", parent, os.FileMode(0o755)).Return(os.ErrPermission)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			err := TouchWithOptions(
+				file,
+				ChAtime|ChMtime,
+				false,
+				false,
+				stamp,
+				stamp,
+				Options{CreateParents: tt.createParents},
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TouchWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// realFS holds the default filesystem, captured before any test swaps in a mock.
+var realFS = filesystem.Default
+
+// mockFileInfo is a simple mock for os.FileInfo in tests.
+type mockFileInfo struct {
+	mod  Time
+	mode os.FileMode
+}
+
+func (m mockFileInfo) Name() string      { return "" }
+func (m mockFileInfo) Size() int64       { return 0 }
+func (m mockFileInfo) Mode() os.FileMode { return m.mode }
+func (m mockFileInfo) ModTime() Time     { return m.mod }
+func (m mockFileInfo) IsDir() bool       { return false }
+func (m mockFileInfo) Sys() any          { return nil }
+
+func TestTouchWithOptions_directories(t *testing.T) {
+	filesystem.Default = realFS
+
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name          string
+		path          string
+		noCreate      bool
+		createParents bool
+		wantErr       error
+		wantParent    string
+	}{
+		{
+			name:          "existing directory is updated",
+			path:          "dir",
+			noCreate:      false,
+			createParents: false,
+			wantErr:       nil,
+			wantParent:    "",
+		},
+		{
+			name:          "parent is a file",
+			path:          filepath.Join("file.txt", "new.txt"),
+			noCreate:      false,
+			createParents: false,
+			wantErr:       touchErrors.ErrParentNotDirectory,
+			wantParent:    "file.txt",
+		},
+		{
+			name:          "grandparent is a file with parents",
+			path:          filepath.Join("file.txt", "sub", "new.txt"),
+			noCreate:      false,
+			createParents: true,
+			wantErr:       touchErrors.ErrParentNotDirectory,
+			wantParent:    "file.txt",
+		},
+		{
+			name:          "parent is a file without creating",
+			path:          filepath.Join("dir", "file.txt", "new.txt"),
+			noCreate:      true,
+			createParents: false,
+			wantErr:       touchErrors.ErrParentNotDirectory,
+			wantParent:    filepath.Join("dir", "file.txt"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr != nil && runtime.GOOS == "windows" {
+				t.Skip("Windows reports a missing path rather than ENOTDIR under a regular file")
+			}
+
+			root := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(root, "dir"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, name := range []string{"file.txt", filepath.Join("dir", "file.txt")} {
+				if err := os.WriteFile(filepath.Join(root, name), nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			path := filepath.Join(root, tt.path)
+
+			err := TouchWithOptions(
+				path,
+				ChAtime|ChMtime,
+				tt.noCreate,
+				false,
+				stamp,
+				stamp,
+				Options{CreateParents: tt.createParents},
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TouchWithOptions() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				wantParent := Quote(filepath.Join(root, tt.wantParent)) + " is a file"
+				if !strings.HasSuffix(err.Error(), wantParent) {
+					t.Errorf("TouchWithOptions() error = %v, want it to name %s", err, wantParent)
+				}
+
+				return
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !info.IsDir() || !info.ModTime().Equal(stamp) {
+				t.Errorf("TouchWithOptions() left directory with mtime %v, want %v", info.ModTime(), stamp)
+			}
+		})
+	}
+}
+
+func TestTouchCtx_cancelled(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), stamp)
+	defer cancelExpired()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context //nolint:containedctx // Each case supplies its own context.
+		opts    Options
+		wantErr error
+	}{
+		{
+			name:    "cancelled",
+			ctx:     cancelled,
+			opts:    Options{},
+			wantErr: context.Canceled,
+		},
+		{
+			name:    "deadline exceeded",
+			ctx:     expired,
+			opts:    Options{},
+			wantErr: context.DeadlineExceeded,
+		},
+		{
+			name:    "cancelled while preserving link times",
+			ctx:     cancelled,
+			opts:    Options{PreserveLinkTimes: true},
+			wantErr: context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mock fails the test on any file system call, including Chtimes.
+			filesystem.Default = mocks.NewMockFS(t)
+
+			err := TouchWithOptionsCtx(tt.ctx, "file.txt", ChAtime|ChMtime, false, false, stamp, stamp, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TouchWithOptionsCtx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			err = TouchCtx(tt.ctx, "file.txt", ChAtime|ChMtime, false, false, stamp, stamp)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TouchCtx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_onlyOlderThan(t *testing.T) {
+	cutoff := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		noCreate    bool
+		mockFSSetup func(*mocks.MockFS)
+		wantErr     error
+	}{
+		{
+			name:     "older file is touched",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: cutoff.Add(-time.Second)}, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "much older file is touched",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: cutoff.AddDate(-1, 0, 0)}, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "file modified at the cutoff is skipped",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: cutoff}, nil)
+			},
+			wantErr: touchErrors.ErrNotOlder,
+		},
+		{
+			name:     "newer file is skipped",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: cutoff.Add(time.Hour)}, nil)
+			},
+			wantErr: touchErrors.ErrNotOlder,
+		},
+		{
+			name:     "missing file is still created",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", "file.txt").Return(&os.File{}, true, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "missing file with no create",
+			noCreate: true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mock fails the test on any Chtimes call not set up, so skipped files stay untouched.
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			// A skipped file is reported with ErrNotOlder, so callers don't count it as touched.
+			opts := Options{OnlyOlderThan: cutoff}
+			err := TouchWithOptions("file.txt", ChAtime|ChMtime, tt.noCreate, false, stamp, stamp, opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TouchWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_birthTime(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+	past := stamp.Add(-48 * time.Hour)
+
+	type btimeCall struct {
+		file    string
+		btime   Time
+		noDeref bool
+	}
+
+	tests := []struct {
+		name        string
+		change      int
+		noDeref     bool
+		mockFSSetup func(*mocks.MockFS)
+		setErr      error
+		wantCalls   []btimeCall
+		wantErr     error
+	}{
+		{
+			name:   "existing file only changes birth time",
+			change: ChBtime,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: past}, nil)
+			},
+			wantCalls: []btimeCall{{file: "file.txt", btime: stamp}},
+		},
+		{
+			name:    "existing symlink under noDeref",
+			change:  ChBtime,
+			noDeref: true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Lstat", "file.txt").Return(&mockFileInfo{mod: past}, nil)
+			},
+			wantCalls: []btimeCall{{file: "file.txt", btime: stamp, noDeref: true}},
+		},
+		{
+			name:   "new file gets all times",
+			change: ChBtime,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
+				m.On("OpenForCreate", "file.txt").Return(&os.File{}, true, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(nil)
+			},
+			wantCalls: []btimeCall{{file: "file.txt", btime: stamp}},
+		},
+		{
+			name:   "access and modification times alone leave birth time",
+			change: ChAtime | ChMtime,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: past}, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(nil)
+			},
+			wantCalls: nil,
+		},
+		{
+			name:   "unsupported platform",
+			change: ChBtime,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: past}, nil)
+			},
+			setErr:    touchErrors.ErrBirthTimeUnsupported,
+			wantCalls: []btimeCall{{file: "file.txt", btime: stamp}},
+			wantErr:   touchErrors.ErrBirthTimeUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			var calls []btimeCall
+
+			oldSetBtime := platform.SetBtime
+
+			defer func() { platform.SetBtime = oldSetBtime }()
+
+			platform.SetBtime = func(file string, btime Time, noDeref bool) error {
+				calls = append(calls, btimeCall{file: file, btime: btime, noDeref: noDeref})
+
+				return tt.setErr
+			}
+
+			err := TouchWithOptions("file.txt", tt.change, false, tt.noDeref, stamp, stamp, Options{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TouchWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(calls, tt.wantCalls) {
+				t.Errorf("TouchWithOptions() SetBtime calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_adjust(t *testing.T) {
+	atime := time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local)
+	mtime := time.Date(2025, 7, 12, 9, 30, 0, 0, time.Local)
+	stamp := time.Date(2030, 1, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		adjust    time.Duration
+		change    int
+		exists    bool
+		noCreate  bool
+		wantAtime Time
+		wantMtime Time
+		wantErr   error
+	}{
+		{
+			name:      "forward one hour",
+			adjust:    time.Hour,
+			change:    ChAtime | ChMtime,
+			exists:    true,
+			wantAtime: atime.Add(time.Hour),
+			wantMtime: mtime.Add(time.Hour),
+		},
+		{
+			name:      "back two days",
+			adjust:    -48 * time.Hour,
+			change:    ChAtime | ChMtime,
+			exists:    true,
+			wantAtime: atime.Add(-48 * time.Hour),
+			wantMtime: mtime.Add(-48 * time.Hour),
+		},
+		{
+			name:      "access time only",
+			adjust:    -90 * time.Minute,
+			change:    ChAtime,
+			exists:    true,
+			wantAtime: atime.Add(-90 * time.Minute),
+			wantMtime: mtime,
+		},
+		{
+			name:      "modification time only",
+			adjust:    30 * time.Second,
+			change:    ChMtime,
+			exists:    true,
+			wantAtime: atime,
+			wantMtime: mtime.Add(30 * time.Second),
+		},
+		{
+			name:    "missing file",
+			adjust:  time.Hour,
+			change:  ChAtime | ChMtime,
+			exists:  false,
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name:     "missing file with no create",
+			adjust:   time.Hour,
+			change:   ChAtime | ChMtime,
+			exists:   false,
+			noCreate: true,
+			wantErr:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memFS := filesystem.NewMemFS()
+			filesystem.Default = memFS
+
+			if tt.exists {
+				newFile, err := memFS.Create("file.txt")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				newFile.Close()
+
+				if err := memFS.Chtimes("file.txt", atime, mtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// The given times are ignored in favor of the shifted existing ones.
+			err := TouchWithOptions("file.txt", tt.change, tt.noCreate, false, stamp, stamp, Options{Adjust: tt.adjust})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TouchWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			fileInfo, err := memFS.Stat("file.txt")
+			if !tt.exists {
+				if !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("TouchWithOptions() created the file, stat error = %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := platform.GetAtime(fileInfo); !got.Equal(tt.wantAtime) {
+				t.Errorf("TouchWithOptions() atime = %v, want %v", got, tt.wantAtime)
+			}
+
+			if got := fileInfo.ModTime(); !got.Equal(tt.wantMtime) {
+				t.Errorf("TouchWithOptions() mtime = %v, want %v", got, tt.wantMtime)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_forwardOnly(t *testing.T) {
+	atime := time.Date(2025, 7, 13, 10, 0, 0, 0, time.Local)
+	mtime := time.Date(2025, 7, 12, 9, 30, 0, 0, time.Local)
+	between := time.Date(2025, 7, 12, 18, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		change    int
+		atime     Time
+		mtime     Time
+		exists    bool
+		wantAtime Time
+		wantMtime Time
+	}{
+		{
+			name:      "atime would go back, mtime forward",
+			change:    ChAtime | ChMtime,
+			atime:     between,
+			mtime:     between,
+			exists:    true,
+			wantAtime: atime,
+			wantMtime: between,
+		},
+		{
+			name:      "atime forward, mtime would go back",
+			change:    ChAtime | ChMtime,
+			atime:     atime.Add(time.Hour),
+			mtime:     mtime.Add(-time.Hour),
+			exists:    true,
+			wantAtime: atime.Add(time.Hour),
+			wantMtime: mtime,
+		},
+		{
+			name:      "both would go back",
+			change:    ChAtime | ChMtime,
+			atime:     mtime.AddDate(-1, 0, 0),
+			mtime:     mtime.AddDate(-1, 0, 0),
+			exists:    true,
+			wantAtime: atime,
+			wantMtime: mtime,
+		},
+		{
+			name:      "equal times are kept",
+			change:    ChAtime | ChMtime,
+			atime:     atime,
+			mtime:     mtime,
+			exists:    true,
+			wantAtime: atime,
+			wantMtime: mtime,
+		},
+		{
+			name:      "modification time only",
+			change:    ChMtime,
+			atime:     between,
+			mtime:     between,
+			exists:    true,
+			wantAtime: atime,
+			wantMtime: between,
+		},
+		{
+			name:      "new file takes the given times",
+			change:    ChAtime | ChMtime,
+			atime:     mtime.AddDate(-1, 0, 0),
+			mtime:     mtime.AddDate(-1, 0, 0),
+			exists:    false,
+			wantAtime: mtime.AddDate(-1, 0, 0),
+			wantMtime: mtime.AddDate(-1, 0, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memFS := filesystem.NewMemFS()
+			filesystem.Default = memFS
+
+			if tt.exists {
+				newFile, err := memFS.Create("file.txt")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				newFile.Close()
+
+				if err := memFS.Chtimes("file.txt", atime, mtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			opts := Options{ForwardOnly: true}
+			if err := TouchWithOptions("file.txt", tt.change, false, false, tt.atime, tt.mtime, opts); err != nil {
+				t.Fatalf("TouchWithOptions() error = %v", err)
+			}
+
+			fileInfo, err := memFS.Stat("file.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := platform.GetAtime(fileInfo); !got.Equal(tt.wantAtime) {
+				t.Errorf("TouchWithOptions() atime = %v, want %v", got, tt.wantAtime)
+			}
+
+			if got := fileInfo.ModTime(); !got.Equal(tt.wantMtime) {
+				t.Errorf("TouchWithOptions() mtime = %v, want %v", got, tt.wantMtime)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_special(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+	mod := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		mode    os.FileMode
+		special bool
+		wantErr error
+	}{
+		{name: "regular file touched", mode: 0, special: false, wantErr: nil},
+		{
+			name:    "named pipe rejected",
+			mode:    os.ModeNamedPipe,
+			special: false,
+			wantErr: touchErrors.ErrSpecialFile,
+		},
+		{
+			name:    "socket rejected",
+			mode:    os.ModeSocket,
+			special: false,
+			wantErr: touchErrors.ErrSpecialFile,
+		},
+		{
+			name:    "block device rejected",
+			mode:    os.ModeDevice,
+			special: false,
+			wantErr: touchErrors.ErrSpecialFile,
+		},
+		{
+			name:    "character device rejected",
+			mode:    os.ModeDevice | os.ModeCharDevice,
+			special: false,
+			wantErr: touchErrors.ErrSpecialFile,
+		},
+		{
+			name:    "named pipe touched with special",
+			mode:    os.ModeNamedPipe,
+			special: true,
+			wantErr: nil,
+		},
+		{name: "device touched with special", mode: os.ModeDevice, special: true, wantErr: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mock fails the test on any Chtimes call not set up, so rejected files stay untouched.
+			mockFS := mocks.NewMockFS(t)
+			mockFS.On("Stat", "file").Return(&mockFileInfo{mod: mod, mode: tt.mode}, nil)
+
+			if tt.wantErr == nil {
+				mockFS.On("Chtimes", "file", stamp, stamp).Return(nil)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			opts := Options{Special: tt.special}
+
+			err := TouchWithOptions("file", ChAtime|ChMtime, false, false, stamp, stamp, opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TouchWithOptions() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTouchWithOptions_ifExists(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+	mod := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		noCreate    bool
+		mockFSSetup func(*mocks.MockFS)
+		wantErr     error
+	}{
+		{
+			name:     "existing file touched",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: mod}, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name:     "missing file not created",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
+			},
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name:     "missing file reported despite no create",
+			noCreate: true,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(nil, os.ErrNotExist)
+			},
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name:     "permission denied",
+			noCreate: false,
+			mockFSSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "file.txt").Return(&mockFileInfo{mod: mod}, nil)
+				m.On("Chtimes", "file.txt", stamp, stamp).Return(os.ErrPermission)
+			},
+			wantErr: os.ErrPermission,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The mock fails the test on any OpenForCreate call, so missing files stay uncreated.
+			mockFS := mocks.NewMockFS(t)
+			tt.mockFSSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+
+			opts := Options{IfExists: true}
+
+			err := TouchWithOptions("file.txt", ChAtime|ChMtime, tt.noCreate, false, stamp, stamp, opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TouchWithOptions() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -21,29 +21,303 @@ package errors
 
 import "errors"
 
+// ErrAdjustWithTimeSource indicates that --adjust was combined with a flag that sets absolute times.
+var ErrAdjustWithTimeSource = errors.New("--adjust cannot be combined with another time source")
+
+// ErrBirthTimeUnsupported indicates that setting birth (creation) times is not supported on the current platform.
+var ErrBirthTimeUnsupported = errors.New("setting birth time is not supported on this platform")
+
+// ErrBootTimeUnsupported indicates that reading the system boot time is not supported on the current platform.
+var ErrBootTimeUnsupported = errors.New("boot time reference is not supported on this platform")
+
+// ErrBucketWithoutHistogram indicates that --histogram-bucket was given without --histogram.
+var ErrBucketWithoutHistogram = errors.New("--histogram-bucket requires --histogram")
+
+// ErrBuildInfoKeyMissing indicates that a .buildinfo file does not contain the build time key.
+var ErrBuildInfoKeyMissing = errors.New("buildinfo key missing")
+
+// ErrCancelled indicates a run stopped part way through because its context was cancelled.
+var ErrCancelled = errors.New("cancelled")
+
+// ErrCtimeUnavailable indicates that a file's status change time cannot be read on the current platform.
+var ErrCtimeUnavailable = errors.New("change time is not available")
+
+// ErrDateLayoutMismatch indicates that a -d value does not match the layout given with --format.
+var ErrDateLayoutMismatch = errors.New("date does not match layout")
+
+// ErrDiffWithoutDryRun indicates that --diff was given without --dry-run.
+var ErrDiffWithoutDryRun = errors.New("--diff requires --dry-run")
+
+// ErrDirectoryNotEmpty indicates an attempt to remove a directory that still has entries.
+var ErrDirectoryNotEmpty = errors.New("directory not empty")
+
+// ErrEmptyFSImage indicates a --reference-image filesystem image with no members.
+var ErrEmptyFSImage = errors.New("filesystem image has no members")
+
+// ErrEmptyReferenceTree indicates that a reference directory contains no entries to take times from.
+var ErrEmptyReferenceTree = errors.New("reference directory tree is empty")
+
+// ErrEmptyRsyncList indicates an --rsync-list file that lists no entries.
+var ErrEmptyRsyncList = errors.New("rsync listing has no entries")
+
+// ErrFormatWithoutDate indicates that --format was given without a -d date to parse.
+var ErrFormatWithoutDate = errors.New("--format requires --date")
+
+// ErrFSTypeUnsupported indicates that detecting filesystem types is not supported on the current platform.
+var ErrFSTypeUnsupported = errors.New("filesystem type detection is not supported on this platform")
+
+// ErrInvalidAdjustment indicates an --adjust value that is not a non-zero duration or signed offset.
+var ErrInvalidAdjustment = errors.New("invalid adjustment, want a non-zero duration such as +1h or -2 days")
+
+// ErrInvalidAge indicates a file age threshold that isn't a positive duration.
+var ErrInvalidAge = errors.New("invalid age, want a positive duration such as 36h or 7d")
+
+// ErrInvalidBusReply indicates that a reply from systemd over D-Bus could not be parsed.
+var ErrInvalidBusReply = errors.New("invalid D-Bus reply")
+
+// ErrInvalidCron indicates a malformed 5-field cron expression.
+var ErrInvalidCron = errors.New("invalid cron expression")
+
 // ErrInvalidDateTimeValues indicates that the provided date or time components are out of valid ranges.
 var ErrInvalidDateTimeValues = errors.New("invalid date or time values")
 
+// ErrInvalidHistogramBucket indicates a --histogram-bucket width that isn't positive.
+var ErrInvalidHistogramBucket = errors.New("histogram bucket must be a positive duration")
+
+// ErrInvalidImage indicates a tarball that isn't a valid Docker or OCI image archive.
+var ErrInvalidImage = errors.New("invalid image tarball")
+
+// ErrInvalidJobs indicates a negative --jobs value.
+var ErrInvalidJobs = errors.New("invalid number of jobs, want 0 or more")
+
+// ErrInvalidJSONLRecord indicates that a --jsonl-times line is not a valid times record.
+var ErrInvalidJSONLRecord = errors.New("invalid JSON Lines times record")
+
+// ErrInvalidLockfile indicates a --lockfile that isn't a JSON object.
+var ErrInvalidLockfile = errors.New("invalid lockfile, want a JSON object")
+
+// ErrInvalidLockTime indicates a lockfile time field that is neither a date string nor epoch seconds.
+var ErrInvalidLockTime = errors.New("lockfile field is not a time")
+
+// ErrInvalidMinAfter indicates a --reference-min-after floor that isn't an RFC3339 time.
+var ErrInvalidMinAfter = errors.New("invalid --reference-min-after time, want RFC3339")
+
+// ErrInvalidPercentile indicates a percentile that is not a number from 0 to 100.
+var ErrInvalidPercentile = errors.New("invalid percentile, want a number from 0 to 100")
+
+// ErrInvalidPID indicates a process ID that isn't a positive integer.
+var ErrInvalidPID = errors.New("invalid process ID")
+
 // ErrInvalidPosixLength indicates that the POSIX timestamp string has an invalid length.
 var ErrInvalidPosixLength = errors.New("invalid POSIX timestamp length")
 
+// ErrInvalidReduction indicates that the --reduce flag received an unsupported reduction.
+var ErrInvalidReduction = errors.New("invalid reduction")
+
+// ErrInvalidRemoteStat indicates that a remote host returned unexpected stat output.
+var ErrInvalidRemoteStat = errors.New("invalid remote stat output")
+
+// ErrInvalidRsyncList indicates an --rsync-list line that isn't in rsync's --list-only format.
+var ErrInvalidRsyncList = errors.New("invalid rsync listing")
+
 // ErrInvalidSeconds indicates that the seconds component in a POSIX timestamp is invalid.
 var ErrInvalidSeconds = errors.New("invalid seconds value")
 
+// ErrInvalidSeedWindow indicates that the --seed-window flag is not a valid START,END range.
+var ErrInvalidSeedWindow = errors.New("invalid seed window")
+
+// ErrInvalidSidecar indicates that a --time-sidecars file does not contain a valid RFC3339 time.
+var ErrInvalidSidecar = errors.New("invalid time sidecar")
+
+// ErrInvalidSizeMax indicates that --size-time was given without a positive --size-max.
+var ErrInvalidSizeMax = errors.New("--size-time requires a positive --size-max")
+
+// ErrInvalidSizeWindow indicates that the --size-window flag is not a valid START,END range.
+var ErrInvalidSizeWindow = errors.New("invalid size window")
+
+// ErrInvalidSSHReference indicates that a --reference-ssh value is not of the form [user@]host:path.
+var ErrInvalidSSHReference = errors.New("invalid SSH reference, want [user@]host:path")
+
+// ErrInvalidStatTime indicates a --stat-time expression that could not be parsed.
+var ErrInvalidStatTime = errors.New("invalid --stat-time expression")
+
 // ErrInvalidTimeArg indicates that the --time flag received an invalid argument.
 var ErrInvalidTimeArg = errors.New("invalid time argument")
 
+// ErrInvalidTimezone indicates a --timezone value that isn't a known IANA time zone name.
+var ErrInvalidTimezone = errors.New("invalid time zone")
+
+// ErrInvalidTruncation indicates an unknown --truncate-to unit.
+var ErrInvalidTruncation = errors.New("invalid truncation unit, want day, hour, or minute")
+
+// ErrInvalidUptime indicates that the system uptime could not be parsed.
+var ErrInvalidUptime = errors.New("invalid uptime")
+
+// ErrInvalidVersionFormat indicates a --version-format other than text or json.
+var ErrInvalidVersionFormat = errors.New("invalid version format, want text or json")
+
+// ErrInvalidWARC indicates a malformed WARC archive record.
+var ErrInvalidWARC = errors.New("invalid WARC record")
+
+// ErrIsDirectory indicates a file operation on a path that names a directory.
+var ErrIsDirectory = errors.New("is a directory")
+
+// ErrJournalUnsupported indicates that writing to the system journal is not supported on the current platform.
+var ErrJournalUnsupported = errors.New("system journal is not supported on this platform")
+
+// ErrLockEntryNotFound indicates that a lockfile has no entry named by --lock-entry.
+var ErrLockEntryNotFound = errors.New("lockfile entry not found")
+
+// ErrLockFieldNotFound indicates that a lockfile entry has no field at the --lock-path.
+var ErrLockFieldNotFound = errors.New("lockfile entry has no field at path")
+
+// ErrLockfileWithoutEntry indicates that only one of --lockfile and --lock-entry was given.
+var ErrLockfileWithoutEntry = errors.New("--lockfile and --lock-entry must be used together")
+
+// ErrLockPathWithoutLockfile indicates that --lock-path was given without --lockfile.
+var ErrLockPathWithoutLockfile = errors.New("--lock-path requires --lockfile")
+
+// ErrMinAfterWithoutGlob indicates that --reference-min-after was given without --reference-glob.
+var ErrMinAfterWithoutGlob = errors.New("--reference-min-after requires --reference-glob")
+
 // ErrMissingOperands indicates that no files were provided as arguments when required.
 var ErrMissingOperands = errors.New("missing operands")
 
+// ErrMountPointNotFound indicates that no mount point containing the given path was found.
+var ErrMountPointNotFound = errors.New("mount point not found")
+
+// ErrMountTimeUnsupported indicates that reading a filesystem's mount time is not supported on the current platform.
+var ErrMountTimeUnsupported = errors.New("mount time reference is not supported on this platform")
+
+// ErrMultipleContentSources indicates that both --content and --content-file were specified.
+var ErrMultipleContentSources = errors.New("--content and --content-file are mutually exclusive")
+
+// ErrMultipleStdinReaders indicates that more than one option was asked to read standard input.
+var ErrMultipleStdinReaders = errors.New("only one of --files-from, --content-file, and --jsonl-times can read standard input")
+
 // ErrMultipleTimeSources indicates that multiple time source flags (-r, -t, -d) were specified simultaneously.
 var ErrMultipleTimeSources = errors.New("multiple time sources specified")
 
+// ErrNewestTypeWithoutDir indicates that only one of --reference-newest-type and --dir was given.
+var ErrNewestTypeWithoutDir = errors.New("--reference-newest-type and --dir must be used together")
+
+// ErrNoContentTypeMatches indicates that no file of the requested content type was found.
+var ErrNoContentTypeMatches = errors.New("no files of content type")
+
+// ErrNoCronOccurrence indicates a cron schedule that never fires within the search window.
+var ErrNoCronOccurrence = errors.New("cron schedule has no occurrence")
+
 // ErrNoDerefUnsupported indicates that the --no-dereference option is not supported on the current platform.
 var ErrNoDerefUnsupported = errors.New("no-dereference is not supported on this platform")
 
+// ErrNoExistingAncestor indicates that no directory above a path exists to take times from.
+var ErrNoExistingAncestor = errors.New("no existing ancestor directory")
+
+// ErrNoGitHistory indicates that no commit touches the path given to --reference-git-newest.
+var ErrNoGitHistory = errors.New("no git history for path")
+
+// ErrNoGlobMatches indicates that a reference glob matched no files.
+var ErrNoGlobMatches = errors.New("no files match reference glob")
+
+// ErrNoOpenFiles indicates a process with no open file descriptors referring to files.
+var ErrNoOpenFiles = errors.New("process has no open files")
+
+// ErrNoReferenceTimes indicates that a reduction was requested over an empty set of reference times.
+var ErrNoReferenceTimes = errors.New("no reference times to reduce")
+
+// ErrNotDirectory indicates a path whose parent names a regular file rather than a directory.
+var ErrNotDirectory = errors.New("not a directory")
+
+// ErrNotOlder indicates an existing file left untouched as it was modified at or after the
+// --only-older-than cutoff.
+var ErrNotOlder = errors.New("modified too recently")
+
+// ErrNoTimesAfterFloor indicates that none of the reference times is later than the requested floor.
+var ErrNoTimesAfterFloor = errors.New("no reference time is after the floor")
+
+// ErrNotOSBacked indicates a file that can't be opened as an operating system file.
+var ErrNotOSBacked = errors.New("file system is not backed by the operating system")
+
+// ErrNotTimeUUID indicates a name that is not a canonical UUIDv1 or UUIDv7 with an embedded time.
+var ErrNotTimeUUID = errors.New("not a UUIDv1 or UUIDv7")
+
+// ErrOperandsWithJSONL indicates that file operands were given together with --jsonl-times.
+var ErrOperandsWithJSONL = errors.New("--jsonl-times does not take file operands")
+
+// ErrParentNotDirectory indicates a file that can't be created because one of its parents is a regular file.
+var ErrParentNotDirectory = errors.New("parent is not a directory")
+
+// ErrPartialFailure indicates that some files were touched while others failed.
+var ErrPartialFailure = errors.New("some files failed while others were touched")
+
+// ErrPercentileWithoutGlob indicates that only one of --reference-percentile and --reference-glob was given.
+var ErrPercentileWithoutGlob = errors.New("--reference-percentile and --reference-glob must be used together")
+
+// ErrPreferBirthWithoutReference indicates that --prefer-birth was given without a single --reference.
+var ErrPreferBirthWithoutReference = errors.New("--prefer-birth requires a single --reference")
+
 // ErrProcessingFiles indicates that errors occurred while processing one or more files.
 var ErrProcessingFiles = errors.New("errors occurred while processing files")
 
+// ErrProcFDsUnsupported indicates that reading a process's open files is not supported on the current platform.
+var ErrProcFDsUnsupported = errors.New("process open files reference is not supported on this platform")
+
+// ErrReadOnlyFS indicates a write to a read-only file system.
+var ErrReadOnlyFS = errors.New("read-only filesystem")
+
+// ErrReduceWithoutReference indicates that --reduce was given without --reference.
+var ErrReduceWithoutReference = errors.New("--reduce requires --reference")
+
+// ErrRollbackFailed indicates that --atomic could not restore every file after a failure.
+var ErrRollbackFailed = errors.New("failed to roll back touched files")
+
+// ErrSeedWindowWithoutSeed indicates that --seed-window was given without --reference-seed.
+var ErrSeedWindowWithoutSeed = errors.New("--seed-window requires --reference-seed")
+
+// ErrSequentialWithJobs indicates that --sequential was combined with more than one --jobs.
+var ErrSequentialWithJobs = errors.New("--sequential can't be combined with more than one job")
+
+// ErrSetTimeConflict indicates that -a, -m, or --time selects different times than --set-atime and --set-mtime.
+var ErrSetTimeConflict = errors.New("-a, -m, and --time must select the same times as --set-atime and --set-mtime")
+
+// ErrSizeOptionsWithoutSizeTime indicates that --size-window or --size-max was given without --size-time.
+var ErrSizeOptionsWithoutSizeTime = errors.New("--size-window and --size-max require --size-time")
+
+// ErrSpecialFile indicates a FIFO, socket, or device file left untouched because --special was not given.
+var ErrSpecialFile = errors.New("is a FIFO, socket, or device file")
+
+// ErrSplitReferenceUnpaired indicates that only one of --reference-atime and --reference-mtime was given.
+var ErrSplitReferenceUnpaired = errors.New("--reference-atime and --reference-mtime must be used together")
+
+// ErrStatTimeArithmetic indicates that evaluating a --stat-time expression overflowed or divided by zero.
+var ErrStatTimeArithmetic = errors.New("--stat-time arithmetic error")
+
+// ErrSwapWithoutReference indicates that --swap was given without --reference.
+var ErrSwapWithoutReference = errors.New("--swap requires --reference")
+
+// ErrTimeProbeMismatch indicates that a filesystem didn't keep a probe time close enough to detect its granularity.
+var ErrTimeProbeMismatch = errors.New("filesystem did not keep the probe time")
+
+// ErrTimezoneWithUTC indicates that --timezone and --utc were given together.
+var ErrTimezoneWithUTC = errors.New("--timezone cannot be combined with --utc")
+
+// ErrUnitNeverActive indicates that a systemd unit has no ActiveEnterTimestamp as it hasn't been active since boot.
+var ErrUnitNeverActive = errors.New("unit has not been active since boot")
+
+// ErrUnitReferenceUnsupported indicates that querying systemd units is not supported on the current platform.
+var ErrUnitReferenceUnsupported = errors.New("systemd unit reference is not supported on this platform")
+
+// ErrUnknownUnit indicates that systemd has no loaded unit of the given name.
+var ErrUnknownUnit = errors.New("unknown systemd unit")
+
 // ErrUnsupportedDateFormat indicates that the provided date string does not match any supported format.
 var ErrUnsupportedDateFormat = errors.New("unsupported date format")
+
+// ErrUnsupportedFSImage indicates a --reference-image file that isn't a squashfs or erofs image.
+var ErrUnsupportedFSImage = errors.New("unsupported filesystem image, want squashfs or erofs")
+
+// ErrWARCRecordNotFound indicates that a WARC archive has no record for the target URI.
+var ErrWARCRecordNotFound = errors.New("no WARC record for target URI")
+
+// ErrWARCWithoutTarget indicates that only one of --warc and --warc-target was given.
+var ErrWARCWithoutTarget = errors.New("--warc and --warc-target must be used together")
@@ -1,11 +1,16 @@
 // Package filesystem defines the FS interface for abstracting file system operations,
 // allowing for testability and modularity in file interactions. It provides a default
 // implementation using the os package and supports operations like retrieving file info
-// (Stat/Lstat), creating files, and changing timestamps (Chtimes).
+// (Stat/Lstat), creating and opening files, changing timestamps (Chtimes), creating
+// directories (MkdirAll), removing files (Remove), walking directory trees (WalkDir), and
+// matching names against patterns (Glob).
 //
 // Main Components:
-// - FS: Interface for file system operations, including Stat, Lstat, Create, and Chtimes.
+// - FS: Interface for file system operations, including Stat, Lstat, Create, OpenForCreate, Open, OpenFile, Chtimes, MkdirAll, Remove, WalkDir, and Glob.
+// - File: The handle Create and OpenForCreate return for writing, implemented by *os.File.
 // - Default: The default FS implementation using standard os functions.
+// - MemFS: A stateful, concurrency-safe in-memory FS that stores access and modification times and content.
+// - NewFromFS: Adapts a read-only io/fs.FS (such as an embed.FS or fstest.MapFS) to FS.
 //
 // This package is used by the core package to perform file operations in a way that
 // can be mocked during testing. It wraps os functions with error formatting for consistency.
@@ -19,8 +19,12 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package filesystem
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"time"
 )
 
@@ -35,12 +39,39 @@ type FS interface {
 	Lstat(
 		path string,
 	) (info os.FileInfo, err error) // Retrieves file info without following path symlinks.
-	Create(path string) (file *os.File, err error) // Creates a new file at path.
+	Create(path string) (file File, err error) // Creates a new file at path.
+	OpenForCreate(
+		path string,
+	) (file File, created bool, err error) // Opens path for writing, creating it if missing but never truncating it; created reports whether this call made it.
+	Open(path string) (file *os.File, err error) // Opens path for reading.
+	OpenFile(
+		path string,
+		flag int,
+		perm os.FileMode,
+	) (file *os.File, err error) // Opens path with the given flags, creating it with perm if requested.
 	Chtimes(
 		path string,
 		atime Time,
 		mtime Time,
 	) error // Changes path's access and mod times, following symlinks.
+	MkdirAll(
+		path string,
+		perm os.FileMode,
+	) error // Creates path and any missing parents with perm.
+	Remove(path string) error // Removes the file or empty directory at path.
+	WalkDir(
+		root string,
+		fn fs.WalkDirFunc,
+	) error // Walks the tree rooted at root, calling fn for each entry without following symlinks.
+	Glob(
+		pattern string,
+	) (matches []string, err error) // Returns the sorted names matching pattern, as filepath.Glob does.
+}
+
+// File is a file opened for writing by Create or OpenForCreate. *os.File implements it.
+type File interface {
+	io.WriteCloser
+	Stat() (info os.FileInfo, err error) // Retrieves file info for the open file.
 }
 
 // defaultFS is the default implementation using os package functions.
@@ -49,6 +80,9 @@ type defaultFS struct{}
 // Default is the default file system implementation, using standard os functions.
 var Default FS = defaultFS{}
 
+// newFilePerm is the mode OpenForCreate creates files with before the umask, as with os.Create.
+const newFilePerm = 0o666
+
 // Stat implements FS.Stat using os.Stat.
 func (defaultFS) Stat(path string) (os.FileInfo, error) {
 	info, err := os.Stat(path)
@@ -70,7 +104,7 @@ func (defaultFS) Lstat(path string) (os.FileInfo, error) {
 }
 
 // Create implements FS.Create using os.Create.
-func (defaultFS) Create(path string) (*os.File, error) {
+func (defaultFS) Create(path string) (File, error) {
 	file, err := os.Create(path)
 	if err != nil {
 		return nil, fmt.Errorf("create %s: %w", path, err)
@@ -79,6 +113,46 @@ func (defaultFS) Create(path string) (*os.File, error) {
 	return file, nil
 }
 
+// OpenForCreate implements FS.OpenForCreate using os.OpenFile with O_CREATE and O_EXCL, so
+// created is only set for a file this call made. A file created by someone else since it was
+// last checked is then opened without O_TRUNC, keeping its contents.
+func (defaultFS) OpenForCreate(path string) (File, bool, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, newFilePerm)
+	if err == nil {
+		return file, true, nil
+	}
+
+	// A dangling symlink also exists for O_EXCL; opening it without O_EXCL creates its target.
+	if errors.Is(err, fs.ErrExist) {
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY, newFilePerm)
+		if err == nil {
+			return file, false, nil
+		}
+	}
+
+	return nil, false, fmt.Errorf("create %s: %w", path, err)
+}
+
+// Open implements FS.Open using os.Open.
+func (defaultFS) Open(path string) (*os.File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// OpenFile implements FS.OpenFile using os.OpenFile.
+func (defaultFS) OpenFile(path string, flag int, perm os.FileMode) (*os.File, error) {
+	file, err := os.OpenFile(path, flag, perm)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
 // Chtimes implements FS.Chtimes using os.Chtimes.
 func (defaultFS) Chtimes(path string, atime Time, mtime Time) error {
 	if err := os.Chtimes(path, atime, mtime); err != nil {
@@ -87,3 +161,40 @@ func (defaultFS) Chtimes(path string, atime Time, mtime Time) error {
 
 	return nil
 }
+
+// MkdirAll implements FS.MkdirAll using os.MkdirAll.
+func (defaultFS) MkdirAll(path string, perm os.FileMode) error {
+	if err := os.MkdirAll(path, perm); err != nil {
+		return fmt.Errorf("mkdir %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Remove implements FS.Remove using os.Remove.
+func (defaultFS) Remove(path string) error {
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("remove %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// WalkDir implements FS.WalkDir using filepath.WalkDir.
+func (defaultFS) WalkDir(root string, fn fs.WalkDirFunc) error {
+	if err := filepath.WalkDir(root, fn); err != nil {
+		return fmt.Errorf("walk %s: %w", root, err)
+	}
+
+	return nil
+}
+
+// Glob implements FS.Glob using filepath.Glob.
+func (defaultFS) Glob(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("glob %s: %w", pattern, err)
+	}
+
+	return matches, nil
+}
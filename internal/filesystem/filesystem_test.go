@@ -19,8 +19,12 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package filesystem
 
 import (
+	"errors"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 )
@@ -179,6 +183,186 @@ func Test_defaultFS_Create(t *testing.T) {
 	}
 }
 
+func Test_defaultFS_OpenForCreate(t *testing.T) {
+	root := t.TempDir()
+
+	existing := filepath.Join(root, "existing.txt")
+	if err := os.WriteFile(existing, []byte("original"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		d           defaultFS
+		path        string
+		wantContent string
+		wantCreated bool
+		wantErr     bool
+	}{
+		{
+			name:        "creates missing file",
+			d:           defaultFS{},
+			path:        filepath.Join(root, "new.txt"),
+			wantContent: "",
+			wantCreated: true,
+			wantErr:     false,
+		},
+		{
+			name:        "keeps existing contents",
+			d:           defaultFS{},
+			path:        existing,
+			wantContent: "original",
+			wantCreated: false,
+			wantErr:     false,
+		},
+		{
+			name:        "missing parent",
+			d:           defaultFS{},
+			path:        filepath.Join(root, "missing", "new.txt"),
+			wantContent: "",
+			wantCreated: false,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, created, err := tt.d.OpenForCreate(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("defaultFS.OpenForCreate() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if created != tt.wantCreated {
+				t.Errorf("defaultFS.OpenForCreate() created = %v, want %v", created, tt.wantCreated)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			file.Close()
+
+			data, err := os.ReadFile(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != tt.wantContent {
+				t.Errorf("defaultFS.OpenForCreate() content = %q, want %q", data, tt.wantContent)
+			}
+		})
+	}
+}
+
+func Test_defaultFS_Open(t *testing.T) {
+	existing := filepath.Join(t.TempDir(), "test_open.txt")
+	if err := os.WriteFile(existing, []byte("content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		d       defaultFS
+		path    string
+		wantErr bool
+	}{
+		{
+			name:    "existing file",
+			d:       defaultFS{},
+			path:    existing,
+			wantErr: false,
+		},
+		{
+			name:    "missing file",
+			d:       defaultFS{},
+			path:    filepath.Join(t.TempDir(), "missing.txt"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.Open(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("defaultFS.Open() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got == nil {
+				return
+			}
+
+			defer got.Close()
+
+			content, err := io.ReadAll(got)
+			if err != nil {
+				t.Fatalf("read opened file: %v", err)
+			}
+
+			if string(content) != "content" {
+				t.Errorf("defaultFS.Open() content = %q, want %q", content, "content")
+			}
+		})
+	}
+}
+
+func Test_defaultFS_OpenFile(t *testing.T) {
+	type args struct {
+		path string
+		flag int
+		perm os.FileMode
+	}
+
+	tests := []struct {
+		name    string
+		d       defaultFS
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "append creates missing file",
+			d:    defaultFS{},
+			args: args{
+				path: filepath.Join(t.TempDir(), "test_open.txt"),
+				flag: os.O_APPEND | os.O_CREATE | os.O_WRONLY,
+				perm: 0o644,
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing file without create",
+			d:    defaultFS{},
+			args: args{
+				path: filepath.Join(t.TempDir(), "missing.txt"),
+				flag: os.O_WRONLY,
+				perm: 0o644,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.OpenFile(tt.args.path, tt.args.flag, tt.args.perm)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("defaultFS.OpenFile() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got != nil {
+				got.Close()
+			}
+
+			if !tt.wantErr {
+				if _, statErr := os.Stat(tt.args.path); statErr != nil {
+					t.Errorf("defaultFS.OpenFile() file should exist but stat failed: %v", statErr)
+				}
+			}
+		})
+	}
+}
+
 func Test_defaultFS_Chtimes(t *testing.T) {
 	type args struct {
 		path  string
@@ -262,3 +446,225 @@ func Test_defaultFS_Chtimes(t *testing.T) {
 		})
 	}
 }
+
+func Test_defaultFS_MkdirAll(t *testing.T) {
+	root := t.TempDir()
+
+	blocker := filepath.Join(root, "file.txt")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		d       defaultFS
+		path    string
+		wantErr bool
+	}{
+		{
+			name:    "creates nested directories",
+			d:       defaultFS{},
+			path:    filepath.Join(root, "a", "b", "c"),
+			wantErr: false,
+		},
+		{
+			name:    "existing directory",
+			d:       defaultFS{},
+			path:    root,
+			wantErr: false,
+		},
+		{
+			name:    "file in the way",
+			d:       defaultFS{},
+			path:    filepath.Join(blocker, "sub"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.d.MkdirAll(tt.path, 0o755)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("defaultFS.MkdirAll() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr {
+				if info, statErr := os.Stat(tt.path); statErr != nil || !info.IsDir() {
+					t.Errorf("defaultFS.MkdirAll() directory should exist: %v", statErr)
+				}
+			}
+		})
+	}
+}
+
+func Test_defaultFS_Remove(t *testing.T) {
+	root := t.TempDir()
+
+	file := filepath.Join(root, "file.txt")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	full := filepath.Join(root, "full")
+	if err := os.MkdirAll(filepath.Join(full, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		d       defaultFS
+		path    string
+		wantErr bool
+	}{
+		{
+			name:    "removes file",
+			d:       defaultFS{},
+			path:    file,
+			wantErr: false,
+		},
+		{
+			name:    "missing file",
+			d:       defaultFS{},
+			path:    filepath.Join(root, "missing.txt"),
+			wantErr: true,
+		},
+		{
+			name:    "non-empty directory",
+			d:       defaultFS{},
+			path:    full,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.d.Remove(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("defaultFS.Remove() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !tt.wantErr {
+				if _, statErr := os.Stat(tt.path); !errors.Is(statErr, os.ErrNotExist) {
+					t.Errorf("defaultFS.Remove() path should be gone: %v", statErr)
+				}
+			}
+		})
+	}
+}
+
+func Test_defaultFS_WalkDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a.txt", filepath.Join("sub", "b.txt")} {
+		if err := os.WriteFile(filepath.Join(root, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		d       defaultFS
+		root    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "walks every entry",
+			d:    defaultFS{},
+			root: root,
+			want: []string{
+				root,
+				filepath.Join(root, "a.txt"),
+				filepath.Join(root, "sub"),
+				filepath.Join(root, "sub", "b.txt"),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "missing root",
+			d:       defaultFS{},
+			root:    filepath.Join(root, "missing"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+
+			err := tt.d.WalkDir(tt.root, func(path string, _ fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+
+				got = append(got, path)
+
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("defaultFS.WalkDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("defaultFS.WalkDir() visited %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_defaultFS_Glob(t *testing.T) {
+	root := t.TempDir()
+
+	for _, name := range []string{"a.log", "b.log", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(root, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		d       defaultFS
+		pattern string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "matches",
+			d:       defaultFS{},
+			pattern: filepath.Join(root, "*.log"),
+			want:    []string{filepath.Join(root, "a.log"), filepath.Join(root, "b.log")},
+			wantErr: false,
+		},
+		{
+			name:    "no matches",
+			d:       defaultFS{},
+			pattern: filepath.Join(root, "*.md"),
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "malformed pattern",
+			d:       defaultFS{},
+			pattern: filepath.Join(root, "["),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.Glob(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("defaultFS.Glob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("defaultFS.Glob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package filesystem defines the FS interface and its default implementation for file operations.
+// This file adapts a read-only io/fs.FS to the FS interface.
+package filesystem
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// iofsFS adapts an fs.FS to FS for read operations.
+type iofsFS struct {
+	fsys fs.FS
+}
+
+// NewFromFS returns an FS that reads from fsys, such as an embedded, overlay, or
+// fstest.MapFS file system. Paths are made slash-separated and relative to the root of
+// fsys, so "/a/b" and "a/b" name the same file.
+//
+// Limitations:
+//   - Lstat describes symlinks only if fsys implements fs.ReadLinkFS; otherwise it follows them like Stat.
+//   - Create, Chtimes, and MkdirAll fail with errors.ErrReadOnlyFS, as does OpenFile.
+//   - Open fails with errors.ErrNotOSBacked, because fs.FS files aren't *os.File values.
+//   - WalkDir passes paths relative to the root of fsys to its callback.
+func NewFromFS(fsys fs.FS) FS {
+	return iofsFS{fsys: fsys}
+}
+
+// Stat implements FS.Stat using fs.Stat.
+func (f iofsFS) Stat(name string) (os.FileInfo, error) {
+	info, err := fs.Stat(f.fsys, fsPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", name, err)
+	}
+
+	return info, nil
+}
+
+// Lstat implements FS.Lstat using fs.Lstat, which falls back to fs.Stat when fsys
+// can't report on symlinks themselves.
+func (f iofsFS) Lstat(name string) (os.FileInfo, error) {
+	info, err := fs.Lstat(f.fsys, fsPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("lstat %s: %w", name, err)
+	}
+
+	return info, nil
+}
+
+// Create implements FS.Create, which always fails on the read-only fs.FS.
+func (iofsFS) Create(name string) (File, error) {
+	return nil, fmt.Errorf("create %s: %w", name, errors.ErrReadOnlyFS)
+}
+
+// OpenForCreate implements FS.OpenForCreate, which always fails on the read-only fs.FS.
+func (iofsFS) OpenForCreate(name string) (File, bool, error) {
+	return nil, false, fmt.Errorf("create %s: %w", name, errors.ErrReadOnlyFS)
+}
+
+// Open implements FS.Open, which always fails as fs.FS files aren't *os.File values.
+func (iofsFS) Open(name string) (*os.File, error) {
+	return nil, fmt.Errorf("open %s: %w", name, errors.ErrNotOSBacked)
+}
+
+// OpenFile implements FS.OpenFile, which always fails on the read-only fs.FS.
+func (iofsFS) OpenFile(name string, _ int, _ os.FileMode) (*os.File, error) {
+	return nil, fmt.Errorf("open %s: %w", name, errors.ErrReadOnlyFS)
+}
+
+// Chtimes implements FS.Chtimes, which always fails on the read-only fs.FS.
+func (iofsFS) Chtimes(name string, _ Time, _ Time) error {
+	return fmt.Errorf("chtimes %s: %w", name, errors.ErrReadOnlyFS)
+}
+
+// MkdirAll implements FS.MkdirAll, which always fails on the read-only fs.FS.
+func (iofsFS) MkdirAll(name string, _ os.FileMode) error {
+	return fmt.Errorf("mkdir %s: %w", name, errors.ErrReadOnlyFS)
+}
+
+// Remove implements FS.Remove, which always fails on the read-only fs.FS.
+func (iofsFS) Remove(name string) error {
+	return fmt.Errorf("remove %s: %w", name, errors.ErrReadOnlyFS)
+}
+
+// WalkDir implements FS.WalkDir using fs.WalkDir.
+func (f iofsFS) WalkDir(root string, fn fs.WalkDirFunc) error {
+	if err := fs.WalkDir(f.fsys, fsPath(root), fn); err != nil {
+		return fmt.Errorf("walk %s: %w", root, err)
+	}
+
+	return nil
+}
+
+// Glob implements FS.Glob using fs.Glob.
+func (f iofsFS) Glob(pattern string) ([]string, error) {
+	matches, err := fs.Glob(f.fsys, fsPath(pattern))
+	if err != nil {
+		return nil, fmt.Errorf("glob %s: %w", pattern, err)
+	}
+
+	return matches, nil
+}
+
+// fsPath converts an operating system path to the unrooted, slash-separated form
+// fs.FS expects, with the root itself as ".".
+func fsPath(name string) string {
+	name = strings.TrimLeft(path.Clean(filepath.ToSlash(name)), "/")
+	if name == "" {
+		return "."
+	}
+
+	return name
+}
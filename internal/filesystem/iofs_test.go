@@ -0,0 +1,366 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package filesystem defines the FS interface and its default implementation for file operations.
+// This file adapts a read-only io/fs.FS to the FS interface.
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// testMapFS is a small tree with a file, a nested file, and a symlink to the file.
+func testMapFS() fstest.MapFS {
+	return fstest.MapFS{
+		"file.txt":     {Data: []byte("content"), ModTime: time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC)},
+		"dir/nested":   {Data: []byte("nested"), ModTime: time.Date(2025, 7, 14, 12, 0, 0, 0, time.UTC)},
+		"link.txt":     {Data: []byte("file.txt"), Mode: fs.ModeSymlink},
+		"dangling.txt": {Data: []byte("missing.txt"), Mode: fs.ModeSymlink},
+	}
+}
+
+func Test_iofsFS_Stat(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		wantMod  time.Time
+		wantDir  bool
+		wantErr  error
+		wantSize int64
+	}{
+		{
+			name:     "file",
+			path:     "file.txt",
+			wantMod:  time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC),
+			wantDir:  false,
+			wantErr:  nil,
+			wantSize: 7,
+		},
+		{
+			name:     "rooted path",
+			path:     "/dir/./nested",
+			wantMod:  time.Date(2025, 7, 14, 12, 0, 0, 0, time.UTC),
+			wantDir:  false,
+			wantErr:  nil,
+			wantSize: 6,
+		},
+		{
+			name:     "symlink is followed",
+			path:     "link.txt",
+			wantMod:  time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC),
+			wantDir:  false,
+			wantErr:  nil,
+			wantSize: 7,
+		},
+		{
+			name:     "synthesized directory",
+			path:     "dir",
+			wantMod:  time.Time{},
+			wantDir:  true,
+			wantErr:  nil,
+			wantSize: 0,
+		},
+		{
+			name:     "root",
+			path:     "/",
+			wantMod:  time.Time{},
+			wantDir:  true,
+			wantErr:  nil,
+			wantSize: 0,
+		},
+		{
+			name:     "missing file",
+			path:     "missing.txt",
+			wantMod:  time.Time{},
+			wantDir:  false,
+			wantErr:  os.ErrNotExist,
+			wantSize: 0,
+		},
+		{
+			name:     "path escaping the root",
+			path:     "../outside.txt",
+			wantMod:  time.Time{},
+			wantDir:  false,
+			wantErr:  os.ErrNotExist,
+			wantSize: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := NewFromFS(testMapFS()).Stat(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("iofsFS.Stat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !info.ModTime().Equal(tt.wantMod) || info.IsDir() != tt.wantDir || info.Size() != tt.wantSize {
+				t.Errorf("iofsFS.Stat() = mod %v, dir %v, size %d, want mod %v, dir %v, size %d",
+					info.ModTime(), info.IsDir(), info.Size(), tt.wantMod, tt.wantDir, tt.wantSize)
+			}
+		})
+	}
+}
+
+func Test_iofsFS_Lstat(t *testing.T) {
+	tests := []struct {
+		name        string
+		fsys        fs.FS
+		path        string
+		wantSymlink bool
+		wantErr     error
+	}{
+		{
+			name:        "symlink itself",
+			fsys:        testMapFS(),
+			path:        "link.txt",
+			wantSymlink: true,
+			wantErr:     nil,
+		},
+		{
+			name:        "dangling symlink",
+			fsys:        testMapFS(),
+			path:        "dangling.txt",
+			wantSymlink: true,
+			wantErr:     nil,
+		},
+		{
+			name:        "regular file",
+			fsys:        testMapFS(),
+			path:        "file.txt",
+			wantSymlink: false,
+			wantErr:     nil,
+		},
+		{
+			name:        "falls back to following without ReadLinkFS",
+			fsys:        statOnlyFS{testMapFS()},
+			path:        "link.txt",
+			wantSymlink: false,
+			wantErr:     nil,
+		},
+		{
+			name:        "missing file",
+			fsys:        testMapFS(),
+			path:        "missing.txt",
+			wantSymlink: false,
+			wantErr:     os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := NewFromFS(tt.fsys).Lstat(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("iofsFS.Lstat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got := info.Mode()&fs.ModeSymlink != 0; got != tt.wantSymlink {
+				t.Errorf("iofsFS.Lstat() symlink = %v, want %v", got, tt.wantSymlink)
+			}
+		})
+	}
+}
+
+// statOnlyFS hides every method of the wrapped file system except Open, as a minimal
+// fs.FS without fs.ReadLinkFS would.
+type statOnlyFS struct {
+	fsys fs.FS
+}
+
+func (s statOnlyFS) Open(name string) (fs.File, error) {
+	return s.fsys.Open(name) //nolint:wrapcheck // Pass fs errors through unchanged.
+}
+
+func Test_iofsFS_writes(t *testing.T) {
+	fsys := NewFromFS(testMapFS())
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{
+			name: "create",
+			call: func() error {
+				_, err := fsys.Create("new.txt")
+
+				return err
+			},
+			wantErr: touchErrors.ErrReadOnlyFS,
+		},
+		{
+			name: "open file",
+			call: func() error {
+				_, err := fsys.OpenFile("file.txt", os.O_APPEND|os.O_WRONLY, 0o644)
+
+				return err
+			},
+			wantErr: touchErrors.ErrReadOnlyFS,
+		},
+		{
+			name: "chtimes",
+			call: func() error {
+				return fsys.Chtimes("file.txt", time.Now(), time.Now())
+			},
+			wantErr: touchErrors.ErrReadOnlyFS,
+		},
+		{
+			name: "mkdir all",
+			call: func() error {
+				return fsys.MkdirAll("new/dir", 0o755)
+			},
+			wantErr: touchErrors.ErrReadOnlyFS,
+		},
+		{
+			name: "open for create",
+			call: func() error {
+				_, _, err := fsys.OpenForCreate("new.txt")
+
+				return err
+			},
+			wantErr: touchErrors.ErrReadOnlyFS,
+		},
+		{
+			name: "remove",
+			call: func() error {
+				return fsys.Remove("file.txt")
+			},
+			wantErr: touchErrors.ErrReadOnlyFS,
+		},
+		{
+			name: "open",
+			call: func() error {
+				_, err := fsys.Open("file.txt")
+
+				return err
+			},
+			wantErr: touchErrors.ErrNotOSBacked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("iofsFS %s error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		})
+	}
+
+	// Nothing was written to the underlying map.
+	if _, err := fsys.Stat("new.txt"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("iofsFS.Stat(new.txt) error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func Test_iofsFS_WalkDir(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "whole tree",
+			root:    "/",
+			want:    []string{".", "dangling.txt", "dir", "dir/nested", "file.txt", "link.txt"},
+			wantErr: nil,
+		},
+		{
+			name:    "subdirectory",
+			root:    "dir",
+			want:    []string{"dir", "dir/nested"},
+			wantErr: nil,
+		},
+		{
+			name:    "missing root",
+			root:    "missing",
+			want:    []string{"missing"},
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+
+			err := NewFromFS(testMapFS()).WalkDir(tt.root, func(path string, _ fs.DirEntry, err error) error {
+				got = append(got, path)
+
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("iofsFS.WalkDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("iofsFS.WalkDir() visited %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_iofsFS_Glob(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "top level",
+			pattern: "*.txt",
+			want:    []string{"dangling.txt", "file.txt", "link.txt"},
+			wantErr: nil,
+		},
+		{
+			name:    "nested",
+			pattern: "/dir/*",
+			want:    []string{"dir/nested"},
+			wantErr: nil,
+		},
+		{
+			name:    "malformed pattern",
+			pattern: "[",
+			want:    nil,
+			wantErr: path.ErrBadPattern,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFromFS(testMapFS()).Glob(tt.pattern)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("iofsFS.Glob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("iofsFS.Glob() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
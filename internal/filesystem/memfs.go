@@ -0,0 +1,504 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package filesystem defines the FS interface and its default implementation for file operations.
+// This file provides a stateful in-memory FS for tests and library consumers.
+package filesystem
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// memDirPerm is the mode of directories MemFS creates implicitly, such as the root.
+const memDirPerm = 0o755
+
+// memFilePerm is the mode of files created by MemFS.Create, matching os.Create before umask.
+const memFilePerm = 0o666
+
+// MemFS is a stateful, in-memory FS that records files and directories with their
+// access and modification times in a map guarded by a mutex, making it safe for
+// concurrent use. The zero value is an empty file system ready for use.
+//
+// MemFS models names, times, modes, and content, so it behaves the same on every platform:
+//   - Create and OpenForCreate hand back a File whose writes are stored in memory, where
+//     Size and ReadFile see them.
+//   - Open and OpenFile return ErrNotOSBacked.
+//   - There are no symlinks, so Lstat behaves like Stat.
+//   - The Sys value of returned file info has an AccessTime method, which
+//     platform.GetAtime reads in place of an operating system stat structure.
+//   - Times MemFS sets itself, such as those of new directories, come from Clock.
+//   - A positive Granularity truncates every stored time to a multiple of it, as a
+//     filesystem with coarse timestamps such as FAT's 2 seconds would.
+type MemFS struct {
+	// Granularity, if positive, is the resolution times are stored at from then on; times
+	// already stored are kept as they are.
+	Granularity time.Duration
+
+	// Clock, if set, is the source of the times MemFS sets itself, such as a core.Clock shared
+	// with the touch run; otherwise they are read from time.Now.
+	Clock interface{ Now() Time }
+
+	mu    sync.Mutex
+	files map[string]*memFile
+}
+
+// memFile is one file or directory held by MemFS.
+type memFile struct {
+	mode  fs.FileMode
+	atime Time
+	mtime Time
+	data  []byte
+}
+
+// NewMemFS returns an empty MemFS.
+func NewMemFS() *MemFS {
+	return &MemFS{}
+}
+
+// now returns the current time from m.Clock, or from time.Now if it is nil.
+func (m *MemFS) now() Time {
+	if m.Clock == nil {
+		return time.Now()
+	}
+
+	return m.Clock.Now()
+}
+
+// Stat implements FS.Stat, describing the file or directory at path.
+func (m *MemFS) Stat(path string) (os.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	info, err := m.stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	return info, nil
+}
+
+// Lstat implements FS.Lstat; MemFS has no symlinks, so it behaves like Stat.
+func (m *MemFS) Lstat(path string) (os.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	info, err := m.stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("lstat %s: %w", path, err)
+	}
+
+	return info, nil
+}
+
+// Create implements FS.Create, recording an empty file at path with both times set to now,
+// or truncating an existing one and updating its modification time. Its parent directory
+// must exist. The returned handle appends to the file and must be closed by the caller.
+func (m *MemFS) Create(path string) (File, error) {
+	file, _, err := m.create(path, true)
+
+	return file, err
+}
+
+// OpenForCreate implements FS.OpenForCreate like Create, but leaves the times of an
+// existing file alone, as opening it without truncation doesn't modify it.
+func (m *MemFS) OpenForCreate(path string) (File, bool, error) {
+	return m.create(path, false)
+}
+
+// create records a new file at path, or with truncate empties an existing one and marks it
+// modified, and returns a handle appending to it and whether the file is new.
+func (m *MemFS) create(path string, truncate bool) (File, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name := filepath.Clean(path)
+
+	if file, ok := m.files[name]; ok && file.mode.IsDir() {
+		return nil, false, fmt.Errorf("create %s: %w", path, errors.ErrIsDirectory)
+	}
+
+	if err := m.checkParent(name); err != nil {
+		return nil, false, fmt.Errorf("create %s: %w", path, err)
+	}
+
+	now := m.coarsen(m.now())
+
+	file, ok := m.files[name]
+	if !ok {
+		file = &memFile{mode: memFilePerm, atime: now, mtime: now}
+		m.put(name, file)
+	} else if truncate {
+		file.data = nil
+		file.mtime = now
+	}
+
+	return &memHandle{fs: m, name: name, file: file}, !ok, nil
+}
+
+// ReadFile returns the content of the file at path.
+func (m *MemFS) ReadFile(path string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[filepath.Clean(path)]
+	if !ok {
+		return nil, fmt.Errorf("read %s: %w", path, fs.ErrNotExist)
+	}
+
+	if file.mode.IsDir() {
+		return nil, fmt.Errorf("read %s: %w", path, errors.ErrIsDirectory)
+	}
+
+	return slices.Clone(file.data), nil
+}
+
+// Open implements FS.Open; MemFS files aren't operating system files, so it always fails,
+// with fs.ErrNotExist for a missing file so callers can tell it apart.
+func (m *MemFS) Open(path string) (*os.File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.files[filepath.Clean(path)]; !ok {
+		return nil, fmt.Errorf("open %s: %w", path, fs.ErrNotExist)
+	}
+
+	return nil, fmt.Errorf("open %s: %w", path, errors.ErrNotOSBacked)
+}
+
+// OpenFile implements FS.OpenFile; MemFS files aren't operating system files, so it always fails.
+func (m *MemFS) OpenFile(path string, _ int, _ os.FileMode) (*os.File, error) {
+	return nil, fmt.Errorf("open %s: %w", path, errors.ErrNotOSBacked)
+}
+
+// Chtimes implements FS.Chtimes, storing atime and mtime for the file or directory at path.
+func (m *MemFS) Chtimes(path string, atime Time, mtime Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	file, ok := m.files[filepath.Clean(path)]
+	if !ok {
+		return fmt.Errorf("chtimes %s: %w", path, fs.ErrNotExist)
+	}
+
+	file.atime, file.mtime = m.coarsen(atime), m.coarsen(mtime)
+
+	return nil
+}
+
+// MkdirAll implements FS.MkdirAll, recording path and any missing parents as directories
+// with perm. It fails if path or one of its parents is a regular file.
+func (m *MemFS) MkdirAll(path string, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name := filepath.Clean(path)
+
+	// Collect the missing directories from name up to the first existing ancestor.
+	var missing []string
+
+	for dir := name; !isMemRoot(dir); dir = filepath.Dir(dir) {
+		if file, ok := m.files[dir]; ok {
+			if !file.mode.IsDir() {
+				return fmt.Errorf("mkdir %s: %w", path, errors.ErrNotDirectory)
+			}
+
+			break
+		}
+
+		missing = append(missing, dir)
+	}
+
+	now := m.coarsen(m.now())
+
+	for _, dir := range missing {
+		m.put(dir, &memFile{mode: fs.ModeDir | perm.Perm(), atime: now, mtime: now})
+	}
+
+	return nil
+}
+
+// Remove implements FS.Remove, deleting the file or empty directory at path.
+func (m *MemFS) Remove(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name := filepath.Clean(path)
+
+	file, ok := m.files[name]
+	if !ok {
+		return fmt.Errorf("remove %s: %w", path, fs.ErrNotExist)
+	}
+
+	if file.mode.IsDir() && len(m.children(name)) > 0 {
+		return fmt.Errorf("remove %s: %w", path, errors.ErrDirectoryNotEmpty)
+	}
+
+	delete(m.files, name)
+
+	return nil
+}
+
+// WalkDir implements FS.WalkDir, visiting root and then its descendants in lexical order
+// within each directory, like filepath.WalkDir.
+func (m *MemFS) WalkDir(root string, fn fs.WalkDirFunc) error {
+	m.mu.Lock()
+	info, err := m.stat(root)
+	m.mu.Unlock()
+
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = m.walk(root, fs.FileInfoToDirEntry(info), fn)
+	}
+
+	if err == nil || err == fs.SkipDir || err == fs.SkipAll { //nolint:errorlint // Sentinels are returned unwrapped.
+		return nil
+	}
+
+	return fmt.Errorf("walk %s: %w", root, err)
+}
+
+// Glob implements FS.Glob, matching pattern against every stored file and directory.
+func (m *MemFS) Glob(pattern string) ([]string, error) {
+	// Reject a malformed pattern, as filepath.Glob does, even if nothing is stored.
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("glob %s: %w", pattern, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pattern = filepath.Clean(pattern)
+
+	var matches []string
+
+	for name := range m.files {
+		if matched, _ := filepath.Match(pattern, name); matched && !isMemRoot(name) {
+			matches = append(matches, name)
+		}
+	}
+
+	slices.Sort(matches)
+
+	return matches, nil
+}
+
+// walk calls fn for path and, if it is a directory, recursively for its children. The
+// lock is released while fn runs so that fn may use the file system.
+func (m *MemFS) walk(path string, entry fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(path, entry, nil); err != nil || !entry.IsDir() {
+		if err == fs.SkipDir && entry.IsDir() { //nolint:errorlint // Sentinels are returned unwrapped.
+			return nil
+		}
+
+		return err
+	}
+
+	m.mu.Lock()
+	children := m.children(filepath.Clean(path))
+	m.mu.Unlock()
+
+	for _, child := range children {
+		if err := m.walk(filepath.Join(path, child.Name()), child, fn); err != nil {
+			// SkipDir from a file skips the rest of its directory.
+			if err == fs.SkipDir { //nolint:errorlint // Sentinels are returned unwrapped.
+				break
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// children returns entries for the direct children of dir, sorted by name. The caller
+// must hold the lock.
+func (m *MemFS) children(dir string) []fs.DirEntry {
+	var entries []fs.DirEntry
+
+	for name, file := range m.files {
+		if name != dir && filepath.Dir(name) == dir {
+			entries = append(entries, fs.FileInfoToDirEntry(newMemFileInfo(name, file)))
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int { return strings.Compare(a.Name(), b.Name()) })
+
+	return entries
+}
+
+// stat describes path; roots and the current directory always exist. The caller must
+// hold the lock.
+func (m *MemFS) stat(path string) (os.FileInfo, error) {
+	name := filepath.Clean(path)
+
+	if file, ok := m.files[name]; ok {
+		return newMemFileInfo(name, file), nil
+	}
+
+	if isMemRoot(name) {
+		return newMemFileInfo(name, &memFile{mode: fs.ModeDir | memDirPerm}), nil
+	}
+
+	return nil, fs.ErrNotExist
+}
+
+// checkParent reports whether name's parent directory exists. The caller must hold the lock.
+func (m *MemFS) checkParent(name string) error {
+	parent := filepath.Dir(name)
+	if isMemRoot(parent) {
+		return nil
+	}
+
+	file, ok := m.files[parent]
+
+	switch {
+	case !ok:
+		return fs.ErrNotExist
+	case !file.mode.IsDir():
+		return errors.ErrNotDirectory
+	default:
+		return nil
+	}
+}
+
+// coarsen truncates t to a multiple of Granularity since the Unix epoch, if one is set.
+func (m *MemFS) coarsen(t Time) Time {
+	if m.Granularity <= 0 {
+		return t
+	}
+
+	excess := time.Duration(t.UnixNano() % int64(m.Granularity))
+	if excess < 0 {
+		excess += m.Granularity // Round times before the epoch down too.
+	}
+
+	return t.Add(-excess)
+}
+
+// put records file at name, allocating the map on first use. The caller must hold the lock.
+func (m *MemFS) put(name string, file *memFile) {
+	if m.files == nil {
+		m.files = make(map[string]*memFile)
+	}
+
+	m.files[name] = file
+}
+
+// isMemRoot reports whether the cleaned name is the current directory or a root,
+// which MemFS treats as always existing.
+func isMemRoot(name string) bool {
+	return name == "." || filepath.Dir(name) == name
+}
+
+// MemStat is the Sys value of file info returned by MemFS, carrying the access time
+// that os.FileInfo has no method for.
+type MemStat struct {
+	Atime Time
+}
+
+// AccessTime returns the stored access time.
+func (s *MemStat) AccessTime() Time {
+	return s.Atime
+}
+
+// memHandle is a File open on a MemFS file. Like an operating system file, it keeps
+// writing to the file even if the file is removed meanwhile.
+type memHandle struct {
+	fs     *MemFS
+	name   string
+	file   *memFile
+	closed bool
+}
+
+// Write implements io.Writer, appending p to the file and updating its modification time.
+func (h *memHandle) Write(p []byte) (int, error) {
+	h.fs.mu.Lock()
+	defer h.fs.mu.Unlock()
+
+	if h.closed {
+		return 0, fmt.Errorf("write %s: %w", h.name, os.ErrClosed)
+	}
+
+	h.file.data = append(h.file.data, p...)
+	h.file.mtime = h.fs.coarsen(h.fs.now())
+
+	return len(p), nil
+}
+
+// Stat implements File.Stat, describing the open file.
+func (h *memHandle) Stat() (os.FileInfo, error) {
+	h.fs.mu.Lock()
+	defer h.fs.mu.Unlock()
+
+	if h.closed {
+		return nil, fmt.Errorf("stat %s: %w", h.name, os.ErrClosed)
+	}
+
+	return newMemFileInfo(h.name, h.file), nil
+}
+
+// Close implements io.Closer; the handle can't be used afterward.
+func (h *memHandle) Close() error {
+	h.fs.mu.Lock()
+	defer h.fs.mu.Unlock()
+
+	if h.closed {
+		return fmt.Errorf("close %s: %w", h.name, os.ErrClosed)
+	}
+
+	h.closed = true
+
+	return nil
+}
+
+// memFileInfo is a snapshot of a memFile, implementing os.FileInfo.
+type memFileInfo struct {
+	name  string
+	mode  fs.FileMode
+	size  int64
+	mtime Time
+	sys   *MemStat
+}
+
+// newMemFileInfo snapshots file so later changes don't alter the returned info.
+func newMemFileInfo(name string, file *memFile) memFileInfo {
+	return memFileInfo{
+		name:  filepath.Base(name),
+		mode:  file.mode,
+		size:  int64(len(file.data)),
+		mtime: file.mtime,
+		sys:   &MemStat{Atime: file.atime},
+	}
+}
+
+func (i memFileInfo) Name() string      { return i.name }
+func (i memFileInfo) Size() int64       { return i.size }
+func (i memFileInfo) Mode() fs.FileMode { return i.mode }
+func (i memFileInfo) ModTime() Time     { return i.mtime }
+func (i memFileInfo) IsDir() bool       { return i.mode.IsDir() }
+func (i memFileInfo) Sys() any          { return i.sys }
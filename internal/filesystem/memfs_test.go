@@ -0,0 +1,620 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package filesystem defines the FS interface and its default implementation for file operations.
+// This file provides a stateful in-memory FS for tests and library consumers.
+package filesystem
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// memAtime returns the access time stored in the Sys value of MemFS file info.
+func memAtime(t *testing.T, info os.FileInfo) Time {
+	t.Helper()
+
+	sys, ok := info.Sys().(*MemStat)
+	if !ok {
+		t.Fatalf("Sys() = %T, want *MemStat", info.Sys())
+	}
+
+	return sys.AccessTime()
+}
+
+// fixedClock is a clock that always reads the same time.
+type fixedClock Time
+
+// Now returns c's time.
+func (c fixedClock) Now() Time {
+	return Time(c)
+}
+
+func TestMemFS_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(m *MemFS) error
+		path    string
+		wantErr error
+	}{
+		{
+			name:    "new file in the current directory",
+			setup:   func(*MemFS) error { return nil },
+			path:    "new.txt",
+			wantErr: nil,
+		},
+		{
+			name:    "new file in an existing directory",
+			setup:   func(m *MemFS) error { return m.MkdirAll(filepath.Join("a", "b"), 0o755) },
+			path:    filepath.Join("a", "b", "new.txt"),
+			wantErr: nil,
+		},
+		{
+			name:    "missing parent",
+			setup:   func(*MemFS) error { return nil },
+			path:    filepath.Join("missing", "new.txt"),
+			wantErr: fs.ErrNotExist,
+		},
+		{
+			name: "parent is a file",
+			setup: func(m *MemFS) error {
+				file, err := m.Create("file.txt")
+				if err != nil {
+					return err
+				}
+
+				return file.Close()
+			},
+			path:    filepath.Join("file.txt", "new.txt"),
+			wantErr: touchErrors.ErrNotDirectory,
+		},
+		{
+			name:    "directory",
+			setup:   func(m *MemFS) error { return m.MkdirAll("dir", 0o755) },
+			path:    "dir",
+			wantErr: touchErrors.ErrIsDirectory,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMemFS()
+			if err := tt.setup(m); err != nil {
+				t.Fatal(err)
+			}
+
+			before := time.Now()
+
+			file, err := m.Create(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MemFS.Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			// Writes through the handle replace any earlier content.
+			if _, err := io.WriteString(file, "content"); err != nil {
+				t.Errorf("write to MemFS.Create() handle error = %v", err)
+			}
+
+			if err := file.Close(); err != nil {
+				t.Errorf("close MemFS.Create() handle error = %v", err)
+			}
+
+			if _, err := io.WriteString(file, "more"); !errors.Is(err, os.ErrClosed) {
+				t.Errorf("write to closed MemFS.Create() handle error = %v, want %v", err, os.ErrClosed)
+			}
+
+			info, err := m.Stat(tt.path)
+			if err != nil {
+				t.Fatalf("MemFS.Stat() error = %v", err)
+			}
+
+			if data, err := m.ReadFile(tt.path); err != nil || string(data) != "content" || info.Size() != 7 {
+				t.Errorf("MemFS.ReadFile() = %q, %v with size %d, want %q with size 7",
+					data, err, info.Size(), "content")
+			}
+
+			if !info.Mode().IsRegular() || info.Name() != filepath.Base(tt.path) {
+				t.Errorf("MemFS.Stat() = %s with mode %v, want regular file %s",
+					info.Name(), info.Mode(), filepath.Base(tt.path))
+			}
+
+			if info.ModTime().Before(before) || memAtime(t, info).Before(before) {
+				t.Errorf("MemFS.Stat() times = %v, %v, want at or after %v",
+					memAtime(t, info), info.ModTime(), before)
+			}
+		})
+	}
+}
+
+func TestMemFS_OpenForCreate(t *testing.T) {
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m := NewMemFS()
+
+	file, created, err := m.OpenForCreate("new.txt")
+	if err != nil || !created {
+		t.Fatalf("MemFS.OpenForCreate(new) = %v, %v, want created", created, err)
+	}
+
+	io.WriteString(file, "content")
+	file.Close()
+
+	if _, err := m.Stat("new.txt"); err != nil {
+		t.Errorf("MemFS.Stat(new) error = %v", err)
+	}
+
+	if err := m.Chtimes("new.txt", old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unlike Create, opening an existing file doesn't truncate it, so its times are kept.
+	file, created, err = m.OpenForCreate("new.txt")
+	if err != nil || created {
+		t.Fatalf("MemFS.OpenForCreate(existing) = %v, %v, want not created", created, err)
+	}
+
+	file.Close()
+
+	info, err := m.Stat("new.txt")
+	if err != nil || !info.ModTime().Equal(old) {
+		t.Errorf("MemFS.Stat(existing) = %v, %v, want modification time %v", info, err, old)
+	}
+
+	// Nor does it lose the content, which a second handle appends to.
+	file, _, err = m.OpenForCreate("new.txt")
+	if err != nil {
+		t.Fatalf("MemFS.OpenForCreate(existing) error = %v", err)
+	}
+
+	io.WriteString(file, "more")
+	file.Close()
+
+	if data, err := m.ReadFile("new.txt"); err != nil || string(data) != "contentmore" {
+		t.Errorf("MemFS.ReadFile(existing) = %q, %v, want %q", data, err, "contentmore")
+	}
+
+	_, _, err = m.OpenForCreate(filepath.Join("missing", "new.txt"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("MemFS.OpenForCreate(missing parent) error = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestMemFS_Chtimes(t *testing.T) {
+	atime := time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC)
+	mtime := time.Date(2025, 7, 14, 12, 0, 0, 0, time.UTC)
+
+	m := NewMemFS()
+
+	file, err := m.Create("file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file.Close()
+
+	if err := m.Chtimes("./file.txt", atime, mtime); err != nil {
+		t.Fatalf("MemFS.Chtimes() error = %v", err)
+	}
+
+	for _, stat := range []func(string) (os.FileInfo, error){m.Stat, m.Lstat} {
+		info, err := stat("file.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !memAtime(t, info).Equal(atime) || !info.ModTime().Equal(mtime) {
+			t.Errorf("times = %v, %v, want %v, %v", memAtime(t, info), info.ModTime(), atime, mtime)
+		}
+	}
+
+	if err := m.Chtimes("missing.txt", atime, mtime); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("MemFS.Chtimes(missing) error = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestMemFS_granularity(t *testing.T) {
+	tests := []struct {
+		name        string
+		granularity time.Duration
+		set         Time
+		want        Time
+	}{
+		{
+			name:        "exact times without granularity",
+			granularity: 0,
+			set:         time.Unix(1_700_000_001, 123_456_789),
+			want:        time.Unix(1_700_000_001, 123_456_789),
+		},
+		{
+			name:        "odd second truncated to 2 seconds",
+			granularity: 2 * time.Second,
+			set:         time.Unix(1_700_000_001, 999_999_999),
+			want:        time.Unix(1_700_000_000, 0),
+		},
+		{
+			name:        "even second keeps its second",
+			granularity: 2 * time.Second,
+			set:         time.Unix(1_700_000_002, 500_000_000),
+			want:        time.Unix(1_700_000_002, 0),
+		},
+		{
+			name:        "before the epoch rounds down",
+			granularity: 2 * time.Second,
+			set:         time.Unix(-3, 500_000_000),
+			want:        time.Unix(-4, 0),
+		},
+		{
+			name:        "100 nanoseconds",
+			granularity: 100 * time.Nanosecond,
+			set:         time.Unix(1_700_000_001, 123_456_789),
+			want:        time.Unix(1_700_000_001, 123_456_700),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MemFS{Granularity: tt.granularity}
+
+			file, err := m.Create("file.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			file.Close()
+
+			if err := m.Chtimes("file.txt", tt.set, tt.set); err != nil {
+				t.Fatalf("MemFS.Chtimes() error = %v", err)
+			}
+
+			info, err := m.Stat("file.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !memAtime(t, info).Equal(tt.want) || !info.ModTime().Equal(tt.want) {
+				t.Errorf("times = %v, %v, want %v", memAtime(t, info), info.ModTime(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMemFS_Stat(t *testing.T) {
+	m := NewMemFS()
+	if err := m.MkdirAll("dir", 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantMode fs.FileMode
+		wantErr  error
+	}{
+		{name: "directory", path: "dir", wantMode: fs.ModeDir | 0o700, wantErr: nil},
+		{name: "current directory", path: ".", wantMode: fs.ModeDir | memDirPerm, wantErr: nil},
+		{name: "root", path: string(filepath.Separator), wantMode: fs.ModeDir | memDirPerm, wantErr: nil},
+		{name: "missing", path: "missing.txt", wantMode: 0, wantErr: fs.ErrNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := m.Stat(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MemFS.Stat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && info.Mode() != tt.wantMode {
+				t.Errorf("MemFS.Stat() mode = %v, want %v", info.Mode(), tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestMemFS_MkdirAll(t *testing.T) {
+	stamp := time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC)
+
+	m := &MemFS{Clock: fixedClock(stamp)}
+
+	file, err := m.Create("file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file.Close()
+
+	if err := m.MkdirAll(filepath.Join("a", "b", "c"), 0o750); err != nil {
+		t.Fatalf("MemFS.MkdirAll() error = %v", err)
+	}
+
+	// Existing directories are left alone.
+	if err := m.MkdirAll("a", 0o700); err != nil {
+		t.Fatalf("MemFS.MkdirAll(existing) error = %v", err)
+	}
+
+	for _, dir := range []string{"a", filepath.Join("a", "b"), filepath.Join("a", "b", "c")} {
+		info, err := m.Stat(dir)
+		if err != nil || info.Mode() != fs.ModeDir|0o750 || !info.ModTime().Equal(stamp) {
+			t.Errorf("MemFS.Stat(%s) = %v, %v, want directory with mode 0750 made at %v",
+				dir, info, err, stamp)
+		}
+	}
+
+	err = m.MkdirAll(filepath.Join("file.txt", "sub"), 0o755)
+	if !errors.Is(err, touchErrors.ErrNotDirectory) {
+		t.Errorf("MemFS.MkdirAll(under file) error = %v, want %v", err, touchErrors.ErrNotDirectory)
+	}
+}
+
+func TestMemFS_Remove(t *testing.T) {
+	m := NewMemFS()
+
+	if err := m.MkdirAll(filepath.Join("full", "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := m.Create("file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{name: "file", path: "file.txt", wantErr: nil},
+		{name: "already removed", path: "file.txt", wantErr: fs.ErrNotExist},
+		{name: "non-empty directory", path: "full", wantErr: touchErrors.ErrDirectoryNotEmpty},
+		{name: "empty directory", path: filepath.Join("full", "sub"), wantErr: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.Remove(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MemFS.Remove() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil {
+				if _, err := m.Stat(tt.path); !errors.Is(err, fs.ErrNotExist) {
+					t.Errorf("MemFS.Stat() after Remove error = %v, want %v", err, fs.ErrNotExist)
+				}
+			}
+		})
+	}
+}
+
+func TestMemFS_Open(t *testing.T) {
+	m := NewMemFS()
+
+	if _, err := m.Open("file.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("MemFS.Open() missing error = %v, want %v", err, fs.ErrNotExist)
+	}
+
+	file, err := m.Create("file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file.Close()
+
+	if _, err := m.Open("file.txt"); !errors.Is(err, touchErrors.ErrNotOSBacked) {
+		t.Errorf("MemFS.Open() error = %v, want %v", err, touchErrors.ErrNotOSBacked)
+	}
+
+	_, err = m.OpenFile("file.txt", os.O_CREATE|os.O_WRONLY, 0o644)
+	if !errors.Is(err, touchErrors.ErrNotOSBacked) {
+		t.Errorf("MemFS.OpenFile() error = %v, want %v", err, touchErrors.ErrNotOSBacked)
+	}
+}
+
+func TestMemFS_WalkDir(t *testing.T) {
+	m := NewMemFS()
+	for _, dir := range []string{filepath.Join("root", "a"), filepath.Join("root", "skip", "deep")} {
+		if err := m.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{
+		filepath.Join("root", "a", "x.txt"),
+		filepath.Join("root", "b.txt"),
+		filepath.Join("root", "skip", "y.txt"),
+	} {
+		file, err := m.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		file.Close()
+	}
+
+	tests := []struct {
+		name    string
+		root    string
+		skip    string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "whole tree in lexical order",
+			root: "root",
+			skip: "",
+			want: []string{
+				"root", filepath.Join("root", "a"), filepath.Join("root", "a", "x.txt"), filepath.Join("root", "b.txt"),
+				filepath.Join("root", "skip"), filepath.Join("root", "skip", "deep"), filepath.Join("root", "skip", "y.txt"),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "skip directory",
+			root: "root",
+			skip: filepath.Join("root", "skip"),
+			want: []string{
+				"root", filepath.Join("root", "a"), filepath.Join("root", "a", "x.txt"), filepath.Join("root", "b.txt"),
+				filepath.Join("root", "skip"),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "skip rest of directory from a file",
+			root: "root",
+			skip: filepath.Join("root", "b.txt"),
+			want: []string{
+				"root", filepath.Join("root", "a"), filepath.Join("root", "a", "x.txt"), filepath.Join("root", "b.txt"),
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "missing root",
+			root:    "missing",
+			skip:    "",
+			want:    []string{"missing"},
+			wantErr: fs.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+
+			err := m.WalkDir(tt.root, func(path string, _ fs.DirEntry, err error) error {
+				got = append(got, path)
+
+				if path == tt.skip {
+					return fs.SkipDir
+				}
+
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MemFS.WalkDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("MemFS.WalkDir() visited %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemFS_Glob(t *testing.T) {
+	m := NewMemFS()
+
+	if err := m.MkdirAll("logs", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{"a.txt", filepath.Join("logs", "b.log"), filepath.Join("logs", "c.log")}
+
+	for _, name := range names {
+		file, err := m.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		file.Close()
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "top level",
+			pattern: "*",
+			want:    []string{"a.txt", "logs"},
+			wantErr: nil,
+		},
+		{
+			name:    "nested",
+			pattern: filepath.Join("logs", "*.log"),
+			want:    []string{filepath.Join("logs", "b.log"), filepath.Join("logs", "c.log")},
+			wantErr: nil,
+		},
+		{
+			name:    "no matches",
+			pattern: "*.md",
+			want:    nil,
+			wantErr: nil,
+		},
+		{
+			name:    "malformed pattern",
+			pattern: "[",
+			want:    nil,
+			wantErr: filepath.ErrBadPattern,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Glob(tt.pattern)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MemFS.Glob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("MemFS.Glob() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemFS_concurrent(t *testing.T) {
+	m := NewMemFS()
+	atime := time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+
+	for i := range 50 {
+		wg.Go(func() {
+			name := filepath.Join(".", string(rune('a'+i%26))+".txt")
+
+			if file, err := m.Create(name); err == nil {
+				file.Close()
+			}
+
+			m.Chtimes(name, atime, atime)
+			m.Stat(name)
+		})
+	}
+
+	wg.Wait()
+
+	var count int
+
+	m.WalkDir(".", func(_ string, _ fs.DirEntry, _ error) error {
+		count++
+
+		return nil
+	})
+
+	if want := 26 + 1; count != want {
+		t.Errorf("MemFS.WalkDir() visited %d entries, want %d", count, want)
+	}
+}
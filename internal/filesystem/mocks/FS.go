@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"io/fs"
 	"os"
 
 	"github.com/nicholas-fedor/touch/internal/filesystem"
@@ -102,23 +103,23 @@ func (_c *MockFS_Chtimes_Call) RunAndReturn(run func(path string, atime filesyst
 }
 
 // Create provides a mock function for the type MockFS
-func (_mock *MockFS) Create(path string) (*os.File, error) {
+func (_mock *MockFS) Create(path string) (filesystem.File, error) {
 	ret := _mock.Called(path)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
-	var r0 *os.File
+	var r0 filesystem.File
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*os.File, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(string) (filesystem.File, error)); ok {
 		return returnFunc(path)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *os.File); ok {
+	if returnFunc, ok := ret.Get(0).(func(string) filesystem.File); ok {
 		r0 = returnFunc(path)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*os.File)
+			r0 = ret.Get(0).(filesystem.File)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
@@ -153,12 +154,74 @@ func (_c *MockFS_Create_Call) Run(run func(path string)) *MockFS_Create_Call {
 	return _c
 }
 
-func (_c *MockFS_Create_Call) Return(file *os.File, err error) *MockFS_Create_Call {
+func (_c *MockFS_Create_Call) Return(file filesystem.File, err error) *MockFS_Create_Call {
 	_c.Call.Return(file, err)
 	return _c
 }
 
-func (_c *MockFS_Create_Call) RunAndReturn(run func(path string) (*os.File, error)) *MockFS_Create_Call {
+func (_c *MockFS_Create_Call) RunAndReturn(run func(path string) (filesystem.File, error)) *MockFS_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Glob provides a mock function for the type MockFS
+func (_mock *MockFS) Glob(pattern string) ([]string, error) {
+	ret := _mock.Called(pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Glob")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return returnFunc(pattern)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = returnFunc(pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFS_Glob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Glob'
+type MockFS_Glob_Call struct {
+	*mock.Call
+}
+
+// Glob is a helper method to define mock.On call
+//   - pattern string
+func (_e *MockFS_Expecter) Glob(pattern interface{}) *MockFS_Glob_Call {
+	return &MockFS_Glob_Call{Call: _e.mock.On("Glob", pattern)}
+}
+
+func (_c *MockFS_Glob_Call) Run(run func(pattern string)) *MockFS_Glob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFS_Glob_Call) Return(matches []string, err error) *MockFS_Glob_Call {
+	_c.Call.Return(matches, err)
+	return _c
+}
+
+func (_c *MockFS_Glob_Call) RunAndReturn(run func(pattern string) ([]string, error)) *MockFS_Glob_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -225,6 +288,318 @@ func (_c *MockFS_Lstat_Call) RunAndReturn(run func(path string) (os.FileInfo, er
 	return _c
 }
 
+// MkdirAll provides a mock function for the type MockFS
+func (_mock *MockFS) MkdirAll(path string, perm os.FileMode) error {
+	ret := _mock.Called(path, perm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MkdirAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, os.FileMode) error); ok {
+		r0 = returnFunc(path, perm)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFS_MkdirAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MkdirAll'
+type MockFS_MkdirAll_Call struct {
+	*mock.Call
+}
+
+// MkdirAll is a helper method to define mock.On call
+//   - path string
+//   - perm os.FileMode
+func (_e *MockFS_Expecter) MkdirAll(path interface{}, perm interface{}) *MockFS_MkdirAll_Call {
+	return &MockFS_MkdirAll_Call{Call: _e.mock.On("MkdirAll", path, perm)}
+}
+
+func (_c *MockFS_MkdirAll_Call) Run(run func(path string, perm os.FileMode)) *MockFS_MkdirAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 os.FileMode
+		if args[1] != nil {
+			arg1 = args[1].(os.FileMode)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFS_MkdirAll_Call) Return(err error) *MockFS_MkdirAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFS_MkdirAll_Call) RunAndReturn(run func(path string, perm os.FileMode) error) *MockFS_MkdirAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function for the type MockFS
+func (_mock *MockFS) Open(path string) (*os.File, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 *os.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*os.File, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *os.File); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*os.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFS_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockFS_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - path string
+func (_e *MockFS_Expecter) Open(path interface{}) *MockFS_Open_Call {
+	return &MockFS_Open_Call{Call: _e.mock.On("Open", path)}
+}
+
+func (_c *MockFS_Open_Call) Run(run func(path string)) *MockFS_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFS_Open_Call) Return(file *os.File, err error) *MockFS_Open_Call {
+	_c.Call.Return(file, err)
+	return _c
+}
+
+func (_c *MockFS_Open_Call) RunAndReturn(run func(path string) (*os.File, error)) *MockFS_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenFile provides a mock function for the type MockFS
+func (_mock *MockFS) OpenFile(path string, flag int, perm os.FileMode) (*os.File, error) {
+	ret := _mock.Called(path, flag, perm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenFile")
+	}
+
+	var r0 *os.File
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int, os.FileMode) (*os.File, error)); ok {
+		return returnFunc(path, flag, perm)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int, os.FileMode) *os.File); ok {
+		r0 = returnFunc(path, flag, perm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*os.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int, os.FileMode) error); ok {
+		r1 = returnFunc(path, flag, perm)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockFS_OpenFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenFile'
+type MockFS_OpenFile_Call struct {
+	*mock.Call
+}
+
+// OpenFile is a helper method to define mock.On call
+//   - path string
+//   - flag int
+//   - perm os.FileMode
+func (_e *MockFS_Expecter) OpenFile(path interface{}, flag interface{}, perm interface{}) *MockFS_OpenFile_Call {
+	return &MockFS_OpenFile_Call{Call: _e.mock.On("OpenFile", path, flag, perm)}
+}
+
+func (_c *MockFS_OpenFile_Call) Run(run func(path string, flag int, perm os.FileMode)) *MockFS_OpenFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 os.FileMode
+		if args[2] != nil {
+			arg2 = args[2].(os.FileMode)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFS_OpenFile_Call) Return(file *os.File, err error) *MockFS_OpenFile_Call {
+	_c.Call.Return(file, err)
+	return _c
+}
+
+func (_c *MockFS_OpenFile_Call) RunAndReturn(run func(path string, flag int, perm os.FileMode) (*os.File, error)) *MockFS_OpenFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenForCreate provides a mock function for the type MockFS
+func (_mock *MockFS) OpenForCreate(path string) (filesystem.File, bool, error) {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenForCreate")
+	}
+
+	var r0 filesystem.File
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(string) (filesystem.File, bool, error)); ok {
+		return returnFunc(path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) filesystem.File); ok {
+		r0 = returnFunc(path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(filesystem.File)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = returnFunc(path)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(string) error); ok {
+		r2 = returnFunc(path)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockFS_OpenForCreate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenForCreate'
+type MockFS_OpenForCreate_Call struct {
+	*mock.Call
+}
+
+// OpenForCreate is a helper method to define mock.On call
+//   - path string
+func (_e *MockFS_Expecter) OpenForCreate(path interface{}) *MockFS_OpenForCreate_Call {
+	return &MockFS_OpenForCreate_Call{Call: _e.mock.On("OpenForCreate", path)}
+}
+
+func (_c *MockFS_OpenForCreate_Call) Run(run func(path string)) *MockFS_OpenForCreate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFS_OpenForCreate_Call) Return(file filesystem.File, created bool, err error) *MockFS_OpenForCreate_Call {
+	_c.Call.Return(file, created, err)
+	return _c
+}
+
+func (_c *MockFS_OpenForCreate_Call) RunAndReturn(run func(path string) (filesystem.File, bool, error)) *MockFS_OpenForCreate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function for the type MockFS
+func (_mock *MockFS) Remove(path string) error {
+	ret := _mock.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(path)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFS_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type MockFS_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - path string
+func (_e *MockFS_Expecter) Remove(path interface{}) *MockFS_Remove_Call {
+	return &MockFS_Remove_Call{Call: _e.mock.On("Remove", path)}
+}
+
+func (_c *MockFS_Remove_Call) Run(run func(path string)) *MockFS_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFS_Remove_Call) Return(err error) *MockFS_Remove_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFS_Remove_Call) RunAndReturn(run func(path string) error) *MockFS_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stat provides a mock function for the type MockFS
 func (_mock *MockFS) Stat(path string) (os.FileInfo, error) {
 	ret := _mock.Called(path)
@@ -286,3 +661,60 @@ func (_c *MockFS_Stat_Call) RunAndReturn(run func(path string) (os.FileInfo, err
 	_c.Call.Return(run)
 	return _c
 }
+
+// WalkDir provides a mock function for the type MockFS
+func (_mock *MockFS) WalkDir(root string, fn fs.WalkDirFunc) error {
+	ret := _mock.Called(root, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WalkDir")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, fs.WalkDirFunc) error); ok {
+		r0 = returnFunc(root, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockFS_WalkDir_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WalkDir'
+type MockFS_WalkDir_Call struct {
+	*mock.Call
+}
+
+// WalkDir is a helper method to define mock.On call
+//   - root string
+//   - fn fs.WalkDirFunc
+func (_e *MockFS_Expecter) WalkDir(root interface{}, fn interface{}) *MockFS_WalkDir_Call {
+	return &MockFS_WalkDir_Call{Call: _e.mock.On("WalkDir", root, fn)}
+}
+
+func (_c *MockFS_WalkDir_Call) Run(run func(root string, fn fs.WalkDirFunc)) *MockFS_WalkDir_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 fs.WalkDirFunc
+		if args[1] != nil {
+			arg1 = args[1].(fs.WalkDirFunc)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockFS_WalkDir_Call) Return(err error) *MockFS_WalkDir_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockFS_WalkDir_Call) RunAndReturn(run func(root string, fn fs.WalkDirFunc) error) *MockFS_WalkDir_Call {
+	_c.Call.Return(run)
+	return _c
+}
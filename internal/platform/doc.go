@@ -3,14 +3,35 @@
 // are overridden by build tags for different operating systems (Unix, Darwin, Windows).
 //
 // Main Components:
-// - GetAtime: Function to retrieve the access time from file info, using OS-specific structures.
-// - SetTimesNoDeref: Function to set timestamps without dereferencing symlinks, using OS-specific calls.
+// - GetAtime: Function to retrieve the access time from file info, using OS-specific structures or the AccessTime method of an in-memory file system's Sys value.
+// - GetAtimeAt: Function to retrieve the access time of a file at a path, looking it up again with statx on Linux and otherwise using GetAtime.
+// - SetTimesNoDeref: Function to set timestamps without dereferencing symlinks, using OS-specific calls, with SetTimesNoDerefSupported reporting whether it can.
+// - GetBtime: Function to retrieve the birth (creation) time of a file, reporting whether one is available.
+// - SetBtime: Function to set the birth (creation) time of a file, with SetBtimeSupported reporting whether it can (Darwin and Windows only).
+// - GetCtime: Function to retrieve the status change time from file info, reporting whether one is available (Unix only).
+// - GetDevice: Function to retrieve the ID of the device holding a file, reporting whether one is available (Unix only).
+// - GetMountTime: Function to approximate the mount time of the filesystem containing a path (Linux only).
+// - GetFSType: Function to identify the filesystem containing a path and whether it is a network filesystem (Linux only).
+// - GetBootTime: Function to approximate the system boot time as now minus uptime (Linux only).
+// - GetProcFDsTime: Function to find the newest modification time among a process's open files (Linux only).
+// - GetUnitActiveEnterTime: Function to read when a systemd unit last became active (Linux only).
+// - WriteJournal: Function to send an entry of KEY=value fields to the system journal (Linux only).
 // - init: Sets fallback implementations for unsupported platforms or default behaviors.
 //
 // Build Tags:
-// - touch_unix.go: For Unix-like systems (non-Windows, non-Darwin), uses syscall.Stat_t and unix.UtimesNanoAt.
-// - touch_darwin.go: For Darwin (macOS), uses syscall.Stat_t and unix.Lutimes.
-// - touch_windows.go: For Windows, uses windows.Win32FileAttributeData and a custom filetimeToTime conversion.
+// - touch_unix.go: For Unix-like systems other than Windows, Darwin, FreeBSD, and NetBSD, including Linux and OpenBSD, uses syscall.Stat_t (including st_ctim) and unix.UtimesNanoAt.
+// - touch_bsd.go: For FreeBSD and NetBSD, uses syscall.Stat_t (Atimespec and Ctimespec) and unix.UtimesNanoAt.
+// - touch_darwin.go: For Darwin (macOS), uses syscall.Stat_t and unix.UtimesNanoAt, falling back to unix.Lutimes, and unix.Setattrlist for birth times.
+// - touch_atime_linux.go: For Linux, reads the access time with statx, falling back to st_atim when the kernel (before 4.11) or filesystem doesn't report it.
+// - touch_btime_linux.go: For Linux, reads the birth time with statx when the kernel (4.11 on) and filesystem record one.
+// - touch_btime_bsd.go: For FreeBSD and NetBSD, reads st_birthtim.
+// - touch_mount_linux.go: For Linux, reads /proc/self/mountinfo and the mount root's change time.
+// - touch_fstype_linux.go: For Linux, matches statfs magic numbers against NFS, SMB, CIFS, and FUSE.
+// - touch_boot_linux.go: For Linux, subtracts the uptime in /proc/uptime from the current time.
+// - touch_procfds_linux.go: For Linux, stats the files behind the /proc/PID/fd symlinks.
+// - touch_unit_linux.go: For Linux, reads a unit's ActiveEnterTimestamp from systemd over D-Bus with busctl.
+// - touch_journal_linux.go: For Linux, writes native protocol datagrams to the systemd journal socket.
+// - touch_windows.go: For Windows, uses windows.Win32FileAttributeData and a custom filetimeToTime conversion, and CreateFile with SetFileTime on reparse points and for creation times.
 //
 // This package is used by the core package to handle OS-specific logic in a modular way,
 // allowing the core Touch function to remain platform-agnostic.
@@ -32,15 +32,142 @@ type Time = time.Time
 // GetAtime retrieves the access time from file info, platform-specific.
 var GetAtime func(os.FileInfo) Time
 
+// GetAtimeAt retrieves the access time of the file at path, platform-specific, looking it up
+// again where the platform offers more than fileInfo carries. fileInfo must describe path as
+// just stat'd; noDeref selects the link itself for symlinks. Elsewhere it is GetAtime.
+var GetAtimeAt func(path string, fileInfo os.FileInfo, noDeref bool) Time
+
 // SetTimesNoDeref sets times without dereferencing symlinks, platform-specific.
 var SetTimesNoDeref func(string, Time, Time) error
 
+// SetTimesNoDerefSupported reports whether SetTimesNoDeref can set the times of symlinks
+// themselves on this platform.
+var SetTimesNoDerefSupported bool
+
+// GetDevice retrieves the ID of the device holding a file from its file info, platform-specific.
+// The boolean reports whether a device ID is available.
+var GetDevice func(os.FileInfo) (uint64, bool)
+
+// GetBtime retrieves the birth (creation) time of the file at path, platform-specific.
+// fileInfo must describe path; noDeref selects the link itself for symlinks where the
+// platform needs to look the file up again. The boolean reports whether a birth time
+// is available.
+var GetBtime func(path string, fileInfo os.FileInfo, noDeref bool) (Time, bool)
+
+// SetBtime sets the birth (creation) time of the file at path, platform-specific,
+// leaving its other times unchanged. noDeref selects the link itself for symlinks.
+var SetBtime func(path string, birthTime Time, noDeref bool) error
+
+// SetBtimeSupported reports whether SetBtime can set birth times on this platform.
+var SetBtimeSupported bool
+
+// GetCtime retrieves the status change time from file info, platform-specific.
+// The boolean reports whether a change time is available.
+var GetCtime func(os.FileInfo) (Time, bool)
+
+// GetMountTime approximates when the filesystem containing a path was mounted, platform-specific.
+var GetMountTime func(string) (Time, error)
+
+// FSType describes the filesystem containing a path.
+type FSType struct {
+	Name    string // Short name such as nfs or cifs, or the magic number in hex when unrecognized.
+	Network bool   // Whether the filesystem is served over the network.
+}
+
+// GetFSType returns the type of the filesystem containing a path, platform-specific.
+var GetFSType func(path string) (FSType, error)
+
+// GetBootTime approximates when the system booted as now minus uptime, platform-specific.
+var GetBootTime func() (Time, error)
+
+// GetProcFDsTime returns the newest modification time among the files a process has open,
+// platform-specific.
+var GetProcFDsTime func(pid int) (Time, error)
+
+// GetUnitActiveEnterTime returns when a systemd unit last entered the active state, as
+// systemd's ActiveEnterTimestamp, platform-specific.
+var GetUnitActiveEnterTime func(unit string) (Time, error)
+
+// JournalField is one KEY=value field of a system journal entry.
+type JournalField struct {
+	Key   string
+	Value string
+}
+
+// WriteJournal sends an entry made of fields to the system journal, platform-specific.
+var WriteJournal func(fields []JournalField) error
+
+// accessTimer is implemented by the Sys value of file info from file systems that store
+// access times themselves, such as filesystem.MemFS.
+type accessTimer interface {
+	AccessTime() Time
+}
+
+// sysAccessTime returns the access time carried by fileInfo's Sys value, if it has one
+// independent of the operating system.
+func sysAccessTime(fileInfo os.FileInfo) (Time, bool) {
+	if sys, ok := fileInfo.Sys().(accessTimer); ok {
+		return sys.AccessTime(), true
+	}
+
+	return Time{}, false
+}
+
 // init sets fallback implementations.
 func init() {
 	GetAtime = func(fileInfo os.FileInfo) Time {
+		if atime, ok := sysAccessTime(fileInfo); ok {
+			return atime
+		}
+
 		return fileInfo.ModTime() // Default: use mod time if access unavailable.
 	}
+
+	GetAtimeAt = func(_ string, fileInfo os.FileInfo, _ bool) Time {
+		return GetAtime(fileInfo) // Default: the stat data is all there is.
+	}
+
 	SetTimesNoDeref = func(_ string, _ Time, _ Time) error {
 		return errors.ErrNoDerefUnsupported // Default: unsupported.
 	}
+
+	GetBtime = func(_ string, _ os.FileInfo, _ bool) (Time, bool) {
+		return Time{}, false // Default: unavailable.
+	}
+
+	SetBtime = func(_ string, _ Time, _ bool) error {
+		return errors.ErrBirthTimeUnsupported // Default: unsupported.
+	}
+
+	GetCtime = func(_ os.FileInfo) (Time, bool) {
+		return Time{}, false // Default: unavailable.
+	}
+
+	GetDevice = func(_ os.FileInfo) (uint64, bool) {
+		return 0, false // Default: unavailable.
+	}
+
+	GetMountTime = func(_ string) (Time, error) {
+		return Time{}, errors.ErrMountTimeUnsupported // Default: unsupported.
+	}
+
+	GetFSType = func(_ string) (FSType, error) {
+		return FSType{}, errors.ErrFSTypeUnsupported // Default: unsupported.
+	}
+
+	GetBootTime = func() (Time, error) {
+		return Time{}, errors.ErrBootTimeUnsupported // Default: unsupported.
+	}
+
+	GetProcFDsTime = func(_ int) (Time, error) {
+		return Time{}, errors.ErrProcFDsUnsupported // Default: unsupported.
+	}
+
+	GetUnitActiveEnterTime = func(_ string) (Time, error) {
+		return Time{}, errors.ErrUnitReferenceUnsupported // Default: unsupported.
+	}
+
+	WriteJournal = func(_ []JournalField) error {
+		return errors.ErrJournalUnsupported // Default: unsupported.
+	}
 }
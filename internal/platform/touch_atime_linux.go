@@ -0,0 +1,63 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// init assigns the Linux implementation of GetAtimeAt, which queries statx for the access time.
+// It runs after the fallbacks in platform.go, as init functions run in file name order.
+func init() {
+	GetAtimeAt = func(path string, fileInfo os.FileInfo, noDeref bool) Time {
+		// Only files stat'd by the operating system can be looked up again by path; others,
+		// such as those of an in-memory file system, carry their access times themselves.
+		sysStat, ok := fileInfo.Sys().(*syscall.Stat_t)
+		if !ok {
+			return GetAtime(fileInfo)
+		}
+
+		flags := 0
+		if noDeref {
+			flags = unix.AT_SYMLINK_NOFOLLOW
+		}
+
+		// Kernels before 4.11 lack statx and fail with ENOSYS; the st_atim in fileInfo is then
+		// used instead, as it is for any other error.
+		var stx unix.Statx_t
+		if err := unix.Statx(unix.AT_FDCWD, path, flags, unix.STATX_ATIME, &stx); err != nil {
+			return GetAtime(fileInfo)
+		}
+
+		// A path relative to another root, as with an io/fs file system, may name a different
+		// file here, and some filesystems leave STATX_ATIME unset; use fileInfo for both.
+		//nolint:unconvert // Ino and Dev are narrower types on some architectures.
+		if stx.Mask&unix.STATX_ATIME == 0 || stx.Ino != uint64(sysStat.Ino) ||
+			unix.Mkdev(stx.Dev_major, stx.Dev_minor) != uint64(sysStat.Dev) {
+			return GetAtime(fileInfo)
+		}
+
+		return time.Unix(stx.Atime.Sec, int64(stx.Atime.Nsec))
+	}
+}
@@ -0,0 +1,116 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestGetAtimeAt(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.txt")
+	other := filepath.Join(dir, "other.txt")
+
+	// A sub-microsecond component shows that neither way loses precision the filesystem keeps.
+	atime := time.Date(2025, 7, 13, 14, 30, 0, 123456789, time.Local)
+	mtime := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	for _, name := range []string{path, other} {
+		if err := os.WriteFile(name, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.Chtimes(path, atime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(other, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	var stx unix.Statx_t
+	if err := unix.Statx(unix.AT_FDCWD, path, 0, unix.STATX_ATIME, &stx); err != nil {
+		t.Skipf("statx unavailable: %v", err)
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The st_atim GetAtime reads from stat must agree with statx's stx_atime.
+	statxAtime := time.Unix(stx.Atime.Sec, int64(stx.Atime.Nsec))
+	if got := GetAtime(fileInfo); !got.Equal(statxAtime) {
+		t.Fatalf("GetAtime() = %v, want statx atime %v", got, statxAtime)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		fileInfo os.FileInfo
+		want     Time
+	}{
+		{
+			name:     "statx access time",
+			path:     path,
+			fileInfo: fileInfo,
+			want:     statxAtime,
+		},
+		{
+			name:     "missing file falls back to stat data",
+			path:     filepath.Join(dir, "missing.txt"),
+			fileInfo: fileInfo,
+			want:     statxAtime,
+		},
+		{
+			name:     "different file falls back to stat data",
+			path:     other,
+			fileInfo: fileInfo,
+			want:     statxAtime,
+		},
+		{
+			// Without stat data, the file isn't looked up again and GetAtime's fallback applies.
+			name:     "not stat'd by the operating system",
+			path:     path,
+			fileInfo: sysLessFileInfo{fileInfo},
+			want:     mtime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetAtimeAt(tt.path, tt.fileInfo, false); !got.Equal(tt.want) {
+				t.Errorf("GetAtimeAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// sysLessFileInfo wraps a FileInfo, hiding its platform-specific Sys data.
+type sysLessFileInfo struct {
+	os.FileInfo
+}
+
+func (sysLessFileInfo) Sys() any { return nil }
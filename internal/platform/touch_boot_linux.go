@@ -0,0 +1,66 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// uptimePath is the uptime source consulted by GetBootTime, overridable in tests.
+var uptimePath = "/proc/uptime"
+
+// init assigns the Linux implementation of GetBootTime.
+// It runs after the fallbacks in platform.go, as init functions run in file name order.
+func init() {
+	GetBootTime = func() (Time, error) {
+		data, err := os.ReadFile(uptimePath)
+		if err != nil {
+			return Time{}, fmt.Errorf("read %s: %w", uptimePath, err)
+		}
+
+		uptime, err := parseUptime(string(data))
+		if err != nil {
+			return Time{}, err
+		}
+
+		return time.Now().Add(-uptime), nil
+	}
+}
+
+// parseUptime parses the first field of /proc/uptime, the seconds since boot.
+func parseUptime(data string) (time.Duration, error) {
+	fields := strings.Fields(data)
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("%w: empty", errors.ErrInvalidUptime)
+	}
+
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("%w: %q", errors.ErrInvalidUptime, fields[0])
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
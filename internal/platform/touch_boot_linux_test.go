@@ -0,0 +1,104 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetBootTime(t *testing.T) {
+	data, err := os.ReadFile(uptimePath)
+	if err != nil {
+		t.Skipf("uptime unavailable: %v", err)
+	}
+
+	uptime, err := parseUptime(string(data))
+	if err != nil {
+		t.Fatalf("parseUptime() error = %v", err)
+	}
+
+	before := time.Now()
+
+	got, err := GetBootTime()
+	if err != nil {
+		t.Fatalf("GetBootTime() error = %v", err)
+	}
+
+	if !got.Before(before) {
+		t.Errorf("GetBootTime() = %v, want earlier than now %v", got, before)
+	}
+
+	// Allow for the uptime advancing between the two reads of /proc/uptime.
+	if diff := before.Sub(got) - uptime; diff < -time.Second || diff > time.Second {
+		t.Errorf("GetBootTime() is %v before now, want roughly the uptime %v", before.Sub(got), uptime)
+	}
+}
+
+func Test_parseUptime(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name: "uptime and idle time",
+			data: "350735.47 234388.90\n",
+			want: 350735*time.Second + 470*time.Millisecond,
+		},
+		{
+			name: "whole seconds",
+			data: "42 10",
+			want: 42 * time.Second,
+		},
+		{
+			name:    "empty",
+			data:    "",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			data:    "abc 10",
+			wantErr: true,
+		},
+		{
+			name:    "negative",
+			data:    "-5 10",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseUptime(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseUptime() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			// Float parsing may be off by a fraction of a microsecond.
+			if diff := got - tt.want; diff < -time.Microsecond || diff > time.Microsecond {
+				t.Errorf("parseUptime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
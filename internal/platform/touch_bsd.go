@@ -0,0 +1,81 @@
+//go:build freebsd || netbsd
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package platform provides platform-specific implementations for timestamp operations.
+// It defines exported vars for GetAtime and SetTimesNoDeref, overridden by build tags.
+package platform
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// init assigns FreeBSD and NetBSD implementations for GetAtime, GetCtime, GetDevice, and SetTimesNoDeref.
+// Their syscall.Stat_t names the times Atimespec and Ctimespec, where Linux has Atim and Ctim.
+func init() {
+	GetAtime = func(fileInfo os.FileInfo) Time {
+		if atime, ok := sysAccessTime(fileInfo); ok {
+			return atime // In-memory file systems store access times themselves.
+		}
+
+		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
+			// Cast to int64 to support 32-bit architectures (386, arm) where Sec and Nsec are int32.
+			//nolint:unconvert // Necessary for 32-bit compatibility.
+			return time.Unix(int64(sysStat.Atimespec.Sec), int64(sysStat.Atimespec.Nsec))
+		}
+
+		return fileInfo.ModTime() // Fallback if cast fails.
+	}
+
+	GetCtime = func(fileInfo os.FileInfo) (Time, bool) {
+		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
+			//nolint:unconvert // Necessary for 32-bit compatibility.
+			return time.Unix(int64(sysStat.Ctimespec.Sec), int64(sysStat.Ctimespec.Nsec)), true
+		}
+
+		return Time{}, false
+	}
+
+	GetDevice = func(fileInfo os.FileInfo) (uint64, bool) {
+		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
+			//nolint:unconvert,gosec // Dev is a signed or narrower type on some platforms.
+			return uint64(sysStat.Dev), true
+		}
+
+		return 0, false
+	}
+
+	// Both provide utimensat, which honors AT_SYMLINK_NOFOLLOW (FreeBSD 10.3 and NetBSD 6 on).
+	SetTimesNoDerefSupported = true
+	SetTimesNoDeref = func(file string, accessTime, modTime Time) error {
+		ts := []unix.Timespec{
+			unix.NsecToTimespec(accessTime.UnixNano()),
+			unix.NsecToTimespec(modTime.UnixNano()),
+		}
+		if err := unix.UtimesNanoAt(unix.AT_FDCWD, file, ts, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+			return fmt.Errorf("utimesnanoat %s: %w", file, err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+//go:build freebsd || netbsd
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetAtime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	atime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.Local)
+	mtime := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	if err := os.Chtimes(path, atime, mtime); err != nil {
+		t.Fatal(err)
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The access time must come from Atimespec rather than the modification time fallback.
+	if got := GetAtime(fileInfo); !got.Equal(atime) {
+		t.Errorf("GetAtime() = %v, want %v", got, atime)
+	}
+}
+
+func TestSetTimesNoDeref(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	link := filepath.Join(dir, "link.txt")
+
+	if err := os.WriteFile(target, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	atime := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+	mtime := time.Date(2025, 7, 13, 15, 45, 0, 0, time.Local)
+
+	if err := SetTimesNoDeref(link, atime, mtime); err != nil {
+		t.Fatalf("SetTimesNoDeref() error = %v", err)
+	}
+
+	linkInfo, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := GetAtime(linkInfo); !got.Equal(atime) {
+		t.Errorf("SetTimesNoDeref() link atime = %v, want %v", got, atime)
+	}
+
+	if got := linkInfo.ModTime(); !got.Equal(mtime) {
+		t.Errorf("SetTimesNoDeref() link mtime = %v, want %v", got, mtime)
+	}
+
+	targetInfo, err := os.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if targetInfo.ModTime().Equal(mtime) {
+		t.Error("SetTimesNoDeref() changed the symlink target")
+	}
+}
@@ -0,0 +1,46 @@
+//go:build freebsd || netbsd
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// init assigns the FreeBSD and NetBSD implementation of GetBtime, reading st_birthtim.
+// It runs after the fallbacks in platform.go, as init functions run in file name order.
+func init() {
+	GetBtime = func(_ string, fileInfo os.FileInfo, _ bool) (Time, bool) {
+		sysStat, ok := fileInfo.Sys().(*syscall.Stat_t)
+		if !ok {
+			return Time{}, false
+		}
+
+		// Filesystems that don't record a birth time report -1 seconds.
+		if sysStat.Birthtimespec.Sec < 0 {
+			return Time{}, false
+		}
+
+		// Cast to int64 to support 32-bit architectures where Sec and Nsec are int32.
+		//nolint:unconvert // Necessary for 32-bit compatibility.
+		return time.Unix(int64(sysStat.Birthtimespec.Sec), int64(sysStat.Birthtimespec.Nsec)), true
+	}
+}
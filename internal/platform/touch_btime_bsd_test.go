@@ -0,0 +1,78 @@
+//go:build freebsd || netbsd
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetBtime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		fileInfo os.FileInfo
+		wantOK   bool
+	}{
+		{
+			name:     "available",
+			path:     path,
+			fileInfo: fileInfo,
+			wantOK:   true,
+		},
+		{
+			name:     "unavailable without platform stat data",
+			path:     path,
+			fileInfo: sysLessFileInfo{fileInfo},
+			wantOK:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetBtime(tt.path, tt.fileInfo, false)
+			if ok != tt.wantOK {
+				t.Fatalf("GetBtime() ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			if ok && (got.IsZero() || got.After(time.Now().Add(time.Minute))) {
+				t.Errorf("GetBtime() = %v, want a plausible past time", got)
+			}
+		})
+	}
+}
+
+// sysLessFileInfo wraps a FileInfo, hiding its platform-specific Sys data.
+type sysLessFileInfo struct {
+	os.FileInfo
+}
+
+func (sysLessFileInfo) Sys() any { return nil }
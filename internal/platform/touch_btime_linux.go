@@ -0,0 +1,52 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// init assigns the Linux implementation of GetBtime, which queries statx for the birth time.
+// It runs after the fallbacks in platform.go, as init functions run in file name order.
+func init() {
+	GetBtime = func(path string, _ os.FileInfo, noDeref bool) (Time, bool) {
+		flags := 0
+		if noDeref {
+			flags = unix.AT_SYMLINK_NOFOLLOW
+		}
+
+		// Kernels before 4.11 lack statx and fail with ENOSYS; the stat data in fileInfo has
+		// no birth time to fall back on, so it is reported as unavailable like any other error.
+		var stx unix.Statx_t
+		if err := unix.Statx(unix.AT_FDCWD, path, flags, unix.STATX_BTIME, &stx); err != nil {
+			return Time{}, false
+		}
+
+		// Filesystems that don't record a birth time leave STATX_BTIME unset in the result.
+		if stx.Mask&unix.STATX_BTIME == 0 {
+			return Time{}, false
+		}
+
+		return time.Unix(stx.Btime.Sec, int64(stx.Btime.Nsec)), true
+	}
+}
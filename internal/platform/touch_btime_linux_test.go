@@ -0,0 +1,89 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestGetBtime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Not every filesystem records a birth time; probe before asserting availability.
+	var stx unix.Statx_t
+	if err := unix.Statx(unix.AT_FDCWD, path, 0, unix.STATX_BTIME, &stx); err != nil {
+		t.Skipf("statx unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		wantOK bool
+	}{
+		{
+			name:   "available when recorded",
+			path:   path,
+			wantOK: stx.Mask&unix.STATX_BTIME != 0,
+		},
+		{
+			name:   "unavailable for missing file",
+			path:   filepath.Join(filepath.Dir(path), "missing.txt"),
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetBtime(tt.path, nil, false)
+			if ok != tt.wantOK {
+				t.Fatalf("GetBtime() ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			if ok && (got.IsZero() || got.After(time.Now().Add(time.Minute))) {
+				t.Errorf("GetBtime() = %v, want a plausible past time", got)
+			}
+		})
+	}
+}
+
+func TestSetBtime_unsupported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if SetBtimeSupported {
+		t.Error("SetBtimeSupported = true, want false")
+	}
+
+	if err := SetBtime(path, time.Now(), false); !errors.Is(err, touchErrors.ErrBirthTimeUnsupported) {
+		t.Errorf("SetBtime() error = %v, want %v", err, touchErrors.ErrBirthTimeUnsupported)
+	}
+}
@@ -20,6 +20,8 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package platform
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"os"
 	"syscall"
@@ -28,9 +30,13 @@ import (
 	"golang.org/x/sys/unix"
 )
 
-// init assigns Darwin-specific implementations for GetAtime and SetTimesNoDeref.
+// init assigns Darwin-specific implementations for GetAtime, GetBtime, SetBtime, GetCtime, GetDevice, and SetTimesNoDeref.
 func init() {
 	GetAtime = func(fileInfo os.FileInfo) Time {
+		if atime, ok := sysAccessTime(fileInfo); ok {
+			return atime // In-memory file systems store access times themselves.
+		}
+
 		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
 			return time.Unix(sysStat.Atimespec.Sec, sysStat.Atimespec.Nsec)
 		}
@@ -38,7 +44,69 @@ func init() {
 		return fileInfo.ModTime() // Fallback if cast fails.
 	}
 
+	GetBtime = func(_ string, fileInfo os.FileInfo, _ bool) (Time, bool) {
+		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
+			return time.Unix(sysStat.Birthtimespec.Sec, sysStat.Birthtimespec.Nsec), true
+		}
+
+		return Time{}, false
+	}
+
+	SetBtimeSupported = true
+	SetBtime = func(file string, birthTime Time, noDeref bool) error {
+		attrs := unix.Attrlist{Bitmapcount: unix.ATTR_BIT_MAP_COUNT, Commonattr: unix.ATTR_CMN_CRTIME}
+
+		// The attribute buffer holds a single struct timespec of two 64-bit fields.
+		ts := unix.NsecToTimespec(birthTime.UnixNano())
+		buf := binary.NativeEndian.AppendUint64(nil, uint64(ts.Sec))
+		buf = binary.NativeEndian.AppendUint64(buf, uint64(ts.Nsec))
+
+		options := 0
+		if noDeref {
+			options = unix.FSOPT_NOFOLLOW
+		}
+
+		if err := unix.Setattrlist(file, &attrs, buf, options); err != nil {
+			return fmt.Errorf("setattrlist %s: %w", file, err)
+		}
+
+		return nil
+	}
+
+	GetCtime = func(fileInfo os.FileInfo) (Time, bool) {
+		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
+			return time.Unix(sysStat.Ctimespec.Sec, sysStat.Ctimespec.Nsec), true
+		}
+
+		return Time{}, false
+	}
+
+	GetDevice = func(fileInfo os.FileInfo) (uint64, bool) {
+		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
+			//nolint:unconvert,gosec // Dev is a signed or narrower type on some platforms.
+			return uint64(sysStat.Dev), true
+		}
+
+		return 0, false
+	}
+
+	SetTimesNoDerefSupported = true
 	SetTimesNoDeref = func(file string, accessTime, modTime Time) error {
+		ts := []unix.Timespec{
+			unix.NsecToTimespec(accessTime.UnixNano()),
+			unix.NsecToTimespec(modTime.UnixNano()),
+		}
+
+		err := unix.UtimesNanoAt(unix.AT_FDCWD, file, ts, unix.AT_SYMLINK_NOFOLLOW)
+		if err == nil {
+			return nil
+		}
+
+		// utimensat arrived in macOS 10.13; older systems only offer microsecond lutimes.
+		if !errors.Is(err, unix.ENOSYS) {
+			return fmt.Errorf("utimesnanoat %s: %w", file, err)
+		}
+
 		timevals := []unix.Timeval{
 			{Sec: accessTime.Unix(), Usec: int32(accessTime.UnixMicro() % 1000000)},
 			{Sec: modTime.Unix(), Usec: int32(modTime.UnixMicro() % 1000000)},
@@ -0,0 +1,175 @@
+//go:build darwin
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetBtime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		fileInfo os.FileInfo
+		wantOK   bool
+	}{
+		{
+			name:     "available",
+			path:     path,
+			fileInfo: fileInfo,
+			wantOK:   true,
+		},
+		{
+			name:     "unavailable without platform stat data",
+			path:     path,
+			fileInfo: sysLessFileInfo{fileInfo},
+			wantOK:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetBtime(tt.path, tt.fileInfo, false)
+			if ok != tt.wantOK {
+				t.Fatalf("GetBtime() ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			if ok && (got.IsZero() || got.After(time.Now().Add(time.Minute))) {
+				t.Errorf("GetBtime() = %v, want a plausible past time", got)
+			}
+		})
+	}
+}
+
+// sysLessFileInfo wraps a FileInfo, hiding its platform-specific Sys data.
+type sysLessFileInfo struct {
+	os.FileInfo
+}
+
+func (sysLessFileInfo) Sys() any { return nil }
+
+func TestSetBtime(t *testing.T) {
+	dir := t.TempDir()
+	birth := time.Date(2020, 1, 2, 3, 4, 5, 0, time.Local)
+	mtime := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		file    string
+		create  bool
+		wantErr bool
+	}{
+		{name: "existing file", file: "file.txt", create: true, wantErr: false},
+		{name: "missing file", file: "missing.txt", create: false, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+
+			if tt.create {
+				if err := os.WriteFile(path, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.Chtimes(path, mtime, mtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := SetBtime(path, birth, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetBtime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			fileInfo, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, ok := GetBtime(path, fileInfo, false); !ok || !got.Equal(birth) {
+				t.Errorf("SetBtime() birth time = %v (ok %v), want %v", got, ok, birth)
+			}
+
+			if got := fileInfo.ModTime(); !got.Equal(mtime) {
+				t.Errorf("SetBtime() changed mtime to %v, want %v", got, mtime)
+			}
+		})
+	}
+}
+
+func TestSetTimesNoDeref_nanoseconds(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	link := filepath.Join(dir, "link.txt")
+
+	if err := os.WriteFile(target, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	// A sub-microsecond component would be lost by the microsecond lutimes call.
+	atime := time.Date(2025, 7, 13, 14, 30, 0, 123456789, time.Local)
+	mtime := time.Date(2025, 7, 13, 15, 45, 0, 987654321, time.Local)
+
+	if err := SetTimesNoDeref(link, atime, mtime); err != nil {
+		t.Fatalf("SetTimesNoDeref() error = %v", err)
+	}
+
+	linkInfo, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := GetAtime(linkInfo); !got.Equal(atime) {
+		t.Errorf("SetTimesNoDeref() link atime = %v, want %v", got, atime)
+	}
+
+	if got := linkInfo.ModTime(); !got.Equal(mtime) {
+		t.Errorf("SetTimesNoDeref() link mtime = %v, want %v", got, mtime)
+	}
+
+	targetInfo, err := os.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if targetInfo.ModTime().Equal(mtime) {
+		t.Error("SetTimesNoDeref() changed the symlink target")
+	}
+}
@@ -0,0 +1,57 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// statfs is the statfs call used by GetFSType, overridable in tests.
+var statfs = unix.Statfs
+
+// networkFSTypes names the statfs magic numbers of filesystems served over the network.
+var networkFSTypes = map[uint32]string{
+	unix.NFS_SUPER_MAGIC:  "nfs",
+	unix.SMB_SUPER_MAGIC:  "smb",
+	unix.SMB2_SUPER_MAGIC: "smb2",
+	unix.CIFS_SUPER_MAGIC: "cifs",
+	unix.FUSE_SUPER_MAGIC: "fuse",
+}
+
+// init assigns the Linux implementation of GetFSType.
+// It runs after the fallbacks in platform.go, as init functions run in file name order.
+func init() {
+	GetFSType = func(path string) (FSType, error) {
+		var stat unix.Statfs_t
+		if err := statfs(path, &stat); err != nil {
+			return FSType{}, fmt.Errorf("statfs %s: %w", path, err)
+		}
+
+		// The magic number is 32 bits wide, though some architectures store it in a wider field.
+		magic := uint32(stat.Type) //nolint:gosec // Truncation keeps exactly the magic number.
+		if name, ok := networkFSTypes[magic]; ok {
+			return FSType{Name: name, Network: true}, nil
+		}
+
+		return FSType{Name: fmt.Sprintf("%#x", magic), Network: false}, nil
+	}
+}
@@ -0,0 +1,88 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestGetFSType(t *testing.T) {
+	tests := []struct {
+		name    string
+		magic   int64
+		err     error
+		want    FSType
+		wantErr error
+	}{
+		{name: "nfs", magic: unix.NFS_SUPER_MAGIC, want: FSType{Name: "nfs", Network: true}},
+		{name: "cifs", magic: unix.CIFS_SUPER_MAGIC, want: FSType{Name: "cifs", Network: true}},
+		{name: "smb2", magic: unix.SMB2_SUPER_MAGIC, want: FSType{Name: "smb2", Network: true}},
+		{name: "fuse", magic: unix.FUSE_SUPER_MAGIC, want: FSType{Name: "fuse", Network: true}},
+		{name: "ext4 is local", magic: unix.EXT4_SUPER_MAGIC, want: FSType{Name: "0xef53", Network: false}},
+		{name: "tmpfs is local", magic: unix.TMPFS_MAGIC, want: FSType{Name: "0x1021994", Network: false}},
+		{name: "statfs failure", err: unix.ENOENT, wantErr: os.ErrNotExist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStatfs := statfs
+
+			defer func() { statfs = oldStatfs }()
+
+			statfs = func(path string, stat *unix.Statfs_t) error {
+				if path != "/mnt/share/file.txt" {
+					t.Errorf("statfs() path = %q, want %q", path, "/mnt/share/file.txt")
+				}
+
+				stat.Type = fsMagic(stat.Type, tt.magic)
+
+				return tt.err
+			}
+
+			got, err := GetFSType("/mnt/share/file.txt")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetFSType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("GetFSType() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fsMagic converts magic to the type of Statfs_t.Type, which varies by architecture.
+func fsMagic[T int32 | uint32 | int64](_ T, magic int64) T {
+	return T(magic)
+}
+
+func TestGetFSType_realPath(t *testing.T) {
+	got, err := GetFSType(t.TempDir())
+	if err != nil {
+		t.Fatalf("GetFSType() error = %v", err)
+	}
+
+	if got.Name == "" {
+		t.Errorf("GetFSType() = %+v, want a named filesystem type", got)
+	}
+}
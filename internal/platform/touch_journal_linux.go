@@ -0,0 +1,71 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// journalSocket is the systemd journal's native protocol socket, overridable in tests.
+var journalSocket = "/run/systemd/journal/socket"
+
+// init assigns the Linux implementation of WriteJournal, which speaks the journal's
+// native protocol. It runs after the fallbacks in platform.go, as init functions run
+// in file name order.
+func init() {
+	WriteJournal = func(fields []JournalField) error {
+		conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: journalSocket, Net: "unixgram"})
+		if err != nil {
+			return fmt.Errorf("connect to journal %s: %w", journalSocket, err)
+		}
+		defer conn.Close()
+
+		if _, err := conn.Write(encodeJournalFields(fields)); err != nil {
+			return fmt.Errorf("write to journal %s: %w", journalSocket, err)
+		}
+
+		return nil
+	}
+}
+
+// encodeJournalFields encodes fields as one native protocol datagram. Single-line values
+// are sent as KEY=value; values containing a newline use the binary form, the key and a
+// newline followed by the value's little-endian 64-bit length, the value, and a newline.
+func encodeJournalFields(fields []JournalField) []byte {
+	var buf bytes.Buffer
+
+	for _, field := range fields {
+		if !strings.Contains(field.Value, "\n") {
+			buf.WriteString(field.Key + "=" + field.Value + "\n")
+
+			continue
+		}
+
+		buf.WriteString(field.Key + "\n")
+		_ = binary.Write(&buf, binary.LittleEndian, uint64(len(field.Value))) // Writes to a bytes.Buffer can't fail.
+		buf.WriteString(field.Value + "\n")
+	}
+
+	return buf.Bytes()
+}
@@ -0,0 +1,106 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"bytes"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteJournal(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []JournalField
+		want   []byte
+	}{
+		{
+			name: "single-line fields",
+			fields: []JournalField{
+				{Key: "MESSAGE", Value: "touched file.txt"},
+				{Key: "PRIORITY", Value: "6"},
+			},
+			want: []byte("MESSAGE=touched file.txt\nPRIORITY=6\n"),
+		},
+		{
+			name: "multi-line value uses the binary form",
+			fields: []JournalField{
+				{Key: "MESSAGE", Value: "a\nb"},
+				{Key: "PRIORITY", Value: "6"},
+			},
+			want: []byte("MESSAGE\n\x03\x00\x00\x00\x00\x00\x00\x00a\nb\nPRIORITY=6\n"),
+		},
+		{
+			name:   "no fields",
+			fields: nil,
+			want:   []byte{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			socket := filepath.Join(t.TempDir(), "journal.sock")
+
+			listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+			if err != nil {
+				t.Skipf("unix datagram sockets unavailable: %v", err)
+			}
+			defer listener.Close()
+
+			oldSocket := journalSocket
+
+			defer func() { journalSocket = oldSocket }()
+
+			journalSocket = socket
+
+			if err := WriteJournal(tt.fields); err != nil {
+				t.Fatalf("WriteJournal() error = %v", err)
+			}
+
+			if err := listener.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+				t.Fatal(err)
+			}
+
+			buf := make([]byte, 4096)
+
+			n, _, err := listener.ReadFromUnix(buf)
+			if err != nil {
+				t.Fatalf("read datagram: %v", err)
+			}
+
+			if got := buf[:n]; !bytes.Equal(got, tt.want) {
+				t.Errorf("WriteJournal() sent %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteJournal_missingSocket(t *testing.T) {
+	oldSocket := journalSocket
+
+	defer func() { journalSocket = oldSocket }()
+
+	journalSocket = filepath.Join(t.TempDir(), "missing.sock")
+
+	if err := WriteJournal([]JournalField{{Key: "MESSAGE", Value: "lost"}}); err == nil {
+		t.Error("WriteJournal() error = nil, want an error for a missing socket")
+	}
+}
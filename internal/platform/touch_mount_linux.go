@@ -0,0 +1,138 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// Constants for parsing /proc/self/mountinfo.
+const (
+	mountInfoMountPointField = 4 // Zero-based index of the mount point field.
+	mountInfoOctalEscapeLen  = 4 // Length of an octal escape such as \040.
+)
+
+// mountInfoPath is the mount table consulted by GetMountTime, overridable in tests.
+var mountInfoPath = "/proc/self/mountinfo"
+
+// init assigns the Linux implementation of GetMountTime.
+// It runs after the fallbacks in platform.go, as init functions run in file name order.
+func init() {
+	GetMountTime = func(path string) (Time, error) {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return Time{}, fmt.Errorf("resolve %s: %w", path, err)
+		}
+
+		resolved, err := filepath.EvalSymlinks(absPath)
+		if err != nil {
+			return Time{}, fmt.Errorf("resolve %s: %w", path, err)
+		}
+
+		mountPoint, err := findMountPoint(resolved)
+		if err != nil {
+			return Time{}, err
+		}
+
+		// The kernel does not record mount times, so use the change time of the
+		// mounted filesystem's root inode as the closest available approximation.
+		var stat unix.Stat_t
+		if err := unix.Stat(mountPoint, &stat); err != nil {
+			return Time{}, fmt.Errorf("stat mount point %s: %w", mountPoint, err)
+		}
+
+		//nolint:unconvert // Necessary for 32-bit compatibility.
+		return time.Unix(int64(stat.Ctim.Sec), int64(stat.Ctim.Nsec)), nil
+	}
+}
+
+// findMountPoint returns the longest mount point in the mount table that contains path.
+func findMountPoint(path string) (string, error) {
+	file, err := os.Open(mountInfoPath)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", mountInfoPath, err)
+	}
+	defer file.Close()
+
+	best := ""
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) <= mountInfoMountPointField {
+			continue
+		}
+
+		mountPoint := unescapeMountField(fields[mountInfoMountPointField])
+		if containsPath(mountPoint, path) && len(mountPoint) > len(best) {
+			best = mountPoint
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("read %s: %w", mountInfoPath, err)
+	}
+
+	if best == "" {
+		return "", fmt.Errorf("%w: %s", errors.ErrMountPointNotFound, path)
+	}
+
+	return best, nil
+}
+
+// containsPath reports whether path is mountPoint or lies beneath it.
+func containsPath(mountPoint, path string) bool {
+	if mountPoint == "/" || mountPoint == path {
+		return true
+	}
+
+	return strings.HasPrefix(path, mountPoint+"/")
+}
+
+// unescapeMountField decodes the octal escapes (e.g. \040 for space) used in mountinfo.
+func unescapeMountField(field string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+mountInfoOctalEscapeLen <= len(field) {
+			if value, err := strconv.ParseUint(field[i+1:i+mountInfoOctalEscapeLen], 8, 8); err == nil {
+				builder.WriteByte(byte(value))
+
+				i += mountInfoOctalEscapeLen - 1
+
+				continue
+			}
+		}
+
+		builder.WriteByte(field[i])
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,94 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetMountTime(t *testing.T) {
+	if _, err := os.Stat(mountInfoPath); err != nil {
+		t.Skipf("mount table unavailable: %v", err)
+	}
+
+	got, err := GetMountTime(t.TempDir())
+	if err != nil {
+		t.Fatalf("GetMountTime() error = %v", err)
+	}
+
+	if got.IsZero() || got.After(time.Now().Add(time.Minute)) {
+		t.Errorf("GetMountTime() = %v, want a plausible past time", got)
+	}
+}
+
+func Test_findMountPoint(t *testing.T) {
+	mountInfo := "22 1 8:1 / / rw,relatime - ext4 /dev/sda1 rw\n" +
+		"30 22 0:25 / /mnt/data rw,relatime - ext4 /dev/sdb1 rw\n" +
+		"31 22 0:26 / /mnt/my\\040disk rw,relatime - ext4 /dev/sdc1 rw\n"
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "nested under mount",
+			path: "/mnt/data/logs/app.log",
+			want: "/mnt/data",
+		},
+		{
+			name: "sibling prefix is not a match",
+			path: "/mnt/database/file",
+			want: "/",
+		},
+		{
+			name: "escaped space in mount point",
+			path: "/mnt/my disk/file",
+			want: "/mnt/my disk",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldPath := mountInfoPath
+
+			defer func() { mountInfoPath = oldPath }()
+
+			mountInfoPath = filepath.Join(t.TempDir(), "mountinfo")
+			if err := os.WriteFile(mountInfoPath, []byte(mountInfo), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := findMountPoint(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("findMountPoint() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("findMountPoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
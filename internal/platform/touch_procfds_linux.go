@@ -0,0 +1,106 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// procRoot is the proc filesystem consulted by GetProcFDsTime, overridable in tests.
+var procRoot = "/proc"
+
+// init assigns the Linux implementation of GetProcFDsTime.
+// It runs after the fallbacks in platform.go, as init functions run in file name order.
+func init() {
+	GetProcFDsTime = func(pid int) (Time, error) {
+		fdDir := filepath.Join(procRoot, strconv.Itoa(pid), "fd")
+
+		entries, err := os.ReadDir(fdDir)
+		if err != nil {
+			return Time{}, fmt.Errorf("read open files of process %d: %w", pid, err)
+		}
+
+		var (
+			newest Time
+			found  bool
+		)
+
+		for _, entry := range entries {
+			fdPath := filepath.Join(fdDir, entry.Name())
+
+			modTime, ok, err := openFileModTime(fdPath)
+			if err != nil {
+				return Time{}, err
+			}
+
+			if ok && (!found || modTime.After(newest)) {
+				newest, found = modTime, true
+			}
+		}
+
+		if !found {
+			return Time{}, fmt.Errorf("%w: process %d", touchErrors.ErrNoOpenFiles, pid)
+		}
+
+		return newest, nil
+	}
+}
+
+// openFileModTime returns the modification time of the regular file open on the
+// /proc/PID/fd symlink fdPath. It reports false for descriptors that aren't regular files,
+// such as pipes, sockets, and devices, and for descriptors closed since they were listed.
+func openFileModTime(fdPath string) (Time, bool, error) {
+	target, err := os.Readlink(fdPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return Time{}, false, nil
+	}
+
+	if err != nil {
+		return Time{}, false, fmt.Errorf("read open file %s: %w", fdPath, err)
+	}
+
+	// Pipes, sockets, and anonymous inodes have targets such as "pipe:[1234]".
+	if !strings.HasPrefix(target, "/") {
+		return Time{}, false, nil
+	}
+
+	// Stat through the descriptor so renamed and deleted files are still found.
+	info, err := os.Stat(fdPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return Time{}, false, nil
+	}
+
+	if err != nil {
+		return Time{}, false, fmt.Errorf("stat open file %s: %w", target, err)
+	}
+
+	if !info.Mode().IsRegular() {
+		return Time{}, false, nil
+	}
+
+	return info.ModTime(), true, nil
+}
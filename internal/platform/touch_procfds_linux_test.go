@@ -0,0 +1,171 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestGetProcFDsTime_self(t *testing.T) {
+	if _, err := os.ReadDir("/proc/self/fd"); err != nil {
+		t.Skipf("open files of this process unavailable: %v", err)
+	}
+
+	dir := t.TempDir()
+	newest := time.Date(2100, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Far-future times so the files outrank anything else this process has open.
+	for name, modTime := range map[string]time.Time{
+		"open.txt":    newest.Add(-time.Hour),
+		"deleted.txt": newest,
+	} {
+		path := filepath.Join(dir, name)
+
+		file, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer file.Close()
+
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A deleted file held open is still found through its descriptor.
+	if err := os.Remove(filepath.Join(dir, "deleted.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetProcFDsTime(os.Getpid())
+	if err != nil {
+		t.Fatalf("GetProcFDsTime() error = %v", err)
+	}
+
+	if !got.Equal(newest) {
+		t.Errorf("GetProcFDsTime() = %v, want %v", got, newest)
+	}
+}
+
+func TestGetProcFDsTime(t *testing.T) {
+	oldProcRoot := procRoot
+
+	defer func() { procRoot = oldProcRoot }()
+
+	procRoot = t.TempDir()
+	files := t.TempDir()
+	older := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC)
+
+	for name, modTime := range map[string]time.Time{"older.txt": older, "newer.txt": newer} {
+		path := filepath.Join(files, name)
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// fakeProcess lays out /proc/PID/fd with symlinks named by descriptor number.
+	fakeProcess := func(pid string, targets ...string) {
+		fdDir := filepath.Join(procRoot, pid, "fd")
+		if err := os.MkdirAll(fdDir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		for fd, target := range targets {
+			if err := os.Symlink(target, filepath.Join(fdDir, string(rune('0'+fd)))); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	fakeProcess("100",
+		filepath.Join(files, "older.txt"),
+		"pipe:[1234]",
+		filepath.Join(files, "newer.txt"),
+		"socket:[5678]",
+		files,                              // An open directory is skipped.
+		filepath.Join(files, "closed.txt"), // Closed since listing.
+	)
+	fakeProcess("200", "pipe:[1]", "anon_inode:[eventfd]", files)
+
+	tests := []struct {
+		name    string
+		pid     int
+		want    Time
+		wantErr error
+	}{
+		{
+			name:    "newest regular file",
+			pid:     100,
+			want:    newer,
+			wantErr: nil,
+		},
+		{
+			name:    "no open regular files",
+			pid:     200,
+			want:    Time{},
+			wantErr: touchErrors.ErrNoOpenFiles,
+		},
+		{
+			name:    "missing process",
+			pid:     300,
+			want:    Time{},
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetProcFDsTime(tt.pid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetProcFDsTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetProcFDsTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetProcFDsTime_permission(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("running as root; other processes' open files are readable")
+	}
+
+	// The init process belongs to root, so its descriptors are hidden from other users.
+	_, err := GetProcFDsTime(1)
+	if err == nil {
+		t.Skip("open files of process 1 are readable here")
+	}
+
+	if !errors.Is(err, os.ErrPermission) {
+		t.Errorf("GetProcFDsTime(1) error = %v, want %v", err, os.ErrPermission)
+	}
+}
@@ -0,0 +1,184 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// systemdQueryTimeout bounds each query of systemd over D-Bus.
+const systemdQueryTimeout = 10 * time.Second
+
+// systemd's D-Bus service, manager object, and interfaces.
+const (
+	systemdService   = "org.freedesktop.systemd1"
+	systemdPath      = "/org/freedesktop/systemd1"
+	systemdManager   = "org.freedesktop.systemd1.Manager"
+	systemdUnit      = "org.freedesktop.systemd1.Unit"
+	activeEnterField = "ActiveEnterTimestamp"
+)
+
+// unitTypes are the unit name suffixes systemd recognizes; other names are taken as services.
+var unitTypes = []string{
+	".service", ".socket", ".target", ".device", ".mount", ".automount",
+	".swap", ".timer", ".path", ".slice", ".scope",
+}
+
+// systemdClient queries units and their properties from systemd over D-Bus.
+type systemdClient interface {
+	// UnitPath returns the object path of a loaded unit, failing with ErrUnknownUnit
+	// when systemd has no unit of that name.
+	UnitPath(name string) (string, error)
+	// UnitTimestamp returns a unit's timestamp property in microseconds since the epoch.
+	UnitTimestamp(unitPath, property string) (uint64, error)
+}
+
+// systemdBus is the systemdClient used by GetUnitActiveEnterTime, overridable in tests.
+var systemdBus systemdClient = busctlClient{timeout: systemdQueryTimeout}
+
+// init assigns the Linux implementation of GetUnitActiveEnterTime.
+// It runs after the fallbacks in platform.go, as init functions run in file name order.
+func init() {
+	GetUnitActiveEnterTime = func(unit string) (Time, error) {
+		name := unitName(unit)
+
+		unitPath, err := systemdBus.UnitPath(name)
+		if err != nil {
+			return Time{}, err
+		}
+
+		usec, err := systemdBus.UnitTimestamp(unitPath, activeEnterField)
+		if err != nil {
+			return Time{}, fmt.Errorf("read %s of %s: %w", activeEnterField, name, err)
+		}
+
+		if usec == 0 {
+			return Time{}, fmt.Errorf("%w: %s", errors.ErrUnitNeverActive, name)
+		}
+
+		if usec > math.MaxInt64 {
+			return Time{}, fmt.Errorf("%w: %s %d", errors.ErrInvalidBusReply, activeEnterField, usec)
+		}
+
+		return time.UnixMicro(int64(usec)), nil
+	}
+}
+
+// unitName completes a name without a unit type suffix as a service, as systemctl does.
+func unitName(unit string) string {
+	if slices.Contains(unitTypes, path.Ext(unit)) {
+		return unit
+	}
+
+	return unit + ".service"
+}
+
+// busctlClient implements systemdClient with busctl on the system bus.
+type busctlClient struct {
+	timeout time.Duration
+}
+
+// UnitPath calls the manager's GetUnit method, which fails for units systemd hasn't loaded.
+func (b busctlClient) UnitPath(name string) (string, error) {
+	output, stderr, err := b.run("call", systemdService, systemdPath, systemdManager, "GetUnit", "s", name)
+	if err != nil {
+		if strings.Contains(stderr, "not loaded") {
+			return "", fmt.Errorf("%w: %s", errors.ErrUnknownUnit, name)
+		}
+
+		return "", err
+	}
+
+	value, err := parseBusReply(output, "o")
+	if err != nil {
+		return "", err
+	}
+
+	unitPath, err := strconv.Unquote(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", errors.ErrInvalidBusReply, output)
+	}
+
+	return unitPath, nil
+}
+
+// UnitTimestamp reads the uint64 property of the unit at unitPath.
+func (b busctlClient) UnitTimestamp(unitPath, property string) (uint64, error) {
+	output, _, err := b.run("get-property", systemdService, unitPath, systemdUnit, property)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := parseBusReply(output, "t")
+	if err != nil {
+		return 0, err
+	}
+
+	usec, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", errors.ErrInvalidBusReply, output)
+	}
+
+	return usec, nil
+}
+
+// run runs busctl on the system bus with args, returning its standard output and error.
+func (b busctlClient) run(args ...string) (string, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "busctl", append([]string{"--system", "--"}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", "", fmt.Errorf("busctl timed out after %v: %w", b.timeout, ctx.Err())
+		}
+
+		return "", stderr.String(), fmt.Errorf("busctl: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), stderr.String(), nil
+}
+
+// parseBusReply returns the value of a single-value busctl reply such as `t 1752416200000000`,
+// checking that its D-Bus type signature is signature.
+func parseBusReply(output, signature string) (string, error) {
+	gotSignature, value, ok := strings.Cut(strings.TrimSpace(output), " ")
+	if !ok || gotSignature != signature || value == "" {
+		return "", fmt.Errorf("%w: %q", errors.ErrInvalidBusReply, output)
+	}
+
+	return value, nil
+}
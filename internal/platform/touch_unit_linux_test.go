@@ -0,0 +1,162 @@
+//go:build linux
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// fakeSystemd is a systemdClient serving units from maps instead of D-Bus.
+type fakeSystemd struct {
+	paths      map[string]string // Object path of each loaded unit by name.
+	timestamps map[string]uint64 // ActiveEnterTimestamp of each unit by object path.
+}
+
+func (f fakeSystemd) UnitPath(name string) (string, error) {
+	unitPath, ok := f.paths[name]
+	if !ok {
+		return "", touchErrors.ErrUnknownUnit
+	}
+
+	return unitPath, nil
+}
+
+func (f fakeSystemd) UnitTimestamp(unitPath, property string) (uint64, error) {
+	if property != activeEnterField {
+		return 0, touchErrors.ErrInvalidBusReply
+	}
+
+	return f.timestamps[unitPath], nil
+}
+
+func TestGetUnitActiveEnterTime(t *testing.T) {
+	started := time.Date(2025, 7, 13, 14, 16, 40, 123456000, time.UTC)
+
+	origBus := systemdBus
+	systemdBus = fakeSystemd{
+		paths: map[string]string{
+			"sshd.service":    "/org/freedesktop/systemd1/unit/sshd_2eservice",
+			"backup.timer":    "/org/freedesktop/systemd1/unit/backup_2etimer",
+			"stopped.service": "/org/freedesktop/systemd1/unit/stopped_2eservice",
+		},
+		timestamps: map[string]uint64{
+			"/org/freedesktop/systemd1/unit/sshd_2eservice": uint64(started.UnixMicro()),
+			"/org/freedesktop/systemd1/unit/backup_2etimer": uint64(started.Add(time.Hour).UnixMicro()),
+		},
+	}
+
+	defer func() { systemdBus = origBus }()
+
+	tests := []struct {
+		name    string
+		unit    string
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name: "service",
+			unit: "sshd.service",
+			want: started,
+		},
+		{
+			name: "bare name is a service",
+			unit: "sshd",
+			want: started,
+		},
+		{
+			name: "other unit type",
+			unit: "backup.timer",
+			want: started.Add(time.Hour),
+		},
+		{
+			name:    "unknown unit",
+			unit:    "missing.service",
+			wantErr: touchErrors.ErrUnknownUnit,
+		},
+		{
+			name:    "never active",
+			unit:    "stopped",
+			wantErr: touchErrors.ErrUnitNeverActive,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetUnitActiveEnterTime(tt.unit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetUnitActiveEnterTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetUnitActiveEnterTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseBusReply(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		signature string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "timestamp",
+			output:    "t 1752416200123456\n",
+			signature: "t",
+			want:      "1752416200123456",
+		},
+		{
+			name:      "object path",
+			output:    `o "/org/freedesktop/systemd1/unit/sshd_2eservice"`,
+			signature: "o",
+			want:      `"/org/freedesktop/systemd1/unit/sshd_2eservice"`,
+		},
+		{
+			name:      "wrong signature",
+			output:    "s \"active\"",
+			signature: "t",
+			wantErr:   true,
+		},
+		{
+			name:      "no value",
+			output:    "t",
+			signature: "t",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBusReply(tt.output, tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBusReply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("parseBusReply() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-//go:build !windows && !darwin
+//go:build !windows && !darwin && !freebsd && !netbsd
 
 /*
 Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
@@ -30,9 +30,13 @@ import (
 	"golang.org/x/sys/unix"
 )
 
-// init assigns Unix-specific (non-Darwin) implementations for GetAtime and SetTimesNoDeref.
+// init assigns Unix-specific (non-Darwin, non-FreeBSD, non-NetBSD) implementations for GetAtime, GetCtime, GetDevice, and SetTimesNoDeref.
 func init() {
 	GetAtime = func(fileInfo os.FileInfo) Time {
+		if atime, ok := sysAccessTime(fileInfo); ok {
+			return atime // In-memory file systems store access times themselves.
+		}
+
 		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
 			// Cast to int64 to support 32-bit architectures (386, arm) where Sec and Nsec are int32.
 			// On 64-bit systems, these are already int64, but the cast is safe and avoids type errors.
@@ -43,6 +47,25 @@ func init() {
 		return fileInfo.ModTime() // Fallback if cast fails.
 	}
 
+	GetCtime = func(fileInfo os.FileInfo) (Time, bool) {
+		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
+			//nolint:unconvert // Necessary for 32-bit compatibility.
+			return time.Unix(int64(sysStat.Ctim.Sec), int64(sysStat.Ctim.Nsec)), true
+		}
+
+		return Time{}, false
+	}
+
+	GetDevice = func(fileInfo os.FileInfo) (uint64, bool) {
+		if sysStat, ok := fileInfo.Sys().(*syscall.Stat_t); ok {
+			//nolint:unconvert,gosec // Dev is a signed or narrower type on some platforms.
+			return uint64(sysStat.Dev), true
+		}
+
+		return 0, false
+	}
+
+	SetTimesNoDerefSupported = true
 	SetTimesNoDeref = func(file string, accessTime, modTime Time) error {
 		ts := []unix.Timespec{
 			unix.NsecToTimespec(accessTime.UnixNano()),
@@ -0,0 +1,83 @@
+//go:build !windows && !darwin
+
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetCtime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Backdating the modification time leaves the change time at the moment of the change.
+	old := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := GetCtime(fileInfo)
+	if !ok {
+		t.Fatal("GetCtime() ok = false, want true")
+	}
+
+	if !got.After(old) || got.After(time.Now().Add(time.Minute)) {
+		t.Errorf("GetCtime() = %v, want a recent time after the backdated mtime %v", got, old)
+	}
+}
+
+func TestGetDevice(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "file.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dirInfo, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileDevice, ok := GetDevice(fileInfo)
+	if !ok {
+		t.Fatal("GetDevice() ok = false, want true")
+	}
+
+	// A file lives on the same device as its directory.
+	if dirDevice, _ := GetDevice(dirInfo); fileDevice != dirDevice {
+		t.Errorf("GetDevice() = %d for the file, %d for its directory, want equal", fileDevice, dirDevice)
+	}
+}
@@ -20,10 +20,14 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package platform
 
 import (
+	"fmt"
 	"os"
+	"syscall"
 	"time"
 
 	"golang.org/x/sys/windows"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
 )
 
 // Constants for filetimeToTime calculations.
@@ -34,15 +38,101 @@ const (
 	EpochOffset100ns        = 116444736000000000 // 100ns intervals from 1601 to 1970.
 )
 
-// init assigns Windows-specific implementations for GetAtime and SetTimesNoDeref.
+// init assigns Windows-specific implementations for GetAtime, GetBtime, SetBtime, and SetTimesNoDeref.
 func init() {
 	GetAtime = func(fileInfo os.FileInfo) Time {
+		if atime, ok := sysAccessTime(fileInfo); ok {
+			return atime // In-memory file systems store access times themselves.
+		}
+
 		if winStat, ok := fileInfo.Sys().(*windows.Win32FileAttributeData); ok {
 			return filetimeToTime(winStat.LastAccessTime)
 		}
 
 		return fileInfo.ModTime() // Fallback if cast fails.
 	}
+
+	GetBtime = func(_ string, fileInfo os.FileInfo, _ bool) (Time, bool) {
+		// os.Stat reports syscall's attribute data; accept the x/sys variant as well.
+		switch winStat := fileInfo.Sys().(type) {
+		case *syscall.Win32FileAttributeData:
+			return filetimeToTime(windows.Filetime(winStat.CreationTime)), true
+		case *windows.Win32FileAttributeData:
+			return filetimeToTime(winStat.CreationTime), true
+		default:
+			return Time{}, false
+		}
+	}
+
+	SetBtimeSupported = true
+	SetBtime = func(file string, birthTime Time, noDeref bool) error {
+		var flags uint32 = windows.FILE_FLAG_BACKUP_SEMANTICS
+		if noDeref {
+			flags |= windows.FILE_FLAG_OPEN_REPARSE_POINT
+		}
+
+		handle, err := openForAttributes(file, flags)
+		if err != nil {
+			return fmt.Errorf("open %s: %w", file, err)
+		}
+		defer windows.CloseHandle(handle)
+
+		ctime := windows.NsecToFiletime(birthTime.UnixNano())
+
+		if err := windows.SetFileTime(handle, &ctime, nil, nil); err != nil {
+			return fmt.Errorf("setfiletime %s: %w", file, err)
+		}
+
+		return nil
+	}
+
+	SetTimesNoDerefSupported = true
+	SetTimesNoDeref = func(file string, accessTime, modTime Time) error {
+		handle, err := openReparsePoint(file)
+		if err != nil {
+			return fmt.Errorf("%w: open %s: %w", errors.ErrNoDerefUnsupported, file, err)
+		}
+		defer windows.CloseHandle(handle)
+
+		atime := windows.NsecToFiletime(accessTime.UnixNano())
+		mtime := windows.NsecToFiletime(modTime.UnixNano())
+
+		if err := windows.SetFileTime(handle, nil, &atime, &mtime); err != nil {
+			return fmt.Errorf("setfiletime %s: %w", file, err)
+		}
+
+		return nil
+	}
+}
+
+// openReparsePoint opens file for writing its attributes without following a final
+// symlink or junction. Backup semantics allow directories to be opened as well.
+func openReparsePoint(file string) (windows.Handle, error) {
+	return openForAttributes(file, windows.FILE_FLAG_OPEN_REPARSE_POINT|windows.FILE_FLAG_BACKUP_SEMANTICS)
+}
+
+// openForAttributes opens the existing file for writing its attributes with the given
+// CreateFile flags.
+func openForAttributes(file string, flags uint32) (windows.Handle, error) {
+	path, err := windows.UTF16PtrFromString(file)
+	if err != nil {
+		return windows.InvalidHandle, fmt.Errorf("encode path: %w", err)
+	}
+
+	handle, err := windows.CreateFile(
+		path,
+		windows.FILE_WRITE_ATTRIBUTES,
+		windows.FILE_SHARE_READ|windows.FILE_SHARE_WRITE|windows.FILE_SHARE_DELETE,
+		nil,
+		windows.OPEN_EXISTING,
+		flags,
+		0,
+	)
+	if err != nil {
+		return windows.InvalidHandle, fmt.Errorf("createfile: %w", err)
+	}
+
+	return handle, nil
 }
 
 // filetimeToTime converts a Windows Filetime to time.Time.
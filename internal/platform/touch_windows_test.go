@@ -20,10 +20,15 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package platform
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"golang.org/x/sys/windows"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
 )
 
 func Test_filetimeToTime(t *testing.T) {
@@ -70,3 +75,165 @@ func Test_filetimeToTime(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBtime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		fileInfo os.FileInfo
+		wantOK   bool
+	}{
+		{
+			name:     "available",
+			path:     path,
+			fileInfo: fileInfo,
+			wantOK:   true,
+		},
+		{
+			name:     "unavailable without platform stat data",
+			path:     path,
+			fileInfo: sysLessFileInfo{fileInfo},
+			wantOK:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := GetBtime(tt.path, tt.fileInfo, false)
+			if ok != tt.wantOK {
+				t.Fatalf("GetBtime() ok = %v, want %v", ok, tt.wantOK)
+			}
+
+			if ok && (got.IsZero() || got.After(time.Now().Add(time.Minute))) {
+				t.Errorf("GetBtime() = %v, want a plausible past time", got)
+			}
+		})
+	}
+}
+
+// sysLessFileInfo wraps a FileInfo, hiding its platform-specific Sys data.
+type sysLessFileInfo struct {
+	os.FileInfo
+}
+
+func (sysLessFileInfo) Sys() any { return nil }
+
+func TestSetBtime(t *testing.T) {
+	dir := t.TempDir()
+	birth := time.Date(2020, 1, 2, 3, 4, 5, 0, time.Local)
+	mtime := time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		file    string
+		create  bool
+		wantErr bool
+	}{
+		{name: "existing file", file: "file.txt", create: true, wantErr: false},
+		{name: "missing file", file: "missing.txt", create: false, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.file)
+
+			if tt.create {
+				if err := os.WriteFile(path, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.Chtimes(path, mtime, mtime); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := SetBtime(path, birth, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SetBtime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			fileInfo, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, ok := GetBtime(path, fileInfo, false); !ok || !got.Equal(birth) {
+				t.Errorf("SetBtime() birth time = %v (ok %v), want %v", got, ok, birth)
+			}
+
+			if got := fileInfo.ModTime(); !got.Equal(mtime) {
+				t.Errorf("SetBtime() changed mtime to %v, want %v", got, mtime)
+			}
+		})
+	}
+}
+
+func TestSetTimesNoDeref(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	link := filepath.Join(dir, "link.txt")
+	targetTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.Local)
+
+	if err := os.WriteFile(target, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(target, targetTime, targetTime); err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating symlinks needs Developer Mode or an elevated process on Windows.
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+
+	atime := time.Date(2025, 7, 13, 14, 30, 0, 100, time.Local)
+	mtime := time.Date(2025, 7, 13, 15, 45, 0, 200, time.Local)
+
+	if err := SetTimesNoDeref(link, atime, mtime); err != nil {
+		t.Fatalf("SetTimesNoDeref() error = %v", err)
+	}
+
+	linkInfo, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := GetAtime(linkInfo); !got.Equal(atime) {
+		t.Errorf("SetTimesNoDeref() link atime = %v, want %v", got, atime)
+	}
+
+	if got := linkInfo.ModTime(); !got.Equal(mtime) {
+		t.Errorf("SetTimesNoDeref() link mtime = %v, want %v", got, mtime)
+	}
+
+	targetInfo, err := os.Stat(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !targetInfo.ModTime().Equal(targetTime) {
+		t.Errorf("SetTimesNoDeref() target mtime = %v, want unchanged %v", targetInfo.ModTime(), targetTime)
+	}
+}
+
+func TestSetTimesNoDeref_missingFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+
+	err := SetTimesNoDeref(missing, time.Now(), time.Now())
+	if !errors.Is(err, touchErrors.ErrNoDerefUnsupported) {
+		t.Errorf("SetTimesNoDeref() error = %v, want %v", err, touchErrors.ErrNoDerefUnsupported)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles parsing adjustments to existing file times.
+package timestamp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// ParseAdjustment parses a non-zero shift for existing file times, either a Go duration
+// such as "+1h" or "-90m", or a signed offset such as "+2 days" or "-3 hours".
+// Surrounding whitespace is ignored.
+func ParseAdjustment(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+
+	adjustment, err := time.ParseDuration(value)
+	if err != nil {
+		var ok bool
+
+		adjustment, ok, err = parseOffset(value)
+		if !ok || err != nil {
+			return 0, fmt.Errorf("%w: %q", errors.ErrInvalidAdjustment, value)
+		}
+	}
+
+	if adjustment == 0 {
+		return 0, fmt.Errorf("%w: %q", errors.ErrInvalidAdjustment, value)
+	}
+
+	return adjustment, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles parsing adjustments to existing file times.
+package timestamp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestParseAdjustment(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr error
+	}{
+		{name: "positive duration", value: "+1h", want: time.Hour, wantErr: nil},
+		{name: "unsigned duration", value: "90m", want: 90 * time.Minute, wantErr: nil},
+		{name: "negative compound duration", value: "-1h30m", want: -90 * time.Minute, wantErr: nil},
+		{name: "positive offset", value: "+2 days", want: 48 * time.Hour, wantErr: nil},
+		{name: "negative offset with spaces", value: " -3 hours ", want: -3 * time.Hour, wantErr: nil},
+		{name: "negative week offset", value: "-1 week", want: -7 * 24 * time.Hour, wantErr: nil},
+		{name: "zero", value: "0s", want: 0, wantErr: touchErrors.ErrInvalidAdjustment},
+		{name: "zero offset", value: "+0 days", want: 0, wantErr: touchErrors.ErrInvalidAdjustment},
+		{name: "unsigned offset", value: "2 days", want: 0, wantErr: touchErrors.ErrInvalidAdjustment},
+		{name: "overflowing offset", value: "+9999999999 weeks", want: 0, wantErr: touchErrors.ErrInvalidAdjustment},
+		{name: "absolute date", value: "2025-07-13", want: 0, wantErr: touchErrors.ErrInvalidAdjustment},
+		{name: "empty", value: "", want: 0, wantErr: touchErrors.ErrInvalidAdjustment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAdjustment(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseAdjustment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseAdjustment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles parsing file age thresholds.
+package timestamp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// ageUnits maps the day and week suffixes ParseAge accepts beyond time.ParseDuration.
+var ageUnits = map[string]time.Duration{
+	"d": hoursPerDay * time.Hour,
+	"w": daysPerWeek * hoursPerDay * time.Hour,
+}
+
+// ParseAge parses a positive age such as "90m", "36h", "7d", or "2w": a time.ParseDuration
+// string, or a whole number of days or weeks, which are fixed multiples of 24 hours.
+func ParseAge(value string) (time.Duration, error) {
+	trimmed := strings.TrimSpace(value)
+
+	var (
+		age time.Duration
+		err error
+	)
+
+	if unit, ok := ageUnits[trimmed[max(len(trimmed)-1, 0):]]; ok && len(trimmed) > 1 {
+		var count int64
+
+		count, err = strconv.ParseInt(trimmed[:len(trimmed)-1], 10, 64)
+		if err == nil && count > math.MaxInt64/int64(unit) {
+			err = errors.ErrInvalidAge
+		}
+
+		age = time.Duration(count) * unit
+	} else {
+		age, err = time.ParseDuration(trimmed)
+	}
+
+	if err != nil || age <= 0 {
+		return 0, fmt.Errorf("%w: %q", errors.ErrInvalidAge, value)
+	}
+
+	return age, nil
+}
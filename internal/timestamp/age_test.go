@@ -0,0 +1,61 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles parsing file age thresholds.
+package timestamp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr error
+	}{
+		{name: "minutes", value: "90m", want: 90 * time.Minute, wantErr: nil},
+		{name: "compound duration", value: "1h30m", want: 90 * time.Minute, wantErr: nil},
+		{name: "days", value: "7d", want: 7 * 24 * time.Hour, wantErr: nil},
+		{name: "weeks with spaces", value: " 2w ", want: 14 * 24 * time.Hour, wantErr: nil},
+		{name: "zero", value: "0s", want: 0, wantErr: touchErrors.ErrInvalidAge},
+		{name: "negative", value: "-1h", want: 0, wantErr: touchErrors.ErrInvalidAge},
+		{name: "negative days", value: "-3d", want: 0, wantErr: touchErrors.ErrInvalidAge},
+		{name: "fractional days", value: "1.5d", want: 0, wantErr: touchErrors.ErrInvalidAge},
+		{name: "bare unit", value: "d", want: 0, wantErr: touchErrors.ErrInvalidAge},
+		{name: "days overflow", value: "9999999999d", want: 0, wantErr: touchErrors.ErrInvalidAge},
+		{name: "no unit", value: "7", want: 0, wantErr: touchErrors.ErrInvalidAge},
+		{name: "empty", value: "", want: 0, wantErr: touchErrors.ErrInvalidAge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAge(tt.value)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseAge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseAge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles build instant retrieval from .buildinfo files.
+package timestamp
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// buildInfoTimeKey is the .buildinfo key holding the build instant.
+const buildInfoTimeKey = "BuildTime"
+
+// GetTimeFromBuildInfo reads the BuildTime entry from a key=value .buildinfo file.
+// Blank lines and lines starting with # are ignored; the value is parsed with ParseDate.
+// Returns an error if the file can't be read, the key is missing, or the value is invalid.
+func GetTimeFromBuildInfo(path string) (Time, error) {
+	file, err := filesystem.Default.Open(path)
+	if err != nil {
+		return Time{}, fmt.Errorf("open buildinfo %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found || strings.TrimSpace(key) != buildInfoTimeKey {
+			continue
+		}
+
+		buildTime, err := ParseDate(strings.TrimSpace(value))
+		if err != nil {
+			return Time{}, fmt.Errorf("parse %s in %s: %w", buildInfoTimeKey, path, err)
+		}
+
+		return buildTime, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Time{}, fmt.Errorf("read buildinfo %s: %w", path, err)
+	}
+
+	return Time{}, fmt.Errorf("%w: %s in %s", errors.ErrBuildInfoKeyMissing, buildInfoTimeKey, path)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles build instant retrieval from .buildinfo files.
+package timestamp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+func TestGetTimeFromBuildInfo(t *testing.T) {
+	filesystem.Default = realFS
+
+	tests := []struct {
+		name      string
+		content   string
+		want      Time
+		wantErrIs error
+		wantErr   bool
+	}{
+		{
+			name:    "valid buildinfo",
+			content: "# generated by the release pipeline\nVersion=1.2.3\nBuildTime=2025-07-13T14:30:00Z\n",
+			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "whitespace around key and value",
+			content: "  BuildTime = 2025-07-13T14:30:00Z  \n",
+			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:      "missing key",
+			content:   "Version=1.2.3\nCommit=abc123\n",
+			want:      Time{},
+			wantErrIs: touchErrors.ErrBuildInfoKeyMissing,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid value",
+			content:   "BuildTime=yesterday-ish\n",
+			want:      Time{},
+			wantErrIs: touchErrors.ErrUnsupportedDateFormat,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".buildinfo")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := GetTimeFromBuildInfo(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTimeFromBuildInfo() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("GetTimeFromBuildInfo() error = %v, want %v", err, tt.wantErrIs)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromBuildInfo() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles computing the next occurrence of a cron schedule.
+package timestamp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// cronFieldCount is the number of fields in a standard cron expression.
+const cronFieldCount = 5
+
+// cronSearchYears bounds the search for an occurrence, so that schedules which can never
+// fire, such as "0 0 30 2 *", fail instead of looping forever.
+const cronSearchYears = 5
+
+// cronField describes the range and names accepted by one cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // Names for the values from min onward, if any.
+}
+
+// cronFields lists the fields of a cron expression in order. Day of week accepts 7 as
+// a second Sunday, folded onto 0 when parsing.
+var cronFields = [cronFieldCount]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{
+		name: "month", min: 1, max: 12,
+		names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"},
+	},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// CronSchedule is a parsed 5-field cron expression. Each field is a bit set of the
+// values it matches.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record unrestricted day fields; when both day fields are
+	// restricted, a day matches if either does, as in Vixie cron.
+	domStar, dowStar bool
+}
+
+// ParseCron parses a standard 5-field cron expression: minute, hour, day of month, month,
+// and day of week. Each field is *, a value, a range a-b, or a comma-separated list of
+// these, each optionally followed by /step. Months and days of week also accept
+// three-letter English names. Returns an error for any malformed field.
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != cronFieldCount {
+		return nil, fmt.Errorf("%w: %q has %d fields, want %d", errors.ErrInvalidCron, expr, len(fields), cronFieldCount)
+	}
+
+	var sets [cronFieldCount]uint64
+
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", errors.ErrInvalidCron, expr, err)
+		}
+
+		sets[i] = set
+	}
+
+	// Fold day of week 7 onto Sunday.
+	const sunday7 = 1 << 7
+	if sets[4]&sunday7 != 0 {
+		sets[4] = sets[4]&^sunday7 | 1
+	}
+
+	return &CronSchedule{
+		minute:  sets[0],
+		hour:    sets[1],
+		dom:     sets[2],
+		month:   sets[3],
+		dow:     sets[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// Next returns the first time strictly after after, at a whole minute in after's location,
+// that the schedule matches. Returns an error if there is none within cronSearchYears.
+func (s *CronSchedule) Next(after Time) (Time, error) {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !hasBit(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !hasBit(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !hasBit(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t, nil
+		}
+	}
+
+	return Time{}, fmt.Errorf("%w within %d years of %s", errors.ErrNoCronOccurrence, cronSearchYears, after.Format(time.RFC3339))
+}
+
+// NextCronTime parses expr with ParseCron and returns its next occurrence after now.
+func NextCronTime(expr string, now Time) (Time, error) {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return Time{}, err
+	}
+
+	return schedule.Next(now)
+}
+
+// matchesDay reports whether t's day matches the day-of-month and day-of-week fields.
+func (s *CronSchedule) matchesDay(t Time) bool {
+	domMatch := hasBit(s.dom, t.Day())
+	dowMatch := hasBit(s.dow, int(t.Weekday()))
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// parseCronField parses one comma-separated cron field into a bit set of matching values.
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var set uint64
+
+	for part := range strings.SplitSeq(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+
+		if hasStep {
+			var err error
+
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("%s: invalid step %q", spec.name, stepPart)
+			}
+		}
+
+		low, high := spec.min, spec.max
+
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+
+			low, err = parseCronValue(lowPart, spec)
+			if err != nil {
+				return 0, err
+			}
+
+			high = low
+
+			switch {
+			case isRange:
+				high, err = parseCronValue(highPart, spec)
+				if err != nil {
+					return 0, err
+				}
+
+				if high < low {
+					return 0, fmt.Errorf("%s: range %q ends before it starts", spec.name, rangePart)
+				}
+			case hasStep:
+				// A stepped single value such as 5/15 runs to the end of the field.
+				high = spec.max
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << value
+		}
+	}
+
+	return set, nil
+}
+
+// parseCronValue parses a single numeric or named cron value within spec's range.
+func parseCronValue(value string, spec cronField) (int, error) {
+	for i, name := range spec.names {
+		if strings.EqualFold(value, name) {
+			return spec.min + i, nil
+		}
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number < spec.min || number > spec.max {
+		return 0, fmt.Errorf("%s: invalid value %q, want %d-%d", spec.name, value, spec.min, spec.max)
+	}
+
+	return number, nil
+}
+
+// hasBit reports whether bit n is set in set.
+func hasBit(set uint64, n int) bool {
+	return set&(1<<n) != 0
+}
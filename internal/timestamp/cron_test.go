@@ -0,0 +1,154 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles computing the next occurrence of a cron schedule.
+package timestamp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "every minute", expr: "* * * * *", wantErr: false},
+		{name: "lists ranges and steps", expr: "0,30 9-17/2 1-15 */3 1-5", wantErr: false},
+		{name: "names", expr: "0 6 * jan-mar SUN,sat", wantErr: false},
+		{name: "stepped single value", expr: "5/15 * * * *", wantErr: false},
+		{name: "sunday as seven", expr: "0 0 * * 7", wantErr: false},
+		{name: "extra spaces", expr: "  0  *  * * *  ", wantErr: false},
+		{name: "too few fields", expr: "* * * *", wantErr: true},
+		{name: "too many fields", expr: "0 * * * * *", wantErr: true},
+		{name: "minute out of range", expr: "60 * * * *", wantErr: true},
+		{name: "day of month zero", expr: "* * 0 * *", wantErr: true},
+		{name: "zero step", expr: "*/0 * * * *", wantErr: true},
+		{name: "reversed range", expr: "5-1 * * * *", wantErr: true},
+		{name: "unknown name", expr: "* * * foo *", wantErr: true},
+		{name: "empty list item", expr: "1,,2 * * * *", wantErr: true},
+		{name: "empty", expr: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCron() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, touchErrors.ErrInvalidCron) {
+				t.Errorf("ParseCron() error = %v, want %v", err, touchErrors.ErrInvalidCron)
+			}
+		})
+	}
+}
+
+func TestNextCronTime(t *testing.T) {
+	// A Sunday afternoon, part way through a minute.
+	now := time.Date(2025, 7, 13, 14, 37, 20, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    Time
+		wantErr error
+	}{
+		{
+			name:    "top of the next hour",
+			expr:    "0 * * * *",
+			want:    time.Date(2025, 7, 13, 15, 0, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "next quarter hour",
+			expr:    "*/15 * * * *",
+			want:    time.Date(2025, 7, 13, 14, 45, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "current minute is skipped",
+			expr:    "37 14 * * *",
+			want:    time.Date(2025, 7, 14, 14, 37, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "next weekday morning",
+			expr:    "30 9 * * mon-fri",
+			want:    time.Date(2025, 7, 14, 9, 30, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "next sunday as seven",
+			expr:    "0 0 * * 7",
+			want:    time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "restricted day fields match either",
+			expr:    "0 0 1 * 3",
+			want:    time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "new year",
+			expr:    "0 0 1 1 *",
+			want:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "passed today rolls to next year",
+			expr:    "0 12 13 jul *",
+			want:    time.Date(2026, 7, 13, 12, 0, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "leap day",
+			expr:    "0 0 29 2 *",
+			want:    time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "never fires",
+			expr:    "0 0 30 2 *",
+			want:    Time{},
+			wantErr: touchErrors.ErrNoCronOccurrence,
+		},
+		{
+			name:    "invalid expression",
+			expr:    "0 25 * * *",
+			want:    Time{},
+			wantErr: touchErrors.ErrInvalidCron,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextCronTime(tt.expr, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NextCronTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("NextCronTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,8 +4,57 @@
 //
 // Main Functions:
 // - ParsePosixTime: Parses POSIX timestamp format [[CC]YY]MMDDhhmm[.ss], handling century/year variations.
-// - ParseDate: Parses date strings in formats like RFC3339, YYYY-MM-DDTHH:MM:SS, and time-only variants.
+// - ParsePosixTimeIn: Like ParsePosixTime, but interprets the timestamp in a given location such as UTC.
+// - ParsePosixTimeAt: Like ParsePosixTimeIn, but takes the current year from a given time instead of Now.
+// - LoadTimezone: Resolves an IANA time zone name for ParseDateIn and ParsePosixTimeIn.
+// - IsPosixStamp: Reports whether a string has the exact digit shape of a POSIX timestamp.
+// - ParseDate: Parses -d date strings: epoch times, keywords, relative offsets, ISO week dates, and common layouts.
+// - ParseDateIn: Like ParseDate, but interprets times without an explicit offset in a given location such as UTC, optionally with a custom layout from --format.
+// - ParseDateAt: Like ParseDateIn, but resolves relative and partial dates against a given time instead of Now.
 // - GetTimesFromRef: Retrieves access and modification times from a reference file, using Stat or Lstat based on noDeref.
+// - GetTimesFromRefPreferBirth: Like GetTimesFromRef, using the reference's birth time in place of its mtime when available.
+// - GetTimesFromRefs: Retrieves times from several reference files and reduces them with ReduceTimes.
+// - GetTimesFromRefsJobs: Like GetTimesFromRefs, but reads a bounded number of references concurrently.
+// - GetTimesFromNewestAtime: Retrieves the times of the reference file with the newest access time, reading a bounded number concurrently.
+// - GetTimesFromOldestAtime: Retrieves the times of the reference file with the oldest access time, reading a bounded number concurrently.
+// - GetTimeFromMaxChange: Retrieves the latest modification or status change time across reference files, reading a bounded number concurrently.
+// - GetTimeFromOldestCtime: Retrieves the earliest status change time across reference files, reading a bounded number concurrently.
+// - GetTimeFromModeGlob: Retrieves the most common modification time of files matching a glob.
+// - GetTimeFromPercentile: Retrieves the nearest-rank percentile of the modification times of files matching a glob.
+// - GetTimeFromMinAfter: Retrieves the earliest modification time after a floor among files matching a glob.
+// - ParsePercentile: Parses a percentile from 0 to 100.
+// - ModeTime: Selects the most frequently occurring time of a set, ties going to the newest.
+// - PercentileTime: Selects the nearest-rank percentile of a set of times.
+// - ReduceTimes: Reduces a set of times to one using min, max, mean, or median.
+// - ParseCron: Parses a standard 5-field cron expression into a CronSchedule, whose Next method finds its next occurrence.
+// - NextCronTime: Returns the next occurrence of a cron expression after a given time.
+// - ParseAdjustment: Parses a non-zero shift for existing times, such as +1h or -2 days.
+// - ParseAge: Parses a positive file age such as 36h or 7d for age thresholds.
+// - TruncateTime: Rounds a time down to the start of its day, hour, or minute in its own location.
+// - ParseSeedWindow: Parses a START,END window for seeded times.
+// - TimeFromSeed: Maps a seed string's SHA-256 digest deterministically to a time within a window.
+// - ParseSizeWindow: Parses a START,END window for size-mapped times.
+// - TimeFromSize: Maps a file size linearly into a window, reaching its end at a maximum size.
+// - ParseStatExpr: Parses a --stat-time arithmetic expression over size, mode, and lines into a StatExpr, whose Eval method yields epoch seconds.
+// - TimeFromUUID: Extracts the time embedded in a UUIDv1 or UUIDv7 string.
+// - GetTimeFromUUIDName: Extracts the time embedded in a file name that is a UUIDv1 or UUIDv7.
+// - GetTimeFromSidecar: Reads the RFC3339 time stored in a per-file .time sidecar.
+// - GetTimesFromSSH: Retrieves a remote file's times over SSH through an injectable RemoteStatter.
+// - GetTimeFromGitNewest: Retrieves the committer time of the newest commit touching a path or the whole repository.
+// - GetTimeFromBuildInfo: Reads the BuildTime entry from a key=value .buildinfo file.
+// - GetTimeFromRsyncList: Retrieves the newest modification time among the entries of an rsync --list-only listing.
+// - GetTimeFromWARC: Reads the WARC-Date of the response or resource record for a target URI in a WARC archive.
+// - GetTimeFromOCI: Reads the created date from the config of a Docker or OCI image tarball's manifest.json.
+// - GetTimeFromFSImage: Finds the newest member modification time in a squashfs or erofs image.
+// - GetTimeFromLockfile: Reads the time field at a dot-separated path in a JSON lockfile's package entry.
+// - GetTimeFromMount: Retrieves the approximate mount time of the filesystem containing a path (Linux only).
+// - GetTimeFromBoot: Retrieves the approximate system boot time as now minus uptime (Linux only).
+// - GetTimeFromProcFDs: Retrieves the newest modification time among a process's open files (Linux only).
+// - GetTimeFromUnit: Retrieves when a systemd unit last became active, its ActiveEnterTimestamp (Linux only).
+// - GetTimeFromSelfAtime: Retrieves the access time of the running executable.
+// - GetTimesFromNewestUnder: Retrieves the times of the most recently modified entry anywhere below a directory.
+// - GetTimesFromNewestType: Retrieves the times of the most recently modified file below a directory with a given sniffed content type.
+// - GetTimesFromNearestAncestor: Retrieves the times of the nearest existing directory above a path.
 //
 // This package is used by the cli package to compute timestamps from user input or reference files.
 // It assumes local timezone for all parsing and integrates with the filesystem and platform packages
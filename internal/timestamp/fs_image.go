@@ -0,0 +1,181 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles finding the newest member of a squashfs or erofs image.
+package timestamp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// FSImageFormat names a read-only filesystem image format.
+type FSImageFormat string
+
+// Supported filesystem image formats.
+const (
+	FSImageSquashFS FSImageFormat = "squashfs"
+	FSImageEROFS    FSImageFormat = "erofs"
+)
+
+// erofsMagicOffset is where the erofs superblock, and so its magic number, begins.
+const erofsMagicOffset = 1024
+
+var (
+	// squashfsMagic opens a little-endian squashfs superblock, as written by mksquashfs.
+	squashfsMagic = []byte("hsqs")
+	// erofsMagic is the little-endian encoding of the erofs superblock magic 0xE0F5E1E2.
+	erofsMagic = []byte{0xe2, 0xe1, 0xf5, 0xe0}
+)
+
+// FSImageExtractor lists the modification times of every member of a filesystem image.
+type FSImageExtractor interface {
+	MemberTimes(image string, format FSImageFormat) ([]Time, error)
+}
+
+// fsImageExtractor is the FSImageExtractor used by GetTimeFromFSImage, overridable in tests.
+var fsImageExtractor FSImageExtractor = commandImageExtractor{}
+
+// GetTimeFromFSImage returns the newest modification time among the members of image, a
+// squashfs or erofs filesystem image recognized by its superblock magic number.
+// Returns an error if the image is of another format, can't be read, or has no members.
+func GetTimeFromFSImage(image string) (Time, error) {
+	format, err := detectFSImageFormat(image)
+	if err != nil {
+		return Time{}, err
+	}
+
+	times, err := fsImageExtractor.MemberTimes(image, format)
+	if err != nil {
+		return Time{}, fmt.Errorf("read %s image %s: %w", format, image, err)
+	}
+
+	if len(times) == 0 {
+		return Time{}, fmt.Errorf("%w: %s", touchErrors.ErrEmptyFSImage, image)
+	}
+
+	newest := times[0]
+	for _, memberTime := range times[1:] {
+		if memberTime.After(newest) {
+			newest = memberTime
+		}
+	}
+
+	return newest, nil
+}
+
+// detectFSImageFormat identifies image from the magic number at the start of its superblock.
+func detectFSImageFormat(image string) (FSImageFormat, error) {
+	file, err := filesystem.Default.Open(image)
+	if err != nil {
+		return "", fmt.Errorf("open image %s: %w", image, err)
+	}
+	defer file.Close()
+
+	header := make([]byte, erofsMagicOffset+len(erofsMagic))
+
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read image %s: %w", image, err)
+	}
+
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, squashfsMagic):
+		return FSImageSquashFS, nil
+	case len(header) == erofsMagicOffset+len(erofsMagic) &&
+		bytes.Equal(header[erofsMagicOffset:], erofsMagic):
+		return FSImageEROFS, nil
+	default:
+		return "", fmt.Errorf("%w: %s", touchErrors.ErrUnsupportedFSImage, image)
+	}
+}
+
+// commandImageExtractor implements FSImageExtractor by unpacking the image into a temporary
+// directory with unsquashfs or fsck.erofs, which keep each member's modification time.
+type commandImageExtractor struct{}
+
+// MemberTimes extracts image and returns the modification times of everything unpacked.
+func (commandImageExtractor) MemberTimes(image string, format FSImageFormat) ([]Time, error) {
+	tempDir, err := os.MkdirTemp("", "touch-image-")
+	if err != nil {
+		return nil, fmt.Errorf("create extraction directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	root := filepath.Join(tempDir, "root")
+
+	var args []string
+
+	switch format {
+	case FSImageSquashFS:
+		args = []string{"unsquashfs", "-quiet", "-no-progress", "-no-xattrs", "-d", root, image}
+	case FSImageEROFS:
+		args = []string{"fsck.erofs", "--extract=" + root, image}
+	default:
+		return nil, fmt.Errorf("%w: %s", touchErrors.ErrUnsupportedFSImage, format)
+	}
+
+	var stderr bytes.Buffer
+
+	//nolint:gosec // The tool is fixed by format and the image comes from --reference-image.
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return extractedTimes(root)
+}
+
+// extractedTimes returns the modification times of root and every entry beneath it,
+// taking symlinks' own times.
+func extractedTimes(root string) ([]Time, error) {
+	var times []Time
+
+	err := filesystem.Default.WalkDir(root, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		times = append(times, info.ModTime())
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk extracted image: %w", err)
+	}
+
+	return times, nil
+}
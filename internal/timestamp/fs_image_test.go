@@ -0,0 +1,194 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles finding the newest member of a squashfs or erofs image.
+package timestamp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// fakeImageExtractor is an FSImageExtractor returning canned member times.
+type fakeImageExtractor struct {
+	format FSImageFormat
+	times  []Time
+	err    error
+}
+
+func (f *fakeImageExtractor) MemberTimes(_ string, format FSImageFormat) ([]Time, error) {
+	f.format = format
+
+	return f.times, f.err
+}
+
+// squashfsHeader returns the start of a squashfs image.
+func squashfsHeader() []byte {
+	return append([]byte("hsqs"), make([]byte, 92)...)
+}
+
+// erofsHeader returns the start of an erofs image, whose superblock follows 1 KiB of padding.
+func erofsHeader() []byte {
+	return append(make([]byte, 1024), 0xe2, 0xe1, 0xf5, 0xe0, 0, 0, 0, 0)
+}
+
+func TestGetTimeFromFSImage(t *testing.T) {
+	filesystem.Default = realFS
+
+	older := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newest := time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC)
+	errExtract := errors.New("unsquashfs: exit status 1")
+
+	tests := []struct {
+		name       string
+		header     []byte
+		times      []Time
+		extractErr error
+		wantFormat FSImageFormat
+		want       Time
+		wantErr    error
+	}{
+		{
+			name:       "squashfs",
+			header:     squashfsHeader(),
+			times:      []Time{older, newest, older},
+			extractErr: nil,
+			wantFormat: FSImageSquashFS,
+			want:       newest,
+			wantErr:    nil,
+		},
+		{
+			name:       "erofs",
+			header:     erofsHeader(),
+			times:      []Time{newest, older},
+			extractErr: nil,
+			wantFormat: FSImageEROFS,
+			want:       newest,
+			wantErr:    nil,
+		},
+		{
+			name:       "ext4 image",
+			header:     append(make([]byte, 1080), 0x53, 0xef),
+			times:      []Time{newest},
+			extractErr: nil,
+			wantFormat: "",
+			want:       Time{},
+			wantErr:    touchErrors.ErrUnsupportedFSImage,
+		},
+		{
+			name:       "too short",
+			header:     []byte("hs"),
+			times:      []Time{newest},
+			extractErr: nil,
+			wantFormat: "",
+			want:       Time{},
+			wantErr:    touchErrors.ErrUnsupportedFSImage,
+		},
+		{
+			name:       "no members",
+			header:     squashfsHeader(),
+			times:      nil,
+			extractErr: nil,
+			wantFormat: FSImageSquashFS,
+			want:       Time{},
+			wantErr:    touchErrors.ErrEmptyFSImage,
+		},
+		{
+			name:       "extraction fails",
+			header:     erofsHeader(),
+			times:      nil,
+			extractErr: errExtract,
+			wantFormat: FSImageEROFS,
+			want:       Time{},
+			wantErr:    errExtract,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image := filepath.Join(t.TempDir(), "image.img")
+			if err := os.WriteFile(image, tt.header, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			extractor := &fakeImageExtractor{times: tt.times, err: tt.extractErr}
+
+			oldExtractor := fsImageExtractor
+			fsImageExtractor = extractor
+
+			t.Cleanup(func() { fsImageExtractor = oldExtractor })
+
+			got, err := GetTimeFromFSImage(image)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromFSImage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromFSImage() = %v, want %v", got, tt.want)
+			}
+
+			if extractor.format != tt.wantFormat {
+				t.Errorf("GetTimeFromFSImage() extracted as %q, want %q", extractor.format, tt.wantFormat)
+			}
+		})
+	}
+
+	_, err := GetTimeFromFSImage(filepath.Join(t.TempDir(), "missing.img"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("GetTimeFromFSImage(missing) error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func Test_extractedTimes(t *testing.T) {
+	filesystem.Default = realFS
+
+	root := t.TempDir()
+	file := filepath.Join(root, "etc", "os-release")
+	fileTime := time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC)
+	dirTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	mtimes := map[string]Time{root: dirTime, filepath.Dir(file): dirTime, file: fileTime}
+	for path, mtime := range mtimes {
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := extractedTimes(root)
+	if err != nil {
+		t.Fatalf("extractedTimes() error = %v", err)
+	}
+
+	want := []Time{dirTime, dirTime, fileTime}
+	if !slices.EqualFunc(got, want, Time.Equal) {
+		t.Errorf("extractedTimes() = %v, want %v", got, want)
+	}
+}
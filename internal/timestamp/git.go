@@ -0,0 +1,74 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles commit time retrieval from git history.
+package timestamp
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// GitRepoRoot is the pathspec selecting the whole repository for GetTimeFromGitNewest.
+const GitRepoRoot = ":/"
+
+// gitRunner runs git with args and returns its standard output, overridable in tests.
+var gitRunner = runGit
+
+// GetTimeFromGitNewest retrieves the committer time of the most recent commit touching
+// pathspec, as reported by git log -1 --format=%cI. Use GitRepoRoot for the whole
+// repository. Returns an error outside a repository or if no commit touches pathspec.
+func GetTimeFromGitNewest(pathspec string) (Time, error) {
+	output, err := gitRunner("log", "-1", "--format=%cI", "--", pathspec)
+	if err != nil {
+		return Time{}, fmt.Errorf("git log for %s: %w", pathspec, err)
+	}
+
+	value := strings.TrimSpace(output)
+	if value == "" {
+		return Time{}, fmt.Errorf("%w: %s", errors.ErrNoGitHistory, pathspec)
+	}
+
+	commitTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return Time{}, fmt.Errorf("parse git commit time %q: %w", value, err)
+	}
+
+	return commitTime, nil
+}
+
+// runGit runs git with args, returning standard output or an error that includes
+// git's standard error, such as "not a git repository".
+func runGit(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	//nolint:gosec // Only GetTimeFromGitNewest's fixed log arguments and the user's pathspec are passed.
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles commit time retrieval from git history.
+package timestamp
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestGetTimeFromGitNewest(t *testing.T) {
+	errNotRepo := errors.New("exit status 128: fatal: not a git repository")
+
+	tests := []struct {
+		name      string
+		pathspec  string
+		output    string
+		runnerErr error
+		want      Time
+		wantErr   error
+	}{
+		{
+			name:      "subtree path",
+			pathspec:  "internal/timestamp",
+			output:    "2025-07-13T14:30:00+02:00\n",
+			runnerErr: nil,
+			want:      time.Date(2025, 7, 13, 12, 30, 0, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "whole repository",
+			pathspec:  GitRepoRoot,
+			output:    "2025-07-14T09:00:00Z\n",
+			runnerErr: nil,
+			want:      time.Date(2025, 7, 14, 9, 0, 0, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "outside a repository",
+			pathspec:  GitRepoRoot,
+			output:    "",
+			runnerErr: errNotRepo,
+			want:      Time{},
+			wantErr:   errNotRepo,
+		},
+		{
+			name:      "no commits touch path",
+			pathspec:  "untracked",
+			output:    "",
+			runnerErr: nil,
+			want:      Time{},
+			wantErr:   touchErrors.ErrNoGitHistory,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldRunner := gitRunner
+
+			defer func() { gitRunner = oldRunner }()
+
+			var gotArgs []string
+
+			gitRunner = func(args ...string) (string, error) {
+				gotArgs = args
+
+				return tt.output, tt.runnerErr
+			}
+
+			got, err := GetTimeFromGitNewest(tt.pathspec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetTimeFromGitNewest() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromGitNewest() = %v, want %v", got, tt.want)
+			}
+
+			wantArgs := []string{"log", "-1", "--format=%cI", "--", tt.pathspec}
+			if !slices.Equal(gotArgs, wantArgs) {
+				t.Errorf("GetTimeFromGitNewest() git args = %q, want %q", gotArgs, wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reading a dependency's recorded time from a JSON lockfile.
+package timestamp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// DefaultLockPath is the path of the time field within a lockfile entry when none is given.
+const DefaultLockPath = "time"
+
+// maxLockfileSize bounds how much of a lockfile is read.
+const maxLockfileSize = 64 << 20
+
+// npmModulesPrefix is the install path prefix of top-level packages in package-lock.json.
+const npmModulesPrefix = "node_modules/"
+
+// GetTimeFromLockfile returns the time recorded for entry in the JSON lockfile at path,
+// read from the field at fieldPath within the entry, such as "time" or "meta.published".
+// Path segments are separated by dots, and numeric segments index arrays.
+//
+// In a package-lock.json, recognized by its lockfileVersion, entry is looked up among
+// "packages" by name or install path, then among the older "dependencies"; in any other
+// JSON object it is a top-level key. A string field is parsed with ParseDate and a number
+// is taken as epoch seconds. Returns an error if the entry or field is missing or the
+// field isn't a time.
+func GetTimeFromLockfile(path, entry, fieldPath string) (Time, error) {
+	file, err := filesystem.Default.Open(path)
+	if err != nil {
+		return Time{}, fmt.Errorf("open lockfile %s: %w", path, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxLockfileSize))
+	if err != nil {
+		return Time{}, fmt.Errorf("read lockfile %s: %w", path, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var root map[string]any
+	if err := decoder.Decode(&root); err != nil {
+		return Time{}, fmt.Errorf("%w: %s: %w", errors.ErrInvalidLockfile, path, err)
+	}
+
+	entryValue, ok := lockEntry(root, entry)
+	if !ok {
+		return Time{}, fmt.Errorf("%w: %q in %s", errors.ErrLockEntryNotFound, entry, path)
+	}
+
+	field, ok := lookupJSONPath(entryValue, fieldPath)
+	if !ok {
+		return Time{}, fmt.Errorf(
+			"%w: %q in entry %q of %s",
+			errors.ErrLockFieldNotFound,
+			fieldPath,
+			entry,
+			path,
+		)
+	}
+
+	lockTime, err := jsonTime(field)
+	if err != nil {
+		return Time{}, fmt.Errorf("%s of entry %q in %s: %w", fieldPath, entry, path, err)
+	}
+
+	return lockTime, nil
+}
+
+// lockEntry finds the object recorded for entry in a lockfile's root object.
+func lockEntry(root map[string]any, entry string) (any, bool) {
+	if _, isNPM := root["lockfileVersion"]; !isNPM {
+		value, ok := root[entry]
+
+		return value, ok
+	}
+
+	// Lockfile versions 2 and 3 key packages by install path; version 1 by name.
+	if packages, ok := root["packages"].(map[string]any); ok {
+		for _, key := range []string{npmModulesPrefix + entry, entry} {
+			if value, ok := packages[key]; ok {
+				return value, true
+			}
+		}
+	}
+
+	if dependencies, ok := root["dependencies"].(map[string]any); ok {
+		value, ok := dependencies[entry]
+
+		return value, ok
+	}
+
+	return nil, false
+}
+
+// lookupJSONPath follows the dot-separated path from value, indexing objects by key and
+// arrays by position. An empty path selects value itself.
+func lookupJSONPath(value any, path string) (any, bool) {
+	if path == "" {
+		return value, true
+	}
+
+	for segment := range strings.SplitSeq(path, ".") {
+		switch current := value.(type) {
+		case map[string]any:
+			next, ok := current[segment]
+			if !ok {
+				return nil, false
+			}
+
+			value = next
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+
+			value = current[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// jsonTime converts a decoded JSON value to a time: strings are parsed with ParseDate and
+// numbers are epoch seconds, possibly fractional.
+func jsonTime(value any) (Time, error) {
+	switch v := value.(type) {
+	case string:
+		parsed, err := ParseDate(v)
+		if err != nil {
+			return Time{}, fmt.Errorf("%w: %w", errors.ErrInvalidLockTime, err)
+		}
+
+		return parsed, nil
+	case json.Number:
+		if seconds, err := v.Int64(); err == nil {
+			return time.Unix(seconds, 0), nil
+		}
+
+		seconds, err := v.Float64()
+		if err != nil {
+			return Time{}, fmt.Errorf("%w: %s", errors.ErrInvalidLockTime, v)
+		}
+
+		return time.Unix(0, int64(seconds*float64(time.Second))), nil
+	default:
+		return Time{}, fmt.Errorf("%w: %v", errors.ErrInvalidLockTime, value)
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reading a dependency's recorded time from a JSON lockfile.
+package timestamp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// packageLockFixture is a version 3 package-lock.json whose packages were annotated with
+// their publish times by the build tooling, one nested and one as epoch seconds.
+const packageLockFixture = `{
+  "name": "app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "requires": true,
+  "packages": {
+    "": {
+      "name": "app",
+      "version": "1.0.0",
+      "dependencies": {"left-pad": "^1.3.0", "lodash.merge": "^4.6.2"}
+    },
+    "node_modules/left-pad": {
+      "version": "1.3.0",
+      "resolved": "https://registry.npmjs.org/left-pad/-/left-pad-1.3.0.tgz",
+      "time": "2018-04-09T01:02:03Z"
+    },
+    "node_modules/lodash.merge": {
+      "version": "4.6.2",
+      "meta": {"published": [1562112000, "2019-07-03T00:00:00Z"]}
+    },
+    "node_modules/left-pad/node_modules/tiny": {
+      "version": "0.0.1",
+      "time": "bogus"
+    }
+  }
+}`
+
+// genericLockFixture is a generic JSON lockfile keyed by package name.
+const genericLockFixture = `{
+  "libfoo": {"version": "2.1.0", "time": 1752417000.5},
+  "libbar": "2025-07-01T00:00:00Z"
+}`
+
+func TestGetTimeFromLockfile(t *testing.T) {
+	filesystem.Default = realFS
+
+	tests := []struct {
+		name      string
+		content   string
+		entry     string
+		fieldPath string
+		want      Time
+		wantErr   error
+	}{
+		{
+			name:      "package lock entry",
+			content:   packageLockFixture,
+			entry:     "left-pad",
+			fieldPath: DefaultLockPath,
+			want:      time.Date(2018, 4, 9, 1, 2, 3, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "nested path with array index",
+			content:   packageLockFixture,
+			entry:     "lodash.merge",
+			fieldPath: "meta.published.0",
+			want:      time.Unix(1562112000, 0),
+			wantErr:   nil,
+		},
+		{
+			name:      "nested package by install path",
+			content:   packageLockFixture,
+			entry:     "node_modules/left-pad/node_modules/tiny",
+			fieldPath: DefaultLockPath,
+			want:      Time{},
+			wantErr:   touchErrors.ErrInvalidLockTime,
+		},
+		{
+			name:      "missing entry",
+			content:   packageLockFixture,
+			entry:     "right-pad",
+			fieldPath: DefaultLockPath,
+			want:      Time{},
+			wantErr:   touchErrors.ErrLockEntryNotFound,
+		},
+		{
+			name:      "missing field",
+			content:   packageLockFixture,
+			entry:     "lodash.merge",
+			fieldPath: DefaultLockPath,
+			want:      Time{},
+			wantErr:   touchErrors.ErrLockFieldNotFound,
+		},
+		{
+			name:      "index out of range",
+			content:   packageLockFixture,
+			entry:     "lodash.merge",
+			fieldPath: "meta.published.2",
+			want:      Time{},
+			wantErr:   touchErrors.ErrLockFieldNotFound,
+		},
+		{
+			name:      "field is an object",
+			content:   packageLockFixture,
+			entry:     "lodash.merge",
+			fieldPath: "meta",
+			want:      Time{},
+			wantErr:   touchErrors.ErrInvalidLockTime,
+		},
+		{
+			name:      "version 1 dependencies",
+			content:   `{"lockfileVersion": 1, "dependencies": {"left-pad": {"time": 1523235723}}}`,
+			entry:     "left-pad",
+			fieldPath: DefaultLockPath,
+			want:      time.Unix(1523235723, 0),
+			wantErr:   nil,
+		},
+		{
+			name:      "generic lockfile with fractional seconds",
+			content:   genericLockFixture,
+			entry:     "libfoo",
+			fieldPath: DefaultLockPath,
+			want:      time.Unix(1752417000, 500_000_000),
+			wantErr:   nil,
+		},
+		{
+			name:      "generic lockfile entry is the time",
+			content:   genericLockFixture,
+			entry:     "libbar",
+			fieldPath: "",
+			want:      time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "not a JSON object",
+			content:   `["left-pad"]`,
+			entry:     "left-pad",
+			fieldPath: DefaultLockPath,
+			want:      Time{},
+			wantErr:   touchErrors.ErrInvalidLockfile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "package-lock.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := GetTimeFromLockfile(path, tt.entry, tt.fieldPath)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromLockfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromLockfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles selecting the earliest time of files matching a glob after a floor.
+package timestamp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// ParseMinAfter parses the RFC3339 floor of --reference-min-after, such as the time of the
+// last build. Fractional seconds are allowed.
+func ParseMinAfter(value string) (Time, error) {
+	floor, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(value))
+	if err != nil {
+		return Time{}, fmt.Errorf("%w: %q", errors.ErrInvalidMinAfter, value)
+	}
+
+	return floor, nil
+}
+
+// MinTimeAfter returns the earliest of times that is strictly later than floor.
+// Returns an error if no time is later than floor.
+func MinTimeAfter(times []Time, floor Time) (Time, error) {
+	var (
+		earliest Time
+		found    bool
+	)
+
+	for _, t := range times {
+		if t.After(floor) && (!found || t.Before(earliest)) {
+			earliest, found = t, true
+		}
+	}
+
+	if !found {
+		return Time{}, fmt.Errorf("%w: %s", errors.ErrNoTimesAfterFloor, floor.Format(time.RFC3339Nano))
+	}
+
+	return earliest, nil
+}
+
+// GetTimeFromMinAfter returns the earliest modification time later than floor among the
+// files matching pattern, as understood by filepath.Glob: the oldest input still newer than
+// the last build. If noDeref is true, matches are read with Lstat. Returns an error if the
+// pattern is malformed, matches nothing, or no match is newer than floor.
+func GetTimeFromMinAfter(pattern string, floor Time, noDeref bool) (Time, error) {
+	modTimes, err := globModTimes(pattern, noDeref)
+	if err != nil {
+		return Time{}, err
+	}
+
+	minTime, err := MinTimeAfter(modTimes, floor)
+	if err != nil {
+		return Time{}, fmt.Errorf("match %s: %w", pattern, err)
+	}
+
+	return minTime, nil
+}
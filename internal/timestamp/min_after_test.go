@@ -0,0 +1,164 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles selecting the earliest time of files matching a glob after a floor.
+package timestamp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+func TestParseMinAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Time
+		wantErr bool
+	}{
+		{
+			name:    "UTC",
+			value:   "2025-07-13T12:00:00Z",
+			want:    time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "offset and fraction",
+			value:   "2025-07-13T14:00:00.5+02:00",
+			want:    time.Date(2025, 7, 13, 12, 0, 0, 500000000, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "missing zone",
+			value:   "2025-07-13T12:00:00",
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "not a time",
+			value:   "yesterday",
+			want:    Time{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMinAfter(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMinAfter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && !errors.Is(err, touchErrors.ErrInvalidMinAfter) {
+				t.Errorf("ParseMinAfter() error = %v, want %v", err, touchErrors.ErrInvalidMinAfter)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseMinAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimeFromMinAfter(t *testing.T) {
+	filesystem.Default = realFS
+
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	dir := t.TempDir()
+
+	// Inputs modified an hour apart either side of base, listed out of time order.
+	offsets := map[string]time.Duration{
+		"a.src": -2 * time.Hour,
+		"b.src": 3 * time.Hour,
+		"c.src": -1 * time.Hour,
+		"d.src": 1 * time.Hour,
+		"e.src": 0,
+		"f.txt": 30 * time.Minute,
+	}
+
+	for name, offset := range offsets {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(path, base.Add(offset), base.Add(offset)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		floor   Time
+		want    Time
+		wantErr error
+	}{
+		{
+			name:    "earliest match above the floor",
+			pattern: filepath.Join(dir, "*.src"),
+			floor:   base.Add(-90 * time.Minute),
+			want:    base.Add(-1 * time.Hour),
+			wantErr: nil,
+		},
+		{
+			name:    "match at the floor is excluded",
+			pattern: filepath.Join(dir, "*.src"),
+			floor:   base,
+			want:    base.Add(time.Hour),
+			wantErr: nil,
+		},
+		{
+			name:    "floor below all matches",
+			pattern: filepath.Join(dir, "*.src"),
+			floor:   base.Add(-24 * time.Hour),
+			want:    base.Add(-2 * time.Hour),
+			wantErr: nil,
+		},
+		{
+			name:    "no match above the floor",
+			pattern: filepath.Join(dir, "*.src"),
+			floor:   base.Add(3 * time.Hour),
+			want:    Time{},
+			wantErr: touchErrors.ErrNoTimesAfterFloor,
+		},
+		{
+			name:    "no matches",
+			pattern: filepath.Join(dir, "*.missing"),
+			floor:   base,
+			want:    Time{},
+			wantErr: touchErrors.ErrNoGlobMatches,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTimeFromMinAfter(tt.pattern, tt.floor, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromMinAfter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromMinAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
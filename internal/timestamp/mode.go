@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles selecting the most common time of files matching a glob.
+package timestamp
+
+import (
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// ModeTime returns the most frequently occurring time in times, comparing instants so that
+// equal times in different locations count together. Ties are broken by the newest time.
+// Returns an error for an empty set.
+func ModeTime(times []Time) (Time, error) {
+	if len(times) == 0 {
+		return Time{}, errors.ErrNoReferenceTimes
+	}
+
+	// Key on seconds and nanoseconds rather than UnixNano, which overflows outside 1678-2262.
+	type instant struct {
+		sec  int64
+		nsec int
+	}
+
+	counts := make(map[instant]int, len(times))
+
+	var (
+		mode      Time
+		modeCount int
+	)
+
+	for _, t := range times {
+		key := instant{sec: t.Unix(), nsec: t.Nanosecond()}
+		counts[key]++
+
+		count := counts[key]
+		if count > modeCount || (count == modeCount && t.After(mode)) {
+			mode, modeCount = t, count
+		}
+	}
+
+	return mode, nil
+}
+
+// GetTimeFromModeGlob returns the most common modification time of the files matching
+// pattern, as understood by filepath.Glob, with ties broken by the newest time. If noDeref
+// is true, matches are read with Lstat. Returns an error if the pattern is malformed or
+// matches nothing.
+func GetTimeFromModeGlob(pattern string, noDeref bool) (Time, error) {
+	modTimes, err := globModTimes(pattern, noDeref)
+	if err != nil {
+		return Time{}, err
+	}
+
+	return ModeTime(modTimes)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles selecting the most common time of files matching a glob.
+package timestamp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+func TestModeTime(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC)
+	later := base.Add(time.Hour)
+	latest := base.Add(2 * time.Hour)
+	offset := time.FixedZone("UTC+2", 2*60*60)
+
+	tests := []struct {
+		name    string
+		times   []Time
+		want    Time
+		wantErr error
+	}{
+		{
+			name:    "one time repeats most often",
+			times:   []Time{later, base, latest, base, later, base},
+			want:    base,
+			wantErr: nil,
+		},
+		{
+			name:    "tie goes to the newest",
+			times:   []Time{base, latest, base, later, latest},
+			want:    latest,
+			wantErr: nil,
+		},
+		{
+			name:    "all distinct goes to the newest",
+			times:   []Time{later, latest, base},
+			want:    latest,
+			wantErr: nil,
+		},
+		{
+			name:    "same instant in different locations counts together",
+			times:   []Time{base, later, base.In(offset), latest, later},
+			want:    later,
+			wantErr: nil,
+		},
+		{
+			name:    "single time",
+			times:   []Time{base},
+			want:    base,
+			wantErr: nil,
+		},
+		{
+			name:    "no times",
+			times:   nil,
+			want:    Time{},
+			wantErr: touchErrors.ErrNoReferenceTimes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ModeTime(tt.times)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ModeTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ModeTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimeFromModeGlob(t *testing.T) {
+	filesystem.Default = realFS
+
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	dir := t.TempDir()
+
+	// Objects sharing a deduplicated time, plus outliers the mode must ignore.
+	modTimes := map[string]Time{
+		"a.o":   base,
+		"b.o":   base,
+		"c.o":   base,
+		"d.o":   base.Add(24 * time.Hour),
+		"e.o":   base.Add(-24 * time.Hour),
+		"x.tie": base,
+		"y.tie": base.Add(time.Hour),
+	}
+	for name, modTime := range modTimes {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    Time
+		wantErr error
+	}{
+		{
+			name:    "most common time",
+			pattern: filepath.Join(dir, "*.o"),
+			want:    base,
+			wantErr: nil,
+		},
+		{
+			name:    "tie goes to the newest",
+			pattern: filepath.Join(dir, "*.tie"),
+			want:    base.Add(time.Hour),
+			wantErr: nil,
+		},
+		{
+			name:    "no matches",
+			pattern: filepath.Join(dir, "*.missing"),
+			want:    Time{},
+			wantErr: touchErrors.ErrNoGlobMatches,
+		},
+		{
+			name:    "malformed pattern",
+			pattern: filepath.Join(dir, "[.o"),
+			want:    Time{},
+			wantErr: filepath.ErrBadPattern,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTimeFromModeGlob(tt.pattern, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromModeGlob() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromModeGlob() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reading the created date of a container image tarball.
+package timestamp
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// ociManifestName is the image archive entry listing each image's config file.
+const ociManifestName = "manifest.json"
+
+// maxOCIMetadataSize bounds how much of the manifest or a config file is read.
+const maxOCIMetadataSize = 16 << 20
+
+// ociManifestEntry is one image in an archive's manifest.json.
+type ociManifestEntry struct {
+	Config string `json:"Config"`
+}
+
+// ociConfig holds the fields read from an image config.
+type ociConfig struct {
+	Created string `json:"created"`
+}
+
+// GetTimeFromOCI returns the created date of the first image in tarball, an archive as
+// written by docker save and compatible tools, read from the config file that its
+// manifest.json names. Gzip-compressed tarballs are detected and read transparently.
+// Returns an error if the tarball isn't a valid image or its config has no created date.
+func GetTimeFromOCI(tarball string) (Time, error) {
+	file, err := filesystem.Default.Open(tarball)
+	if err != nil {
+		return Time{}, fmt.Errorf("open image %s: %w", tarball, err)
+	}
+	defer file.Close()
+
+	// The config usually precedes manifest.json in the archive, so read it in two passes.
+	manifestData, err := readTarEntry(file, ociManifestName)
+	if err != nil {
+		return Time{}, fmt.Errorf("read image %s: %w", tarball, err)
+	}
+
+	var manifest []ociManifestEntry
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return Time{}, fmt.Errorf("%w: %s: %s: %w", touchErrors.ErrInvalidImage, tarball, ociManifestName, err)
+	}
+
+	if len(manifest) == 0 || manifest[0].Config == "" {
+		return Time{}, fmt.Errorf("%w: %s: %s names no config", touchErrors.ErrInvalidImage, tarball, ociManifestName)
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return Time{}, fmt.Errorf("rewind image %s: %w", tarball, err)
+	}
+
+	configData, err := readTarEntry(file, manifest[0].Config)
+	if err != nil {
+		return Time{}, fmt.Errorf("read image %s: %w", tarball, err)
+	}
+
+	var config ociConfig
+	if err := json.Unmarshal(configData, &config); err != nil {
+		return Time{}, fmt.Errorf("%w: %s: config %s: %w", touchErrors.ErrInvalidImage, tarball, manifest[0].Config, err)
+	}
+
+	if config.Created == "" {
+		return Time{}, fmt.Errorf("%w: %s: config has no created date", touchErrors.ErrInvalidImage, tarball)
+	}
+
+	created, err := time.Parse(time.RFC3339Nano, config.Created)
+	if err != nil {
+		return Time{}, fmt.Errorf("%w: %s: created %q: %w", touchErrors.ErrInvalidImage, tarball, config.Created, err)
+	}
+
+	return created, nil
+}
+
+// readTarEntry returns the contents of the entry called name in the tar archive read from
+// file, which may be gzip-compressed. Names are compared after cleaning, so "./x" matches "x".
+func readTarEntry(file *os.File, name string) ([]byte, error) {
+	buffered := bufio.NewReader(file)
+
+	var reader io.Reader = buffered
+
+	if magic, _ := buffered.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("%w: decompress: %w", touchErrors.ErrInvalidImage, err)
+		}
+		defer gzipReader.Close()
+
+		reader = gzipReader
+	}
+
+	archive := tar.NewReader(reader)
+	want := path.Clean(name)
+
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: no %s entry", touchErrors.ErrInvalidImage, name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", touchErrors.ErrInvalidImage, err)
+		}
+
+		if header.Typeflag != tar.TypeReg || path.Clean(header.Name) != want {
+			continue
+		}
+
+		if header.Size > maxOCIMetadataSize {
+			return nil, fmt.Errorf("%w: %s is larger than %d bytes", touchErrors.ErrInvalidImage, name, maxOCIMetadataSize)
+		}
+
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", touchErrors.ErrInvalidImage, name, err)
+		}
+
+		return data, nil
+	}
+}
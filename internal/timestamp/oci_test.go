@@ -0,0 +1,218 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reading the created date of a container image tarball.
+package timestamp
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// ociConfigBlob is the config path used by the docker save fixtures.
+const ociConfigBlob = "blobs/sha256/3f57d9401f8d42f986df300f0c69192fc41da28ccc8d797829467780db3dd741"
+
+// tarEntry is one file written by writeTar.
+type tarEntry struct {
+	name string
+	data string
+}
+
+// writeTar writes entries to a tar archive at path, gzip-compressing it if compress is set.
+func writeTar(t *testing.T, path string, compress bool, entries ...tarEntry) {
+	t.Helper()
+
+	var archive bytes.Buffer
+
+	writer := tar.NewWriter(&archive)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0o644, Size: int64(len(entry.data)), Typeflag: tar.TypeReg}
+		if err := writer.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := writer.Write([]byte(entry.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := archive.Bytes()
+
+	if compress {
+		var compressed bytes.Buffer
+
+		gzipWriter := gzip.NewWriter(&compressed)
+		if _, err := gzipWriter.Write(data); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := gzipWriter.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		data = compressed.Bytes()
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// imageEntries returns a minimal docker save archive whose config, stored before the
+// manifest as docker writes it, carries created.
+func imageEntries(created string) []tarEntry {
+	return []tarEntry{
+		{name: ociConfigBlob, data: `{"architecture":"amd64","created":"` + created + `","os":"linux"}`},
+		{name: "blobs/sha256/layer", data: "layer data"},
+		{name: "manifest.json", data: `[{"Config":"` + ociConfigBlob + `","RepoTags":["app:1.0"],"Layers":["blobs/sha256/layer"]}]`},
+	}
+}
+
+func TestGetTimeFromOCI(t *testing.T) {
+	filesystem.Default = realFS
+
+	dir := t.TempDir()
+
+	image := filepath.Join(dir, "image.tar")
+	writeTar(t, image, false, imageEntries("2024-05-06T07:08:09.123456789Z")...)
+
+	gzipped := filepath.Join(dir, "image.tar.gz")
+	writeTar(t, gzipped, true, imageEntries("2024-05-06T07:08:09Z")...)
+
+	// Older docker save archives name the config <id>.json beside a ./-prefixed manifest.
+	legacy := filepath.Join(dir, "legacy.tar")
+	writeTar(t, legacy, false,
+		tarEntry{name: "./manifest.json", data: `[{"Config":"abc.json"}]`},
+		tarEntry{name: "./abc.json", data: `{"created":"2023-01-02T03:04:05+02:00"}`},
+	)
+
+	noManifest := filepath.Join(dir, "no-manifest.tar")
+	writeTar(t, noManifest, false, tarEntry{name: "hello.txt", data: "hello"})
+
+	missingConfig := filepath.Join(dir, "missing-config.tar")
+	writeTar(t, missingConfig, false, tarEntry{name: "manifest.json", data: `[{"Config":"gone.json"}]`})
+
+	emptyManifest := filepath.Join(dir, "empty-manifest.tar")
+	writeTar(t, emptyManifest, false, tarEntry{name: "manifest.json", data: `[]`})
+
+	noCreated := filepath.Join(dir, "no-created.tar")
+	writeTar(t, noCreated, false,
+		tarEntry{name: "manifest.json", data: `[{"Config":"c.json"}]`},
+		tarEntry{name: "c.json", data: `{"os":"linux"}`},
+	)
+
+	badCreated := filepath.Join(dir, "bad-created.tar")
+	writeTar(t, badCreated, false, imageEntries("last tuesday")...)
+
+	notTar := filepath.Join(dir, "not.tar")
+	if err := os.WriteFile(notTar, []byte("plain text, not an archive\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		tarball string
+		want    Time
+		wantErr error
+	}{
+		{
+			name:    "docker save archive",
+			tarball: image,
+			want:    time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "gzip-compressed archive",
+			tarball: gzipped,
+			want:    time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "legacy archive with offset",
+			tarball: legacy,
+			want:    time.Date(2023, 1, 2, 1, 4, 5, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "no manifest",
+			tarball: noManifest,
+			want:    Time{},
+			wantErr: touchErrors.ErrInvalidImage,
+		},
+		{
+			name:    "manifest names a missing config",
+			tarball: missingConfig,
+			want:    Time{},
+			wantErr: touchErrors.ErrInvalidImage,
+		},
+		{
+			name:    "empty manifest",
+			tarball: emptyManifest,
+			want:    Time{},
+			wantErr: touchErrors.ErrInvalidImage,
+		},
+		{
+			name:    "config without created date",
+			tarball: noCreated,
+			want:    Time{},
+			wantErr: touchErrors.ErrInvalidImage,
+		},
+		{
+			name:    "invalid created date",
+			tarball: badCreated,
+			want:    Time{},
+			wantErr: touchErrors.ErrInvalidImage,
+		},
+		{
+			name:    "not a tarball",
+			tarball: notTar,
+			want:    Time{},
+			wantErr: touchErrors.ErrInvalidImage,
+		},
+		{
+			name:    "missing tarball",
+			tarball: filepath.Join(dir, "missing.tar"),
+			want:    Time{},
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTimeFromOCI(tt.tarball)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromOCI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromOCI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -20,6 +20,8 @@ package timestamp
 
 import (
 	"fmt"
+	"math"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -46,7 +48,10 @@ const (
 	minuteMin          = 0
 	minuteMax          = 59
 	minSecond          = 0
-	maxSecond          = 61 // Allow for leap seconds.
+	maxSecond          = 60 // Allow for a leap second, as POSIX does.
+	hoursPerDay        = 24
+	daysPerWeek        = 7
+	nanosecondDigits   = 9 // Digits of sub-second precision kept from an @epoch fraction.
 )
 
 // Time is an alias for time.Time, used for clarity in function signatures.
@@ -55,10 +60,33 @@ type Time = time.Time
 // Now is a variable function that returns the current time, allowing tests to override it.
 var Now = time.Now
 
+// posixStampPattern matches the digit-only shapes of [[CC]YY]MMDDhhmm[.ss].
+var posixStampPattern = regexp.MustCompile(`^(\d{8}|\d{10}|\d{12})(\.\d{2})?$`)
+
+// IsPosixStamp reports whether s has the exact shape of a POSIX timestamp.
+// It only checks the shape (8, 10, or 12 digits with an optional .ss suffix);
+// ParsePosixTime is still responsible for validating the component ranges.
+func IsPosixStamp(s string) bool {
+	return posixStampPattern.MatchString(s)
+}
+
 // ParsePosixTime parses the POSIX timestamp format [[CC]YY]MMDDhhmm[.ss].
 // Handles century/year variations and validates component ranges.
+// Seconds run from 00 to 60. Go's time has no leap seconds, so .60 is normalized by
+// time.Date to the first second of the next minute, as GNU touch does.
 // Returns a time.Time in the local timezone or an error if invalid.
 func ParsePosixTime(timestampStr string) (Time, error) {
+	return ParsePosixTimeIn(timestampStr, time.Local)
+}
+
+// ParsePosixTimeIn is like ParsePosixTime, but interprets the timestamp in loc, such as
+// time.UTC for --utc, and takes the current year for MMDDhhmm from the date in loc.
+func ParsePosixTimeIn(timestampStr string, loc *time.Location) (Time, error) {
+	return ParsePosixTimeAt(timestampStr, loc, Now())
+}
+
+// ParsePosixTimeAt is like ParsePosixTimeIn, but takes the current year from now instead of Now.
+func ParsePosixTimeAt(timestampStr string, loc *time.Location, now Time) (Time, error) {
 	dotIndex := strings.Index(timestampStr, ".")
 	second := 0
 
@@ -116,7 +144,7 @@ func ParsePosixTime(timestampStr string) (Time, error) {
 
 		timestampStr = timestampStr[2:]
 	case posixMonthLength: // MMDDhhmm
-		year = Now().Year()
+		year = now.In(loc).Year()
 	default:
 		return Time{}, fmt.Errorf("%w: %s", errors.ErrInvalidPosixLength, timestampStr)
 	}
@@ -148,21 +176,129 @@ func ParsePosixTime(timestampStr string) (Time, error) {
 		return Time{}, errors.ErrInvalidDateTimeValues
 	}
 
-	return time.Date(year, time.Month(month), day, hour, minuteValue, second, 0, time.Local), nil
+	return time.Date(year, time.Month(month), day, hour, minuteValue, second, 0, loc), nil
+}
+
+// LoadTimezone resolves an IANA time zone name such as "America/New_York" for ParseDateIn
+// and ParsePosixTimeIn. Returns an error if the name is unknown.
+func LoadTimezone(name string) (*time.Location, error) {
+	loc, err := time.LoadLocation(strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", errors.ErrInvalidTimezone, name)
+	}
+
+	return loc, nil
+}
+
+// relativeDayOffsets maps the day keywords accepted by ParseDate to their offset from today.
+var relativeDayOffsets = map[string]int{
+	"yesterday": -1,
+	"today":     0,
+	"tomorrow":  1,
 }
 
-// ParseDate parses a date string using predefined formats.
-// Supports RFC3339, YYYY-MM-DDTHH:MM:SS, YYYY-MM-DD HH:MM:SS, YYYY-MM-DDTHH:MM, YYYY-MM-DD, HH:MM:SS, HH:MM.
-// Assumes local timezone; returns a time.Time or an error if the format is unsupported.
+// epochPattern matches GNU-style "@SECONDS[.FRACTION]" epoch times, such as "@1752416200"
+// or "@-86400.5".
+var epochPattern = regexp.MustCompile(`^@([+-]?\d+)(?:\.(\d+))?$`)
+
+// relativeOffsetPattern matches signed offsets such as "+2 days" or "-3 hours".
+// The leading sign is required, so absolute dates like 2025-07-13 never match.
+var relativeOffsetPattern = regexp.MustCompile(`^([+-])\s*(\d+)\s*(second|minute|hour|day|week)s?$`)
+
+// relativeOffsetUnits maps the units accepted in relative offsets to their length.
+// Days and weeks are fixed multiples of 24 hours.
+var relativeOffsetUnits = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    hoursPerDay * time.Hour,
+	"week":   daysPerWeek * hoursPerDay * time.Hour,
+}
+
+// dateFormats lists the layouts ParseDateAt tries, in order, after the other forms: RFC3339
+// and ISO-style dates with an optional time and offset, times of day alone, month-name dates
+// as in ls -l and log files, and 12-hour times. Times of day alone fall on the current date,
+// and month-name dates without a year in the current year.
+var dateFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"15:04:05",
+	"15:04",
+	"Jan _2 2006 15:04:05",
+	"Jan _2 2006 15:04",
+	"Jan _2 2006",
+	"Jan _2 15:04:05",
+	"Jan _2 15:04",
+	"_2 Jan 2006 15:04:05",
+	"_2 Jan 2006 15:04",
+	"_2 Jan 2006",
+	"January _2 2006 15:04:05",
+	"January _2 2006 15:04",
+	"January _2 2006",
+	"01/02/2006 3:04:05 PM",
+	"01/02/2006 3:04 PM",
+	"01/02/2006 3:04PM",
+	"3:04:05 PM",
+	"3:04 PM",
+	"3:04PM",
+}
+
+// ParseDate parses a -d date string, resolving relative forms against Now.
+// It accepts "@SECONDS[.FRACTION]" epoch times, the keywords now, today, yesterday, and
+// tomorrow, signed offsets such as "+2 days", ISO 8601 week dates, and the dateFormats
+// layouts. Times with an explicit offset keep it; others assume the local timezone.
+// Returns ErrUnsupportedDateFormat if no form matches.
 func ParseDate(dateStr string) (Time, error) {
-	formats := []string{
-		time.RFC3339,
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05",
-		"2006-01-02T15:04",
-		"2006-01-02",
-		"15:04:05",
-		"15:04",
+	return ParseDateIn(dateStr, "", time.Local)
+}
+
+// ParseDateIn is like ParseDate, but interprets times without an explicit offset in loc,
+// such as time.UTC for --utc. Day keywords resolve to midnight, and time-only and yearless
+// dates to the current date or year, in loc. A non-empty layout, as given with --format,
+// is used as the only Go time layout instead of the built-in formats and keywords.
+func ParseDateIn(dateStr, layout string, loc *time.Location) (Time, error) {
+	return ParseDateAt(dateStr, layout, loc, Now())
+}
+
+// ParseDateAt is like ParseDateIn, but resolves keywords, offsets, and dates without a day
+// or year against now instead of Now.
+func ParseDateAt(dateStr, layout string, loc *time.Location, now Time) (Time, error) {
+	if layout != "" {
+		parsedTime, err := time.ParseInLocation(layout, dateStr, loc)
+		if err != nil {
+			return Time{}, fmt.Errorf(
+				"%w: %q with %q: %w",
+				errors.ErrDateLayoutMismatch,
+				dateStr,
+				layout,
+				err,
+			)
+		}
+
+		return parsedTime, nil
+	}
+
+	if epochTime, ok, err := parseEpoch(dateStr); ok {
+		return epochTime, err
+	}
+
+	if keywordTime, ok, err := parseDateKeyword(dateStr, loc, now); ok {
+		return keywordTime, err
+	}
+
+	if offsetTime, ok, err := parseRelativeOffset(dateStr, now); ok {
+		return offsetTime, err
+	}
+
+	if weekTime, ok, err := parseWeekDate(dateStr, loc); ok {
+		return weekTime, err
 	}
 
 	var (
@@ -170,16 +306,22 @@ func ParseDate(dateStr string) (Time, error) {
 		parseErr   error
 	)
 
-	now := Now()
+	now = now.In(loc)
 	isTimeOnly := false
+	isYearless := false
 
-	for _, format := range formats {
-		parsedTime, parseErr = time.ParseInLocation(format, dateStr, time.Local)
+	for _, format := range dateFormats {
+		parsedTime, parseErr = time.ParseInLocation(format, dateStr, loc)
 		if parseErr == nil {
-			if format == "15:04:05" || format == "15:04" {
+			switch format {
+			case "15:04:05", "15:04", "3:04:05 PM", "3:04 PM", "3:04PM":
 				isTimeOnly = true
 			}
 
+			if format == "Jan _2 15:04:05" || format == "Jan _2 15:04" {
+				isYearless = true
+			}
+
 			break
 		}
 	}
@@ -197,9 +339,131 @@ func ParseDate(dateStr string) (Time, error) {
 			parsedTime.Minute(),
 			parsedTime.Second(),
 			0,
-			time.Local,
+			loc,
+		)
+	}
+
+	if isYearless {
+		month, day := parsedTime.Month(), parsedTime.Day()
+
+		parsedTime = time.Date(
+			now.Year(),
+			month,
+			day,
+			parsedTime.Hour(),
+			parsedTime.Minute(),
+			parsedTime.Second(),
+			0,
+			loc,
 		)
+
+		// Reject a day missing from the current year, such as Feb 29 outside a leap year,
+		// rather than letting time.Date roll it over into the next month.
+		if parsedTime.Month() != month || parsedTime.Day() != day {
+			return Time{}, errors.ErrInvalidDateTimeValues
+		}
 	}
 
 	return parsedTime, nil
 }
+
+// parseEpoch resolves an "@SECONDS[.FRACTION]" epoch time in UTC. It reports false when
+// dateStr doesn't start with "@", and an error when the rest isn't a number of seconds
+// that fits in an int64. Fractions beyond nanosecond precision are truncated, and the
+// fraction of a negative epoch counts further back, so "@-1.5" is 1.5 seconds before 1970.
+func parseEpoch(dateStr string) (Time, bool, error) {
+	trimmed := strings.TrimSpace(dateStr)
+	if !strings.HasPrefix(trimmed, "@") {
+		return Time{}, false, nil
+	}
+
+	match := epochPattern.FindStringSubmatch(trimmed)
+	if match == nil {
+		return Time{}, true, fmt.Errorf("%w: invalid epoch time %q", errors.ErrUnsupportedDateFormat, dateStr)
+	}
+
+	seconds, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return Time{}, true, fmt.Errorf("%w: epoch time %q is out of range", errors.ErrUnsupportedDateFormat, dateStr)
+	}
+
+	var nanoseconds int64
+
+	if fraction := match[2]; fraction != "" {
+		fraction = (fraction + strings.Repeat("0", nanosecondDigits))[:nanosecondDigits]
+		nanoseconds, _ = strconv.ParseInt(fraction, 10, 64) // At most nine digits, so it can't fail.
+
+		if strings.HasPrefix(match[1], "-") {
+			nanoseconds = -nanoseconds
+		}
+	}
+
+	return time.Unix(seconds, nanoseconds).UTC(), true, nil
+}
+
+// parseDateKeyword resolves a relative date keyword against now, with the day keywords at
+// midnight in loc. It reports false when dateStr doesn't start with a keyword, and an error
+// when a keyword is followed by other text, such as "yesterday 14:30", rather than silently
+// dropping it.
+func parseDateKeyword(dateStr string, loc *time.Location, now Time) (Time, bool, error) {
+	fields := strings.Fields(strings.ToLower(dateStr))
+	if len(fields) == 0 {
+		return Time{}, false, nil
+	}
+
+	offset, isDay := relativeDayOffsets[fields[0]]
+	if !isDay && fields[0] != "now" {
+		return Time{}, false, nil
+	}
+
+	if len(fields) > 1 {
+		return Time{}, true, fmt.Errorf(
+			"%w: %q can't be combined with %q",
+			errors.ErrUnsupportedDateFormat,
+			fields[0],
+			strings.Join(fields[1:], " "),
+		)
+	}
+
+	if !isDay {
+		return now, true, nil
+	}
+
+	now = now.In(loc)
+
+	return time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, loc), true, nil
+}
+
+// parseRelativeOffset resolves a signed "[+-]N unit" offset against now. It reports false
+// when dateStr isn't an offset, and an error when the offset overflows a time.Duration.
+func parseRelativeOffset(dateStr string, now Time) (Time, bool, error) {
+	offset, ok, err := parseOffset(dateStr)
+	if !ok || err != nil {
+		return Time{}, ok, err
+	}
+
+	return now.Add(offset), true, nil
+}
+
+// parseOffset parses a signed "[+-]N unit" offset such as "+2 days". It reports false when
+// value isn't an offset, and an error when the offset overflows a time.Duration.
+func parseOffset(value string) (time.Duration, bool, error) {
+	match := relativeOffsetPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, false, nil
+	}
+
+	unit := relativeOffsetUnits[match[3]]
+
+	count, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil || count > math.MaxInt64/int64(unit) {
+		return 0, true, fmt.Errorf("%w: offset %q is too large", errors.ErrUnsupportedDateFormat, value)
+	}
+
+	offset := time.Duration(count) * unit
+	if match[1] == "-" {
+		offset = -offset
+	}
+
+	return offset, true, nil
+}
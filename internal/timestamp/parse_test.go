@@ -19,8 +19,11 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package timestamp
 
 import (
+	"errors"
 	"testing"
 	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
 )
 
 func TestParsePosixTime(t *testing.T) {
@@ -134,11 +137,29 @@ func TestParsePosixTime(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "leap second allowed",
+			name:    "leap second normalized to next minute",
 			args:    args{timestampStr: "202507131430.60"},
-			want:    time.Date(2025, 7, 13, 14, 30, 60, 0, time.Local),
+			want:    time.Date(2025, 7, 13, 14, 31, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "leap second at year end rolls over",
+			args:    args{timestampStr: "202512312359.60"},
+			want:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "last regular second",
+			args:    args{timestampStr: "202507131430.59"},
+			want:    time.Date(2025, 7, 13, 14, 30, 59, 0, time.Local),
 			wantErr: false,
 		},
+		{
+			name:    "second 61 rejected",
+			args:    args{timestampStr: "202507131430.61"},
+			want:    Time{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -162,6 +183,71 @@ func TestParsePosixTime(t *testing.T) {
 	}
 }
 
+func TestIsPosixStamp(t *testing.T) {
+	type args struct {
+		s string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "month format",
+			args: args{s: "07131430"},
+			want: true,
+		},
+		{
+			name: "year format",
+			args: args{s: "2507131430"},
+			want: true,
+		},
+		{
+			name: "full format with seconds",
+			args: args{s: "202507131430.30"},
+			want: true,
+		},
+		{
+			name: "embedded sign parses but is not stamp-shaped",
+			args: args{s: "07+31430"},
+			want: false,
+		},
+		{
+			name: "nine digits",
+			args: args{s: "071314300"},
+			want: false,
+		},
+		{
+			name: "single-digit seconds",
+			args: args{s: "07131430.5"},
+			want: false,
+		},
+		{
+			name: "trailing extension",
+			args: args{s: "07131430.txt"},
+			want: false,
+		},
+		{
+			name: "leading whitespace",
+			args: args{s: " 7131430"},
+			want: false,
+		},
+		{
+			name: "empty string",
+			args: args{s: ""},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPosixStamp(tt.args.s); got != tt.want {
+				t.Errorf("IsPosixStamp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseDate(t *testing.T) {
 	type args struct {
 		dateStr string
@@ -197,6 +283,12 @@ func TestParseDate(t *testing.T) {
 			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
 			wantErr: false,
 		},
+		{
+			name:    "YYYY-MM-DD HH:MM",
+			args:    args{dateStr: "2025-07-13 14:30"},
+			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr: false,
+		},
 		{
 			name:    "YYYY-MM-DD",
 			args:    args{dateStr: "2025-07-13"},
@@ -215,6 +307,120 @@ func TestParseDate(t *testing.T) {
 			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
 			wantErr: false,
 		},
+		{
+			name:    "month name with year and time",
+			args:    args{dateStr: "Jul 13 2025 14:30"},
+			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "month name with year and seconds",
+			args:    args{dateStr: "Jul 13 2025 14:30:15"},
+			want:    time.Date(2025, 7, 13, 14, 30, 15, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "month name without year uses current year",
+			args:    args{dateStr: "Mar 13 08:15"},
+			want:    time.Date(2025, 3, 13, 8, 15, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "month name with padded single-digit day",
+			args:    args{dateStr: "Mar  5 08:15:30"},
+			want:    time.Date(2025, 3, 5, 8, 15, 30, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "month name with unpadded single-digit day",
+			args:    args{dateStr: "Mar 5 2024"},
+			want:    time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "day before month name",
+			args:    args{dateStr: "13 Jul 2025"},
+			want:    time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "single-digit day before month name",
+			args:    args{dateStr: "5 Jul 2025 14:30"},
+			want:    time.Date(2025, 7, 5, 14, 30, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "full month name",
+			args:    args{dateStr: "July 13 2025 14:30"},
+			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12-hour date and time",
+			args:    args{dateStr: "07/13/2025 02:30 PM"},
+			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12-hour unpadded hour without space",
+			args:    args{dateStr: "07/13/2025 2:30PM"},
+			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12-hour with seconds",
+			args:    args{dateStr: "07/13/2025 9:05:07 AM"},
+			want:    time.Date(2025, 7, 13, 9, 5, 7, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12:00 PM is noon",
+			args:    args{dateStr: "07/13/2025 12:00 PM"},
+			want:    time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12:00 AM is midnight",
+			args:    args{dateStr: "07/13/2025 12:00 AM"},
+			want:    time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12:30 AM is after midnight",
+			args:    args{dateStr: "07/13/2025 12:30 AM"},
+			want:    time.Date(2025, 7, 13, 0, 30, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12-hour time only uses today",
+			args:    args{dateStr: "3:04 PM"},
+			want:    time.Date(2025, 7, 13, 15, 4, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12-hour time only noon",
+			args:    args{dateStr: "12:00PM"},
+			want:    time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12-hour time only midnight",
+			args:    args{dateStr: "12:00:00 AM"},
+			want:    time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "12-hour time with 24-hour value",
+			args:    args{dateStr: "13:00 PM"},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid month name",
+			args:    args{dateStr: "Jly 13 2025"},
+			want:    Time{},
+			wantErr: true,
+		},
 		{
 			name:    "invalid format",
 			args:    args{dateStr: "2025/07/13"},
@@ -239,18 +445,180 @@ func TestParseDate(t *testing.T) {
 			want:    time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local),
 			wantErr: false,
 		},
+		{
+			name:    "keyword now",
+			args:    args{dateStr: "now"},
+			want:    time.Date(2025, 7, 13, 9, 45, 30, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "keyword today snaps to midnight",
+			args:    args{dateStr: "today"},
+			want:    time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "keyword yesterday mixed case and spaces",
+			args:    args{dateStr: "  Yesterday "},
+			want:    time.Date(2025, 7, 12, 0, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "keyword tomorrow",
+			args:    args{dateStr: "TOMORROW"},
+			want:    time.Date(2025, 7, 14, 0, 0, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "keyword with time is rejected",
+			args:    args{dateStr: "yesterday 14:30"},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "now with extra text is rejected",
+			args:    args{dateStr: "now please"},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "offset plural days",
+			args:    args{dateStr: "+2 days"},
+			want:    time.Date(2025, 7, 15, 9, 45, 30, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "offset singular day",
+			args:    args{dateStr: "-1 day"},
+			want:    time.Date(2025, 7, 12, 9, 45, 30, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "offset hours",
+			args:    args{dateStr: "-3 hours"},
+			want:    time.Date(2025, 7, 13, 6, 45, 30, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "offset minutes and seconds",
+			args:    args{dateStr: "+90 seconds"},
+			want:    time.Date(2025, 7, 13, 9, 47, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "offset single minute",
+			args:    args{dateStr: "+1 minute"},
+			want:    time.Date(2025, 7, 13, 9, 46, 30, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "offset weeks mixed case",
+			args:    args{dateStr: " +2 Weeks "},
+			want:    time.Date(2025, 7, 27, 9, 45, 30, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "zero offset",
+			args:    args{dateStr: "+0 hours"},
+			want:    time.Date(2025, 7, 13, 9, 45, 30, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "offset without count",
+			args:    args{dateStr: "+ days"},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "offset without sign",
+			args:    args{dateStr: "2 days"},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "offset with unknown unit",
+			args:    args{dateStr: "+2 fortnights"},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "offset overflow",
+			args:    args{dateStr: "+99999999999 weeks"},
+			want:    Time{},
+			wantErr: true,
+		},
 		{
 			name:    "non-leap year invalid",
 			args:    args{dateStr: "2025-02-29"},
 			want:    Time{},
 			wantErr: true,
 		},
+		{
+			name:    "epoch seconds",
+			args:    args{dateStr: "@1752416200"},
+			want:    time.Date(2025, 7, 13, 14, 16, 40, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "epoch zero",
+			args:    args{dateStr: "@0"},
+			want:    time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "epoch with fraction",
+			args:    args{dateStr: "@1752416200.25"},
+			want:    time.Date(2025, 7, 13, 14, 16, 40, 250000000, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "epoch fraction beyond nanoseconds is truncated",
+			args:    args{dateStr: "@1.0000000019"},
+			want:    time.Date(1970, 1, 1, 0, 0, 1, 1, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "negative epoch",
+			args:    args{dateStr: "@-86400"},
+			want:    time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "negative epoch with fraction",
+			args:    args{dateStr: "@-1.5"},
+			want:    time.Date(1969, 12, 31, 23, 59, 58, 500000000, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "epoch without digits",
+			args:    args{dateStr: "@"},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "non-numeric epoch",
+			args:    args{dateStr: "@yesterday"},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "epoch with trailing text",
+			args:    args{dateStr: "@1752416200 UTC"},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "epoch out of range",
+			args:    args{dateStr: "@99999999999999999999"},
+			want:    Time{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Set up fixed current time for consistency in tests.
+			// Set up fixed current time for consistency in tests; mid-morning so day keywords visibly snap to midnight.
 			origNow := Now
-			Now = func() Time { return time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local) }
+			Now = func() Time { return time.Date(2025, 7, 13, 9, 45, 30, 0, time.Local) }
 
 			defer func() { Now = origNow }()
 
@@ -264,6 +632,341 @@ func TestParseDate(t *testing.T) {
 			if !got.Equal(tt.want) {
 				t.Errorf("ParseDate() got = %v, want %v", got, tt.want)
 			}
+
+			if tt.want.Location() == time.UTC && got.Location() != time.UTC {
+				t.Errorf("ParseDate() location = %v, want UTC", got.Location())
+			}
+		})
+	}
+}
+
+func TestParseDate_offsets(t *testing.T) {
+	// Parse in a zone with daylight saving time, so an offset reinterpreted as local time
+	// would land on a different instant around the transitions.
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	origLocal := time.Local
+	time.Local = newYork
+
+	defer func() { time.Local = origLocal }()
+
+	tests := []struct {
+		name       string
+		dateStr    string
+		want       Time
+		wantOffset int
+	}{
+		{
+			name:       "space-separated numeric offset",
+			dateStr:    "2025-07-13 14:30:00 +0200",
+			want:       time.Date(2025, 7, 13, 12, 30, 0, 0, time.UTC),
+			wantOffset: 2 * 60 * 60,
+		},
+		{
+			name:       "T-separated numeric offset",
+			dateStr:    "2025-07-13T14:30:00-0700",
+			want:       time.Date(2025, 7, 13, 21, 30, 0, 0, time.UTC),
+			wantOffset: -7 * 60 * 60,
+		},
+		{
+			name:       "space-separated colon offset",
+			dateStr:    "2025-07-13 14:30:00-07:00",
+			want:       time.Date(2025, 7, 13, 21, 30, 0, 0, time.UTC),
+			wantOffset: -7 * 60 * 60,
+		},
+		{
+			name:       "space-separated Z",
+			dateStr:    "2025-07-13 14:30:00Z",
+			want:       time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+			wantOffset: 0,
+		},
+		{
+			name:       "before spring-forward transition",
+			dateStr:    "2025-03-09 01:30:00 -0500",
+			want:       time.Date(2025, 3, 9, 6, 30, 0, 0, time.UTC),
+			wantOffset: -5 * 60 * 60,
+		},
+		{
+			name:       "after spring-forward transition",
+			dateStr:    "2025-03-09 03:30:00 -0400",
+			want:       time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC),
+			wantOffset: -4 * 60 * 60,
+		},
+		{
+			name:       "time skipped locally by spring-forward",
+			dateStr:    "2025-03-09 02:30:00 -0500",
+			want:       time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC),
+			wantOffset: -5 * 60 * 60,
+		},
+		{
+			name:       "first of repeated fall-back hour",
+			dateStr:    "2025-11-02 01:30:00 -0400",
+			want:       time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC),
+			wantOffset: -4 * 60 * 60,
+		},
+		{
+			name:       "second of repeated fall-back hour",
+			dateStr:    "2025-11-02 01:30:00 -0500",
+			want:       time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC),
+			wantOffset: -5 * 60 * 60,
+		},
+		{
+			name:       "offset differing from local daylight time",
+			dateStr:    "2025-07-13T14:30:00-0500",
+			want:       time.Date(2025, 7, 13, 19, 30, 0, 0, time.UTC),
+			wantOffset: -5 * 60 * 60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.dateStr)
+			if err != nil {
+				t.Fatalf("ParseDate() error = %v", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate() got = %v, want %v", got, tt.want)
+			}
+
+			if _, offset := got.Zone(); offset != tt.wantOffset {
+				t.Errorf("ParseDate() offset = %d, want %d", offset, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestParseIn_locations(t *testing.T) {
+	// Use a fixed local zone east of UTC, so local and UTC readings of the same wall clock
+	// time are two hours apart whatever the machine's own zone.
+	origLocal := time.Local
+	time.Local = time.FixedZone("UTC+2", 2*60*60)
+
+	defer func() { time.Local = origLocal }()
+
+	origNow := Now
+	Now = func() Time { return time.Date(2025, 7, 13, 9, 45, 30, 0, time.UTC) }
+
+	defer func() { Now = origNow }()
+
+	parsers := map[string]func(string, *time.Location) (Time, error){
+		"ParseDateIn": func(value string, loc *time.Location) (Time, error) {
+			return ParseDateIn(value, "", loc)
+		},
+		"ParsePosixTimeIn": ParsePosixTimeIn,
+	}
+
+	tests := []struct {
+		name    string
+		parser  string
+		value   string
+		wantUTC Time
+	}{
+		{
+			name:    "date and time",
+			parser:  "ParseDateIn",
+			value:   "2025-07-13 14:30:00",
+			wantUTC: time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "time only",
+			parser:  "ParseDateIn",
+			value:   "14:30",
+			wantUTC: time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "month name without year",
+			parser:  "ParseDateIn",
+			value:   "Jan 2 03:04",
+			wantUTC: time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC),
+		},
+		{
+			name:    "day keyword",
+			parser:  "ParseDateIn",
+			value:   "today",
+			wantUTC: time.Date(2025, 7, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "POSIX stamp with century",
+			parser:  "ParsePosixTimeIn",
+			value:   "202507131430.15",
+			wantUTC: time.Date(2025, 7, 13, 14, 30, 15, 0, time.UTC),
+		},
+		{
+			name:    "POSIX stamp without year",
+			parser:  "ParsePosixTimeIn",
+			value:   "07131430",
+			wantUTC: time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parse := parsers[tt.parser]
+
+			gotUTC, err := parse(tt.value, time.UTC)
+			if err != nil {
+				t.Fatalf("%s(%q, UTC) error = %v", tt.parser, tt.value, err)
+			}
+
+			if !gotUTC.Equal(tt.wantUTC) || gotUTC.Location() != time.UTC {
+				t.Errorf("%s(%q, UTC) = %v, want %v", tt.parser, tt.value, gotUTC, tt.wantUTC)
+			}
+
+			gotLocal, err := parse(tt.value, time.Local)
+			if err != nil {
+				t.Fatalf("%s(%q, Local) error = %v", tt.parser, tt.value, err)
+			}
+
+			// The same wall clock time in UTC+2 is two hours earlier.
+			if diff := gotUTC.Sub(gotLocal); diff != 2*time.Hour {
+				t.Errorf("UTC reading - local reading = %v, want %v", diff, 2*time.Hour)
+			}
+		})
+	}
+}
+
+func TestParseDateIn_layout(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		layout    string
+		loc       *time.Location
+		want      Time
+		wantErrIs error
+	}{
+		{
+			name:   "dotted day first date",
+			value:  "13.07.2025",
+			layout: "02.01.2006",
+			loc:    time.UTC,
+			want:   time.Date(2025, 7, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "layout with time in zone",
+			value:  "2025/07/13 at 14h30",
+			layout: "2006/01/02 at 15h04",
+			loc:    time.FixedZone("UTC+2", 2*60*60),
+			want:   time.Date(2025, 7, 13, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			name:   "layout overrides built-in formats",
+			value:  "01/02/2025",
+			layout: "02/01/2006",
+			loc:    time.UTC,
+			want:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "input not matching layout",
+			value:     "2025-07-13",
+			layout:    "02.01.2006",
+			loc:       time.UTC,
+			wantErrIs: touchErrors.ErrDateLayoutMismatch,
+		},
+		{
+			name:      "keyword not accepted with layout",
+			value:     "today",
+			layout:    "02.01.2006",
+			loc:       time.UTC,
+			wantErrIs: touchErrors.ErrDateLayoutMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateIn(tt.value, tt.layout, tt.loc)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("ParseDateIn(%q, %q) error = %v, want %v", tt.value, tt.layout, err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseDateIn(%q, %q) error = %v", tt.value, tt.layout, err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDateIn(%q, %q) = %v, want %v", tt.value, tt.layout, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateAt(t *testing.T) {
+	// A fixed now is passed explicitly, so Now is never read or replaced.
+	now := time.Date(2025, 7, 13, 9, 45, 30, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  Time
+	}{
+		{name: "now", value: "now", want: now},
+		{name: "yesterday", value: "yesterday", want: time.Date(2025, 7, 12, 0, 0, 0, 0, time.UTC)},
+		{name: "relative offset", value: "+2 days", want: now.Add(48 * time.Hour)},
+		{name: "time only", value: "14:30", want: time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC)},
+		{name: "yearless", value: "Jan 2 03:04", want: time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)},
+		{name: "absolute", value: "2024-02-03", want: time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateAt(tt.value, "", time.UTC, now)
+			if err != nil {
+				t.Fatalf("ParseDateAt(%q) error = %v", tt.value, err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDateAt(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateAt_yearlessLeapDay(t *testing.T) {
+	tests := []struct {
+		name    string
+		now     Time
+		want    Time
+		wantErr error
+	}{
+		{
+			name:    "leap year",
+			now:     time.Date(2024, 7, 13, 9, 45, 30, 0, time.UTC),
+			want:    time.Date(2024, 2, 29, 14, 30, 0, 0, time.UTC),
+			wantErr: nil,
+		},
+		{
+			name:    "non-leap year",
+			now:     time.Date(2025, 7, 13, 9, 45, 30, 0, time.UTC),
+			want:    Time{},
+			wantErr: touchErrors.ErrInvalidDateTimeValues,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateAt("Feb 29 14:30", "", time.UTC, tt.now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseDateAt() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDateAt() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
+
+func TestParsePosixTimeAt(t *testing.T) {
+	now := time.Date(2031, 12, 31, 23, 0, 0, 0, time.UTC)
+
+	got, err := ParsePosixTimeAt("07131430", time.UTC, now)
+	if err != nil {
+		t.Fatalf("ParsePosixTimeAt() error = %v", err)
+	}
+
+	if want := time.Date(2031, 7, 13, 14, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ParsePosixTimeAt() = %v, want %v", got, want)
+	}
+}
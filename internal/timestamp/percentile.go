@@ -0,0 +1,103 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles selecting a percentile of the times of files matching a glob.
+package timestamp
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// maxPercentile is the highest accepted percentile, selecting the latest time.
+const maxPercentile = 100
+
+// ParsePercentile parses a percentile from 0 to 100 inclusive; fractions are allowed.
+func ParsePercentile(value string) (float64, error) {
+	percentile, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(percentile) || percentile < 0 || percentile > maxPercentile {
+		return 0, fmt.Errorf("%w: %q", errors.ErrInvalidPercentile, value)
+	}
+
+	return percentile, nil
+}
+
+// PercentileTime returns the nearest-rank percentile of times: the smallest time such that
+// at least percentile percent of times are no later than it. The 0th percentile is the
+// earliest time and the 100th the latest. Returns an error for an empty set or a
+// percentile outside 0 to 100.
+func PercentileTime(times []Time, percentile float64) (Time, error) {
+	if len(times) == 0 {
+		return Time{}, errors.ErrNoReferenceTimes
+	}
+
+	if math.IsNaN(percentile) || percentile < 0 || percentile > maxPercentile {
+		return Time{}, fmt.Errorf("%w: %v", errors.ErrInvalidPercentile, percentile)
+	}
+
+	sorted := slices.Clone(times)
+	slices.SortFunc(sorted, func(a, b Time) int { return a.Compare(b) })
+
+	rank := max(int(math.Ceil(percentile/maxPercentile*float64(len(sorted)))), 1)
+
+	return sorted[rank-1], nil
+}
+
+// GetTimeFromPercentile returns the nearest-rank percentile of the modification times of
+// the files matching pattern, as understood by filepath.Glob. If noDeref is true, matches
+// are read with Lstat. Returns an error if the pattern is malformed or matches nothing.
+func GetTimeFromPercentile(pattern string, percentile float64, noDeref bool) (Time, error) {
+	modTimes, err := globModTimes(pattern, noDeref)
+	if err != nil {
+		return Time{}, err
+	}
+
+	return PercentileTime(modTimes, percentile)
+}
+
+// globModTimes returns the modification times of the files matching pattern, as understood
+// by filepath.Glob. If noDeref is true, matches are read with Lstat. Returns an error if the
+// pattern is malformed or matches nothing.
+func globModTimes(pattern string, noDeref bool) ([]Time, error) {
+	matches, err := filesystem.Default.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("match %s: %w", pattern, err)
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s", errors.ErrNoGlobMatches, pattern)
+	}
+
+	modTimes := make([]Time, 0, len(matches))
+
+	for _, match := range matches {
+		_, modTime, err := GetTimesFromRef(match, noDeref)
+		if err != nil {
+			return nil, err
+		}
+
+		modTimes = append(modTimes, modTime)
+	}
+
+	return modTimes, nil
+}
@@ -0,0 +1,255 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles selecting a percentile of the times of files matching a glob.
+package timestamp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+func TestParsePercentile(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "zero", value: "0", want: 0, wantErr: false},
+		{name: "fraction", value: "99.9", want: 99.9, wantErr: false},
+		{name: "hundred", value: "100", want: 100, wantErr: false},
+		{name: "surrounding space", value: " 50 ", want: 50, wantErr: false},
+		{name: "negative", value: "-1", want: 0, wantErr: true},
+		{name: "above hundred", value: "100.5", want: 0, wantErr: true},
+		{name: "not a number", value: "p90", want: 0, wantErr: true},
+		{name: "NaN", value: "NaN", want: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePercentile(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, touchErrors.ErrInvalidPercentile) {
+					t.Errorf("ParsePercentile() error = %v, want %v", err, touchErrors.ErrInvalidPercentile)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParsePercentile() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParsePercentile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentileTime(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	// Ten times, listed out of order, an hour apart.
+	times := []Time{
+		base.Add(7 * time.Hour), base.Add(2 * time.Hour), base.Add(9 * time.Hour), base,
+		base.Add(4 * time.Hour), base.Add(1 * time.Hour), base.Add(8 * time.Hour),
+		base.Add(3 * time.Hour), base.Add(6 * time.Hour), base.Add(5 * time.Hour),
+	}
+
+	tests := []struct {
+		name       string
+		times      []Time
+		percentile float64
+		want       Time
+		wantErr    error
+	}{
+		{
+			name:       "0th percentile is the earliest",
+			times:      times,
+			percentile: 0,
+			want:       base,
+			wantErr:    nil,
+		},
+		{
+			name:       "50th percentile",
+			times:      times,
+			percentile: 50,
+			want:       base.Add(4 * time.Hour),
+			wantErr:    nil,
+		},
+		{
+			name:       "100th percentile is the latest",
+			times:      times,
+			percentile: 100,
+			want:       base.Add(9 * time.Hour),
+			wantErr:    nil,
+		},
+		{
+			name:       "nearest rank rounds up",
+			times:      times,
+			percentile: 91,
+			want:       base.Add(9 * time.Hour),
+			wantErr:    nil,
+		},
+		{
+			name:       "single time",
+			times:      []Time{base},
+			percentile: 50,
+			want:       base,
+			wantErr:    nil,
+		},
+		{
+			name:       "no times",
+			times:      nil,
+			percentile: 50,
+			want:       Time{},
+			wantErr:    touchErrors.ErrNoReferenceTimes,
+		},
+		{
+			name:       "invalid percentile",
+			times:      times,
+			percentile: 101,
+			want:       Time{},
+			wantErr:    touchErrors.ErrInvalidPercentile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PercentileTime(tt.times, tt.percentile)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PercentileTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("PercentileTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimeFromPercentile(t *testing.T) {
+	filesystem.Default = realFS
+
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	dir := t.TempDir()
+
+	// Five logs modified a day apart, plus a file the glob must not match.
+	for i, name := range []string{"c.log", "a.log", "e.log", "b.log", "d.log"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		modTime := base.Add(time.Duration(i) * 24 * time.Hour)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	other := filepath.Join(dir, "other.txt")
+	if err := os.WriteFile(other, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(other, base.Add(-24*time.Hour), base.Add(-24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		pattern    string
+		percentile float64
+		want       Time
+		wantErr    error
+	}{
+		{
+			name:       "0th percentile",
+			pattern:    filepath.Join(dir, "*.log"),
+			percentile: 0,
+			want:       base,
+			wantErr:    nil,
+		},
+		{
+			name:       "50th percentile",
+			pattern:    filepath.Join(dir, "*.log"),
+			percentile: 50,
+			want:       base.Add(2 * 24 * time.Hour),
+			wantErr:    nil,
+		},
+		{
+			name:       "100th percentile",
+			pattern:    filepath.Join(dir, "*.log"),
+			percentile: 100,
+			want:       base.Add(4 * 24 * time.Hour),
+			wantErr:    nil,
+		},
+		{
+			name:       "no matches",
+			pattern:    filepath.Join(dir, "*.missing"),
+			percentile: 50,
+			want:       Time{},
+			wantErr:    touchErrors.ErrNoGlobMatches,
+		},
+		{
+			name:       "malformed pattern",
+			pattern:    filepath.Join(dir, "[.log"),
+			percentile: 50,
+			want:       Time{},
+			wantErr:    filepath.ErrBadPattern,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTimeFromPercentile(tt.pattern, tt.percentile, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromPercentile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromPercentile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimeFromPercentile_memFS(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC)
+
+	// The logs exist only in memory, so they are matched only by globbing the default FS.
+	newMemFS(t, map[string]Time{
+		filepath.Join("logs", "a.log"): base,
+		filepath.Join("logs", "b.log"): base.Add(time.Hour),
+		filepath.Join("logs", "c.log"): base.Add(2 * time.Hour),
+		filepath.Join("logs", "d.txt"): base.Add(3 * time.Hour),
+	})
+
+	got, err := GetTimeFromPercentile(filepath.Join("logs", "*.log"), 100, false)
+	if err != nil {
+		t.Fatalf("GetTimeFromPercentile() error = %v", err)
+	}
+
+	if want := base.Add(2 * time.Hour); !got.Equal(want) {
+		t.Errorf("GetTimeFromPercentile() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reducing the times of multiple reference files to one.
+package timestamp
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+// Reductions accepted by ReduceTimes and GetTimesFromRefs.
+const (
+	ReduceMin    = "min"
+	ReduceMax    = "max"
+	ReduceMean   = "mean"
+	ReduceMedian = "median"
+)
+
+// medianDivisor splits a sorted set in half and averages the two middle values.
+const medianDivisor = 2
+
+// IsValidReduction reports whether reduction names a supported reduction.
+func IsValidReduction(reduction string) bool {
+	switch reduction {
+	case ReduceMin, ReduceMax, ReduceMean, ReduceMedian:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReduceTimes reduces times to a single time using the named reduction.
+// Mean and median are computed with nanosecond precision; an even-sized median
+// is the mean of the two middle values. Returns an error for an empty set or an
+// unknown reduction.
+func ReduceTimes(times []Time, reduction string) (Time, error) {
+	if len(times) == 0 {
+		return Time{}, errors.ErrNoReferenceTimes
+	}
+
+	sorted := slices.Clone(times)
+	slices.SortFunc(sorted, func(a, b Time) int { return a.Compare(b) })
+
+	switch reduction {
+	case ReduceMin:
+		return sorted[0], nil
+	case ReduceMax:
+		return sorted[len(sorted)-1], nil
+	case ReduceMean:
+		return meanTime(sorted), nil
+	case ReduceMedian:
+		mid := len(sorted) / medianDivisor
+		if len(sorted)%medianDivisor == 1 {
+			return sorted[mid], nil
+		}
+
+		return meanTime(sorted[mid-1 : mid+1]), nil
+	default:
+		return Time{}, fmt.Errorf("%w: %s", errors.ErrInvalidReduction, reduction)
+	}
+}
+
+// GetTimesFromRefs retrieves the times of each reference file and reduces the access
+// and modification times independently using the named reduction.
+// If noDeref is true, references are read with Lstat. Returns an error if any reference fails.
+// References are read one at a time; see GetTimesFromRefsJobs to read them concurrently.
+func GetTimesFromRefs(refFilePaths []string, noDeref bool, reduction string) (Time, Time, error) {
+	return GetTimesFromRefsJobs(refFilePaths, noDeref, reduction, 1)
+}
+
+// GetTimesFromRefsJobs is like GetTimesFromRefs, but reads up to jobs references at once,
+// or runtime.NumCPU when jobs is 0. Times are collected by position in refFilePaths, so the
+// reduction doesn't depend on the order reads complete in, and the error returned is always
+// that of the earliest listed failing reference. References listed after a known failure
+// are not read.
+func GetTimesFromRefsJobs(refFilePaths []string, noDeref bool, reduction string, jobs int) (Time, Time, error) {
+	if !IsValidReduction(reduction) {
+		return Time{}, Time{}, fmt.Errorf("%w: %s", errors.ErrInvalidReduction, reduction)
+	}
+
+	times, err := readRefs(refFilePaths, jobs, refTimesReader(noDeref))
+	if err != nil {
+		return Time{}, Time{}, err
+	}
+
+	accessTimes := make([]Time, len(times))
+	modTimes := make([]Time, len(times))
+
+	for i, refTime := range times {
+		accessTimes[i], modTimes[i] = refTime.access, refTime.mod
+	}
+
+	accessTime, err := ReduceTimes(accessTimes, reduction)
+	if err != nil {
+		return Time{}, Time{}, fmt.Errorf("reduce access times: %w", err)
+	}
+
+	modTime, err := ReduceTimes(modTimes, reduction)
+	if err != nil {
+		return Time{}, Time{}, fmt.Errorf("reduce modification times: %w", err)
+	}
+
+	return accessTime, modTime, nil
+}
+
+// refTimes is the access and modification time of one reference file.
+type refTimes struct {
+	access Time
+	mod    Time
+}
+
+// refTimesReader returns a reader for readRefs that retrieves a reference's times with
+// GetTimesFromRef.
+func refTimesReader(noDeref bool) func(string) (refTimes, error) {
+	return func(refFilePath string) (refTimes, error) {
+		accessTime, modTime, err := GetTimesFromRef(refFilePath, noDeref)
+
+		return refTimes{access: accessTime, mod: modTime}, err
+	}
+}
+
+// readRefs calls read for each of refFilePaths on up to jobs goroutines at once, or
+// runtime.NumCPU when jobs is 0, and returns the results by position in refFilePaths.
+// The error returned is always that of the earliest listed failing reference; references
+// listed after a known failure are not read.
+func readRefs[T any](
+	refFilePaths []string,
+	jobs int,
+	read func(refFilePath string) (T, error),
+) ([]T, error) {
+	results := make([]T, len(refFilePaths))
+	refErrs := make([]error, len(refFilePaths))
+
+	// firstFailed is the index of the earliest listed reference known to have failed.
+	var firstFailed atomic.Int64
+
+	firstFailed.Store(int64(len(refFilePaths)))
+
+	workers := jobs
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	workers = min(workers, len(refFilePaths))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range workers {
+		wg.Go(func() {
+			for index := range indexes {
+				if int64(index) > firstFailed.Load() {
+					continue
+				}
+
+				results[index], refErrs[index] = read(refFilePaths[index])
+				if refErrs[index] != nil {
+					lowerFirstFailed(&firstFailed, int64(index))
+				}
+			}
+		})
+	}
+
+	for index := range refFilePaths {
+		indexes <- index
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	if index := firstFailed.Load(); index < int64(len(refFilePaths)) {
+		return nil, refErrs[index]
+	}
+
+	return results, nil
+}
+
+// lowerFirstFailed records index as the earliest failure unless an earlier one is known.
+func lowerFirstFailed(firstFailed *atomic.Int64, index int64) {
+	for {
+		current := firstFailed.Load()
+		if index >= current || firstFailed.CompareAndSwap(current, index) {
+			return
+		}
+	}
+}
+
+// GetTimesFromNewestAtime retrieves the times of the reference file with the newest
+// access time, returning that access time together with the same file's modification time.
+// If noDeref is true, references are read with Lstat. Ties keep the earliest listed reference.
+// Up to jobs references are read at once, as with GetTimesFromRefsJobs.
+func GetTimesFromNewestAtime(refFilePaths []string, noDeref bool, jobs int) (Time, Time, error) {
+	return selectRefByAtime(refFilePaths, noDeref, jobs, Time.After)
+}
+
+// GetTimesFromOldestAtime retrieves the times of the reference file with the oldest
+// access time, i.e. the least recently used, returning that access time together with the
+// same file's modification time. If noDeref is true, references are read with Lstat.
+// Ties keep the earliest listed reference. Up to jobs references are read at once, as
+// with GetTimesFromRefsJobs.
+func GetTimesFromOldestAtime(refFilePaths []string, noDeref bool, jobs int) (Time, Time, error) {
+	return selectRefByAtime(refFilePaths, noDeref, jobs, Time.Before)
+}
+
+// GetTimeFromMaxChange returns the latest time any reference changed: the maximum of
+// each reference's modification and status change times, then the maximum across
+// references. If noDeref is true, references are read with Lstat. Returns an error
+// if any reference fails or its change time is unavailable on this platform.
+// Up to jobs references are read at once, as with GetTimesFromRefsJobs.
+func GetTimeFromMaxChange(refFilePaths []string, noDeref bool, jobs int) (Time, error) {
+	changeTimes, err := readRefs(refFilePaths, jobs, func(refFilePath string) (Time, error) {
+		fileInfo, changeTime, err := getRefCtime(refFilePath, noDeref)
+		if err != nil {
+			return Time{}, err
+		}
+
+		if modTime := fileInfo.ModTime(); modTime.After(changeTime) {
+			changeTime = modTime
+		}
+
+		return changeTime, nil
+	})
+	if err != nil {
+		return Time{}, err
+	}
+
+	return ReduceTimes(changeTimes, ReduceMax)
+}
+
+// GetTimeFromOldestCtime returns the earliest status change time across references,
+// a baseline that later metadata changes to any of them cannot move backward.
+// If noDeref is true, references are read with Lstat. Returns an error if any reference
+// fails or its change time is unavailable on this platform. Up to jobs references are
+// read at once, as with GetTimesFromRefsJobs.
+func GetTimeFromOldestCtime(refFilePaths []string, noDeref bool, jobs int) (Time, error) {
+	changeTimes, err := readRefs(refFilePaths, jobs, func(refFilePath string) (Time, error) {
+		_, changeTime, err := getRefCtime(refFilePath, noDeref)
+
+		return changeTime, err
+	})
+	if err != nil {
+		return Time{}, err
+	}
+
+	return ReduceTimes(changeTimes, ReduceMin)
+}
+
+// getRefCtime returns the file info and status change time of a reference file.
+// If noDeref is true, the reference is read with Lstat.
+func getRefCtime(refFilePath string, noDeref bool) (os.FileInfo, Time, error) {
+	var (
+		fileInfo os.FileInfo
+		err      error
+	)
+
+	if noDeref {
+		fileInfo, err = filesystem.Default.Lstat(refFilePath)
+	} else {
+		fileInfo, err = filesystem.Default.Stat(refFilePath)
+	}
+
+	if err != nil {
+		return nil, Time{}, fmt.Errorf("get file info for %s: %w", refFilePath, err)
+	}
+
+	changeTime, ok := platform.GetCtime(fileInfo)
+	if !ok {
+		return nil, Time{}, fmt.Errorf("%w: %s", errors.ErrCtimeUnavailable, refFilePath)
+	}
+
+	return fileInfo, changeTime, nil
+}
+
+// selectRefByAtime returns the times of the reference whose access time is preferred
+// over every other according to better(candidate, current), reading up to jobs at once.
+func selectRefByAtime(
+	refFilePaths []string,
+	noDeref bool,
+	jobs int,
+	better func(candidate, current Time) bool,
+) (Time, Time, error) {
+	if len(refFilePaths) == 0 {
+		return Time{}, Time{}, errors.ErrNoReferenceTimes
+	}
+
+	times, err := readRefs(refFilePaths, jobs, refTimesReader(noDeref))
+	if err != nil {
+		return Time{}, Time{}, err
+	}
+
+	selected := times[0]
+
+	for _, refTime := range times[1:] {
+		if better(refTime.access, selected.access) {
+			selected = refTime
+		}
+	}
+
+	return selected.access, selected.mod, nil
+}
+
+// meanTime returns the arithmetic mean of times, using big integers so that summing
+// many nanosecond timestamps cannot overflow.
+func meanTime(times []Time) Time {
+	sum := new(big.Int)
+	for _, t := range times {
+		sum.Add(sum, big.NewInt(t.UnixNano()))
+	}
+
+	sum.Quo(sum, big.NewInt(int64(len(times))))
+
+	return time.Unix(0, sum.Int64())
+}
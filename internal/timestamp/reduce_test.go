@@ -0,0 +1,890 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reducing the times of multiple reference files to one.
+package timestamp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+func TestReduceTimes(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	times := []Time{base.Add(4 * time.Hour), base, base.Add(time.Hour), base.Add(3 * time.Hour)}
+
+	type args struct {
+		times     []Time
+		reduction string
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		want    Time
+		wantErr bool
+	}{
+		{
+			name:    "min",
+			args:    args{times: times, reduction: ReduceMin},
+			want:    base,
+			wantErr: false,
+		},
+		{
+			name:    "max",
+			args:    args{times: times, reduction: ReduceMax},
+			want:    base.Add(4 * time.Hour),
+			wantErr: false,
+		},
+		{
+			name:    "mean",
+			args:    args{times: times, reduction: ReduceMean},
+			want:    base.Add(2 * time.Hour),
+			wantErr: false,
+		},
+		{
+			name:    "median even count",
+			args:    args{times: times, reduction: ReduceMedian},
+			want:    base.Add(2 * time.Hour),
+			wantErr: false,
+		},
+		{
+			name:    "median odd count",
+			args:    args{times: times[:3], reduction: ReduceMedian},
+			want:    base.Add(time.Hour),
+			wantErr: false,
+		},
+		{
+			name:    "empty set",
+			args:    args{times: nil, reduction: ReduceMin},
+			want:    Time{},
+			wantErr: true,
+		},
+		{
+			name:    "unknown reduction",
+			args:    args{times: times, reduction: "mode"},
+			want:    Time{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReduceTimes(tt.args.times, tt.args.reduction)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReduceTimes() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ReduceTimes() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimesFromRefs(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		reduction  string
+		mockSetup  func(*mocks.MockFS)
+		wantAccess Time
+		wantMod    Time
+		wantErr    bool
+	}{
+		{
+			name:      "max reduces access and modification independently",
+			reduction: ReduceMax,
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: base.Add(5 * time.Hour)}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{mod: base.Add(2 * time.Hour), sys: base.Add(time.Hour)}, nil)
+			},
+			wantAccess: base.Add(5 * time.Hour),
+			wantMod:    base.Add(2 * time.Hour),
+			wantErr:    false,
+		},
+		{
+			name:      "min",
+			reduction: ReduceMin,
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: base.Add(5 * time.Hour)}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{mod: base.Add(2 * time.Hour), sys: base.Add(time.Hour)}, nil)
+			},
+			wantAccess: base.Add(time.Hour),
+			wantMod:    base,
+			wantErr:    false,
+		},
+		{
+			name:      "reference error",
+			reduction: ReduceMean,
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").Return(nil, os.ErrNotExist)
+			},
+			wantAccess: Time{},
+			wantMod:    Time{},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid reduction",
+			reduction:  "sum",
+			mockSetup:  nil,
+			wantAccess: Time{},
+			wantMod:    Time{},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockFS)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+			oldGetAtime := platform.GetAtime
+
+			defer func() { platform.GetAtime = oldGetAtime }()
+
+			// The mock stores each reference's access time in Sys.
+			platform.GetAtime = func(fi os.FileInfo) Time {
+				atime, _ := fi.Sys().(Time)
+
+				return atime
+			}
+
+			got, got1, err := GetTimesFromRefs([]string{"a.txt", "b.txt"}, false, tt.reduction)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTimesFromRefs() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got.Equal(tt.wantAccess) {
+				t.Errorf("GetTimesFromRefs() got = %v, want %v", got, tt.wantAccess)
+			}
+
+			if !got1.Equal(tt.wantMod) {
+				t.Errorf("GetTimesFromRefs() got1 = %v, want %v", got1, tt.wantMod)
+			}
+		})
+	}
+}
+
+func TestGetTimesFromRefsJobs(t *testing.T) {
+	const refCount = 200
+
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	refs := make([]string, refCount)
+	for i := range refs {
+		refs[i] = fmt.Sprintf("ref%03d.txt", i)
+	}
+
+	// refIndex recovers a reference's position from its name.
+	refIndex := func(path string) int {
+		var index int
+
+		fmt.Sscanf(path, "ref%03d.txt", &index)
+
+		return index
+	}
+
+	tests := []struct {
+		name       string
+		reduction  string
+		jobs       int
+		failing    map[int]bool
+		wantAccess Time
+		wantMod    Time
+		wantErr    string
+	}{
+		{
+			name:       "mean with bounded workers",
+			reduction:  ReduceMean,
+			jobs:       4,
+			wantAccess: base.Add(-99500 * time.Millisecond),
+			wantMod:    base.Add(99500 * time.Millisecond),
+		},
+		{
+			name:       "median with one worker",
+			reduction:  ReduceMedian,
+			jobs:       1,
+			wantAccess: base.Add(-99500 * time.Millisecond),
+			wantMod:    base.Add(99500 * time.Millisecond),
+		},
+		{
+			name:       "max with workers from the number of CPUs",
+			reduction:  ReduceMax,
+			jobs:       0,
+			wantAccess: base,
+			wantMod:    base.Add((refCount - 1) * time.Second),
+		},
+		{
+			name:      "earliest listed failure is reported",
+			reduction: ReduceMin,
+			jobs:      8,
+			failing:   map[int]bool{40: true, 120: true, 180: true},
+			wantErr:   "ref040.txt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxWorkers := tt.jobs
+			if maxWorkers == 0 {
+				maxWorkers = runtime.NumCPU()
+			}
+
+			var inFlight, maxInFlight atomic.Int64
+
+			mockFS := mocks.NewMockFS(t)
+			mockFS.EXPECT().Stat(mock.Anything).RunAndReturn(func(path string) (os.FileInfo, error) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+
+				for peak := maxInFlight.Load(); current > peak; peak = maxInFlight.Load() {
+					if maxInFlight.CompareAndSwap(peak, current) {
+						break
+					}
+				}
+
+				// Later references finish sooner, so reads complete out of list order.
+				index := refIndex(path)
+				time.Sleep(time.Duration(refCount-index) * time.Microsecond)
+
+				if tt.failing[index] {
+					return nil, fmt.Errorf("%s: %w", path, os.ErrPermission)
+				}
+
+				offset := time.Duration(index) * time.Second
+
+				return &mockFileInfo{mod: base.Add(offset), sys: base.Add(-offset)}, nil
+			}).Maybe()
+
+			filesystem.Default = mockFS // Override default FS with mock.
+			oldGetAtime := platform.GetAtime
+
+			defer func() { platform.GetAtime = oldGetAtime }()
+
+			// The mock stores each reference's access time in Sys.
+			platform.GetAtime = func(fi os.FileInfo) Time {
+				atime, _ := fi.Sys().(Time)
+
+				return atime
+			}
+
+			got, got1, err := GetTimesFromRefsJobs(refs, false, tt.reduction, tt.jobs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), " "+tt.wantErr+":") {
+					t.Fatalf("GetTimesFromRefsJobs() error = %v, want one for %s", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetTimesFromRefsJobs() error = %v", err)
+			}
+
+			if !got.Equal(tt.wantAccess) {
+				t.Errorf("GetTimesFromRefsJobs() got = %v, want %v", got, tt.wantAccess)
+			}
+
+			if !got1.Equal(tt.wantMod) {
+				t.Errorf("GetTimesFromRefsJobs() got1 = %v, want %v", got1, tt.wantMod)
+			}
+
+			if peak := maxInFlight.Load(); peak > int64(maxWorkers) {
+				t.Errorf("GetTimesFromRefsJobs() read %d references at once, want at most %d", peak, maxWorkers)
+			}
+		})
+	}
+}
+
+func TestGetTimesFromNewestAtime(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		refs       []string
+		mockSetup  func(*mocks.MockFS)
+		wantAccess Time
+		wantMod    Time
+		wantErr    bool
+	}{
+		{
+			name: "newest atime source chosen with its own mtime",
+			refs: []string{"a.txt", "b.txt", "c.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base.Add(9 * time.Hour), sys: base.Add(time.Hour)}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{mod: base, sys: base.Add(6 * time.Hour)}, nil)
+				m.On("Stat", "c.txt").
+					Return(&mockFileInfo{mod: base.Add(2 * time.Hour), sys: base.Add(3 * time.Hour)}, nil)
+			},
+			wantAccess: base.Add(6 * time.Hour),
+			wantMod:    base,
+			wantErr:    false,
+		},
+		{
+			name: "reference error",
+			refs: []string{"a.txt", "missing.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: base}, nil)
+				m.On("Stat", "missing.txt").Return(nil, os.ErrNotExist)
+			},
+			wantAccess: Time{},
+			wantMod:    Time{},
+			wantErr:    true,
+		},
+		{
+			name:       "no references",
+			refs:       nil,
+			mockSetup:  nil,
+			wantAccess: Time{},
+			wantMod:    Time{},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockFS)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+			oldGetAtime := platform.GetAtime
+
+			defer func() { platform.GetAtime = oldGetAtime }()
+
+			// The mock stores each reference's access time in Sys.
+			platform.GetAtime = func(fi os.FileInfo) Time {
+				atime, _ := fi.Sys().(Time)
+
+				return atime
+			}
+
+			got, got1, err := GetTimesFromNewestAtime(tt.refs, false, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTimesFromNewestAtime() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got.Equal(tt.wantAccess) {
+				t.Errorf("GetTimesFromNewestAtime() got = %v, want %v", got, tt.wantAccess)
+			}
+
+			if !got1.Equal(tt.wantMod) {
+				t.Errorf("GetTimesFromNewestAtime() got1 = %v, want %v", got1, tt.wantMod)
+			}
+		})
+	}
+}
+
+func TestGetTimesFromOldestAtime(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name       string
+		refs       []string
+		mockSetup  func(*mocks.MockFS)
+		wantAccess Time
+		wantMod    Time
+		wantErr    bool
+	}{
+		{
+			name: "oldest atime source chosen with its own mtime",
+			refs: []string{"a.txt", "b.txt", "c.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base.Add(9 * time.Hour), sys: base.Add(time.Hour)}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{mod: base, sys: base.Add(6 * time.Hour)}, nil)
+				m.On("Stat", "c.txt").
+					Return(&mockFileInfo{mod: base.Add(2 * time.Hour), sys: base.Add(3 * time.Hour)}, nil)
+			},
+			wantAccess: base.Add(time.Hour),
+			wantMod:    base.Add(9 * time.Hour),
+			wantErr:    false,
+		},
+		{
+			name: "tie keeps the earliest listed reference",
+			refs: []string{"a.txt", "b.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base.Add(2 * time.Hour), sys: base}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{mod: base.Add(4 * time.Hour), sys: base}, nil)
+			},
+			wantAccess: base,
+			wantMod:    base.Add(2 * time.Hour),
+			wantErr:    false,
+		},
+		{
+			name: "reference error",
+			refs: []string{"a.txt", "missing.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: base}, nil)
+				m.On("Stat", "missing.txt").Return(nil, os.ErrNotExist)
+			},
+			wantAccess: Time{},
+			wantMod:    Time{},
+			wantErr:    true,
+		},
+		{
+			name:       "no references",
+			refs:       nil,
+			mockSetup:  nil,
+			wantAccess: Time{},
+			wantMod:    Time{},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockFS)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+			oldGetAtime := platform.GetAtime
+
+			defer func() { platform.GetAtime = oldGetAtime }()
+
+			// The mock stores each reference's access time in Sys.
+			platform.GetAtime = func(fi os.FileInfo) Time {
+				atime, _ := fi.Sys().(Time)
+
+				return atime
+			}
+
+			got, got1, err := GetTimesFromOldestAtime(tt.refs, false, 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTimesFromOldestAtime() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got.Equal(tt.wantAccess) {
+				t.Errorf("GetTimesFromOldestAtime() got = %v, want %v", got, tt.wantAccess)
+			}
+
+			if !got1.Equal(tt.wantMod) {
+				t.Errorf("GetTimesFromOldestAtime() got1 = %v, want %v", got1, tt.wantMod)
+			}
+		})
+	}
+}
+
+func TestGetTimeFromMaxChange(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		refs      []string
+		mockSetup func(*mocks.MockFS)
+		want      Time
+		wantErr   error
+	}{
+		{
+			name: "change time newer than modification time",
+			refs: []string{"a.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: base.Add(2 * time.Hour)}, nil)
+			},
+			want:    base.Add(2 * time.Hour),
+			wantErr: nil,
+		},
+		{
+			name: "modification time newer than change time",
+			refs: []string{"a.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base.Add(3 * time.Hour), sys: base}, nil)
+			},
+			want:    base.Add(3 * time.Hour),
+			wantErr: nil,
+		},
+		{
+			name: "maximum across references",
+			refs: []string{"a.txt", "b.txt", "c.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: base.Add(time.Hour)}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{mod: base.Add(2 * time.Hour), sys: base.Add(5 * time.Hour)}, nil)
+				m.On("Stat", "c.txt").
+					Return(&mockFileInfo{mod: base.Add(4 * time.Hour), sys: base.Add(3 * time.Hour)}, nil)
+			},
+			want:    base.Add(5 * time.Hour),
+			wantErr: nil,
+		},
+		{
+			name: "change time unavailable",
+			refs: []string{"a.txt", "b.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: base}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{mod: base, sys: nil}, nil)
+			},
+			want:    Time{},
+			wantErr: touchErrors.ErrCtimeUnavailable,
+		},
+		{
+			name: "reference error",
+			refs: []string{"missing.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "missing.txt").Return(nil, os.ErrNotExist)
+			},
+			want:    Time{},
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name:      "no references",
+			refs:      nil,
+			mockSetup: nil,
+			want:      Time{},
+			wantErr:   touchErrors.ErrNoReferenceTimes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockFS)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+			oldGetCtime := platform.GetCtime
+
+			defer func() { platform.GetCtime = oldGetCtime }()
+
+			// The mock stores each reference's change time in Sys; nil means unavailable.
+			platform.GetCtime = func(fi os.FileInfo) (Time, bool) {
+				ctime, ok := fi.Sys().(Time)
+
+				return ctime, ok
+			}
+
+			got, err := GetTimeFromMaxChange(tt.refs, false, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromMaxChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromMaxChange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimeFromMaxChange_realFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("change times are unavailable on Windows")
+	}
+
+	filesystem.Default = realFS
+
+	// Backdating the modification time updates the change time to now, so ctime wins.
+	path := filepath.Join(t.TempDir(), "ref.txt")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantCtime, ok := platform.GetCtime(fileInfo)
+	if !ok {
+		t.Fatal("GetCtime() unavailable for a regular file")
+	}
+
+	got, err := GetTimeFromMaxChange([]string{path}, false, 1)
+	if err != nil {
+		t.Fatalf("GetTimeFromMaxChange() error = %v", err)
+	}
+
+	if !got.Equal(wantCtime) || !got.After(old) {
+		t.Errorf("GetTimeFromMaxChange() = %v, want change time %v", got, wantCtime)
+	}
+}
+
+func TestGetTimeFromOldestCtime(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		refs      []string
+		mockSetup func(*mocks.MockFS)
+		want      Time
+		wantErr   error
+	}{
+		{
+			name: "minimum across references",
+			refs: []string{"a.txt", "b.txt", "c.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: base.Add(3 * time.Hour)}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{mod: base, sys: base.Add(time.Hour)}, nil)
+				m.On("Stat", "c.txt").
+					Return(&mockFileInfo{mod: base, sys: base.Add(2 * time.Hour)}, nil)
+			},
+			want:    base.Add(time.Hour),
+			wantErr: nil,
+		},
+		{
+			name: "modification time is ignored",
+			refs: []string{"a.txt", "b.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base.Add(-5 * time.Hour), sys: base.Add(2 * time.Hour)}, nil)
+				m.On("Stat", "b.txt").
+					Return(&mockFileInfo{mod: base.Add(4 * time.Hour), sys: base.Add(time.Hour)}, nil)
+			},
+			want:    base.Add(time.Hour),
+			wantErr: nil,
+		},
+		{
+			name: "change time unavailable",
+			refs: []string{"a.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: nil}, nil)
+			},
+			want:    Time{},
+			wantErr: touchErrors.ErrCtimeUnavailable,
+		},
+		{
+			name: "reference error",
+			refs: []string{"a.txt", "missing.txt"},
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "a.txt").
+					Return(&mockFileInfo{mod: base, sys: base}, nil)
+				m.On("Stat", "missing.txt").Return(nil, os.ErrNotExist)
+			},
+			want:    Time{},
+			wantErr: os.ErrNotExist,
+		},
+		{
+			name:      "no references",
+			refs:      nil,
+			mockSetup: nil,
+			want:      Time{},
+			wantErr:   touchErrors.ErrNoReferenceTimes,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockFS)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+			oldGetCtime := platform.GetCtime
+
+			defer func() { platform.GetCtime = oldGetCtime }()
+
+			// The mock stores each reference's change time in Sys; nil means unavailable.
+			platform.GetCtime = func(fi os.FileInfo) (Time, bool) {
+				ctime, ok := fi.Sys().(Time)
+
+				return ctime, ok
+			}
+
+			got, err := GetTimeFromOldestCtime(tt.refs, false, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromOldestCtime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromOldestCtime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferenceSelectionJobs(t *testing.T) {
+	const (
+		refCount = 100
+		jobs     = 4
+	)
+
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	refs := make([]string, refCount)
+	for i := range refs {
+		refs[i] = fmt.Sprintf("ref%03d.txt", i)
+	}
+
+	// Each reference's access and change times run backward as its modification time runs forward.
+	last := time.Duration(refCount-1) * time.Second
+
+	tests := []struct {
+		name    string
+		get     func(refs []string, jobs int) (Time, error)
+		failing map[int]bool
+		want    Time
+		wantErr string
+	}{
+		{
+			name: "newest access time",
+			get: func(refs []string, jobs int) (Time, error) {
+				_, modTime, err := GetTimesFromNewestAtime(refs, false, jobs)
+
+				return modTime, err
+			},
+			want: base,
+		},
+		{
+			name: "oldest access time",
+			get: func(refs []string, jobs int) (Time, error) {
+				_, modTime, err := GetTimesFromOldestAtime(refs, false, jobs)
+
+				return modTime, err
+			},
+			want: base.Add(last),
+		},
+		{
+			name: "latest change",
+			get: func(refs []string, jobs int) (Time, error) {
+				return GetTimeFromMaxChange(refs, false, jobs)
+			},
+			want: base.Add(last),
+		},
+		{
+			name: "oldest change time",
+			get: func(refs []string, jobs int) (Time, error) {
+				return GetTimeFromOldestCtime(refs, false, jobs)
+			},
+			want: base.Add(-last),
+		},
+		{
+			name: "earliest listed failure is reported",
+			get: func(refs []string, jobs int) (Time, error) {
+				return GetTimeFromOldestCtime(refs, false, jobs)
+			},
+			failing: map[int]bool{30: true, 70: true},
+			wantErr: "ref030.txt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, maxInFlight atomic.Int64
+
+			mockFS := mocks.NewMockFS(t)
+			mockFS.EXPECT().Stat(mock.Anything).RunAndReturn(func(path string) (os.FileInfo, error) {
+				current := inFlight.Add(1)
+				defer inFlight.Add(-1)
+
+				for peak := maxInFlight.Load(); current > peak; peak = maxInFlight.Load() {
+					if maxInFlight.CompareAndSwap(peak, current) {
+						break
+					}
+				}
+
+				var index int
+
+				fmt.Sscanf(path, "ref%03d.txt", &index)
+
+				// Later references finish sooner, so reads complete out of list order.
+				time.Sleep(time.Duration(refCount-index) * time.Microsecond)
+
+				if tt.failing[index] {
+					return nil, fmt.Errorf("%s: %w", path, os.ErrPermission)
+				}
+
+				offset := time.Duration(index) * time.Second
+
+				return &mockFileInfo{mod: base.Add(offset), sys: base.Add(-offset)}, nil
+			}).Maybe()
+
+			filesystem.Default = mockFS // Override default FS with mock.
+			oldGetAtime, oldGetCtime := platform.GetAtime, platform.GetCtime
+
+			defer func() { platform.GetAtime, platform.GetCtime = oldGetAtime, oldGetCtime }()
+
+			// The mock stores each reference's access and change time in Sys.
+			platform.GetAtime = func(fi os.FileInfo) Time {
+				atime, _ := fi.Sys().(Time)
+
+				return atime
+			}
+			platform.GetCtime = func(fi os.FileInfo) (Time, bool) {
+				ctime, ok := fi.Sys().(Time)
+
+				return ctime, ok
+			}
+
+			got, err := tt.get(refs, jobs)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), " "+tt.wantErr+":") {
+					t.Fatalf("error = %v, want one for %s", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			if peak := maxInFlight.Load(); peak > jobs {
+				t.Errorf("read %d references at once, want at most %d", peak, jobs)
+			}
+		})
+	}
+}
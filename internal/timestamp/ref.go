@@ -19,16 +19,22 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package timestamp
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
 	"github.com/nicholas-fedor/touch/internal/filesystem"
 	"github.com/nicholas-fedor/touch/internal/platform"
 )
 
 // GetTimesFromRef retrieves the access and modification times from a reference file.
 // If noDeref is true, it uses Lstat to avoid following symlinks.
-// Uses platform-specific GetAtime for access time; returns times or an error.
+// Uses platform-specific GetAtimeAt for access time; returns times or an error.
 func GetTimesFromRef(refFilePath string, noDeref bool) (Time, Time, error) {
 	var (
 		fileInfo os.FileInfo
@@ -46,7 +52,189 @@ func GetTimesFromRef(refFilePath string, noDeref bool) (Time, Time, error) {
 	}
 
 	modTime := fileInfo.ModTime()
-	accessTime := platform.GetAtime(fileInfo)
+	accessTime := platform.GetAtimeAt(refFilePath, fileInfo, noDeref)
 
 	return accessTime, modTime, nil
 }
+
+// GetTimesFromRefPreferBirth behaves like GetTimesFromRef, but uses the reference file's
+// birth time in place of its modification time when the platform and filesystem record one.
+// Uses platform-specific GetBtime, falling back to the modification time otherwise.
+func GetTimesFromRefPreferBirth(refFilePath string, noDeref bool) (Time, Time, error) {
+	var (
+		fileInfo os.FileInfo
+		err      error
+	)
+
+	if noDeref {
+		fileInfo, err = filesystem.Default.Lstat(refFilePath)
+	} else {
+		fileInfo, err = filesystem.Default.Stat(refFilePath)
+	}
+
+	if err != nil {
+		return Time{}, Time{}, fmt.Errorf("get file info for %s: %w", refFilePath, err)
+	}
+
+	modTime := fileInfo.ModTime()
+	if birthTime, ok := platform.GetBtime(refFilePath, fileInfo, noDeref); ok {
+		modTime = birthTime
+	}
+
+	accessTime := platform.GetAtimeAt(refFilePath, fileInfo, noDeref)
+
+	return accessTime, modTime, nil
+}
+
+// executable reports the path of the running executable, overridable in tests.
+var executable = os.Executable
+
+// GetTimeFromSelfAtime retrieves the access time of the running executable, which
+// approximates when the tool was last run. The executable is read through the
+// filesystem package and its access time with platform-specific GetAtimeAt.
+// Returns an error if the executable's path can't be determined or it can't be read.
+func GetTimeFromSelfAtime() (Time, error) {
+	path, err := executable()
+	if err != nil {
+		return Time{}, fmt.Errorf("locate executable: %w", err)
+	}
+
+	fileInfo, err := filesystem.Default.Stat(path)
+	if err != nil {
+		return Time{}, fmt.Errorf("get file info for %s: %w", path, err)
+	}
+
+	return platform.GetAtimeAt(path, fileInfo, false), nil
+}
+
+// GetTimesFromNewestUnder retrieves the access and modification times of the entry
+// with the greatest modification time anywhere below dir, found in a single walk.
+// Entries are inspected with Lstat when noDeref is true and Stat otherwise; dir itself
+// is not considered. Returns an error if the walk fails or the tree has no entries.
+func GetTimesFromNewestUnder(dir string, noDeref bool) (Time, Time, error) {
+	var (
+		newestAccess, newestMod Time
+		found                   bool
+	)
+
+	err := filesystem.Default.WalkDir(dir, func(path string, _ fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if path == dir {
+			return nil
+		}
+
+		var (
+			fileInfo os.FileInfo
+			err      error
+		)
+
+		if noDeref {
+			fileInfo, err = filesystem.Default.Lstat(path)
+		} else {
+			fileInfo, err = filesystem.Default.Stat(path)
+		}
+
+		if err != nil {
+			return fmt.Errorf("get file info for %s: %w", path, err)
+		}
+
+		if !found || fileInfo.ModTime().After(newestMod) {
+			newestMod = fileInfo.ModTime()
+			newestAccess = platform.GetAtimeAt(path, fileInfo, noDeref)
+			found = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Time{}, Time{}, fmt.Errorf("walk %s: %w", dir, err)
+	}
+
+	if !found {
+		return Time{}, Time{}, fmt.Errorf("%w: %s", touchErrors.ErrEmptyReferenceTree, dir)
+	}
+
+	return newestAccess, newestMod, nil
+}
+
+// GetTimesFromNearestAncestor retrieves the access and modification times of the nearest
+// existing directory above path, walking up one level at a time. Missing ancestors are
+// skipped; an ancestor that exists but is not a directory, or any other stat failure,
+// is returned as an error, as is reaching the root without finding an ancestor.
+func GetTimesFromNearestAncestor(path string) (Time, Time, error) {
+	dir := filepath.Dir(filepath.Clean(path))
+
+	for {
+		fileInfo, err := filesystem.Default.Stat(dir)
+
+		switch {
+		case err == nil && fileInfo.IsDir():
+			return platform.GetAtimeAt(dir, fileInfo, false), fileInfo.ModTime(), nil
+		case err == nil:
+			return Time{}, Time{}, fmt.Errorf("%w: %s is not a directory", touchErrors.ErrNoExistingAncestor, dir)
+		case !errors.Is(err, os.ErrNotExist):
+			return Time{}, Time{}, fmt.Errorf("get file info for %s: %w", dir, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return Time{}, Time{}, fmt.Errorf("%w: %s", touchErrors.ErrNoExistingAncestor, path)
+		}
+
+		dir = parent
+	}
+}
+
+// GetTimeFromMount retrieves the approximate mount time of the filesystem containing path.
+// Uses the platform-specific GetMountTime, which is only implemented on Linux.
+func GetTimeFromMount(path string) (Time, error) {
+	mountTime, err := platform.GetMountTime(path)
+	if err != nil {
+		return Time{}, fmt.Errorf("get mount time for %s: %w", path, err)
+	}
+
+	return mountTime, nil
+}
+
+// GetTimeFromBoot retrieves the approximate system boot time as now minus uptime.
+// Uses the platform-specific GetBootTime, which is only implemented on Linux.
+func GetTimeFromBoot() (Time, error) {
+	bootTime, err := platform.GetBootTime()
+	if err != nil {
+		return Time{}, fmt.Errorf("get boot time: %w", err)
+	}
+
+	return bootTime, nil
+}
+
+// GetTimeFromProcFDs retrieves the newest modification time among the regular files the
+// process pid has open. Uses the platform-specific GetProcFDsTime, which is only implemented
+// on Linux. Returns an error if pid isn't a positive integer or its open files can't be read.
+func GetTimeFromProcFDs(pid string) (Time, error) {
+	id, err := strconv.Atoi(strings.TrimSpace(pid))
+	if err != nil || id <= 0 {
+		return Time{}, fmt.Errorf("%w: %q", touchErrors.ErrInvalidPID, pid)
+	}
+
+	modTime, err := platform.GetProcFDsTime(id)
+	if err != nil {
+		return Time{}, fmt.Errorf("get open file times of process %d: %w", id, err)
+	}
+
+	return modTime, nil
+}
+
+// GetTimeFromUnit retrieves when the systemd unit last entered the active state, its
+// ActiveEnterTimestamp. A name without a unit type suffix is taken as a service. Uses the
+// platform-specific GetUnitActiveEnterTime, which is only implemented on Linux.
+func GetTimeFromUnit(unit string) (Time, error) {
+	activeTime, err := platform.GetUnitActiveEnterTime(strings.TrimSpace(unit))
+	if err != nil {
+		return Time{}, fmt.Errorf("get active time of unit %s: %w", unit, err)
+	}
+
+	return activeTime, nil
+}
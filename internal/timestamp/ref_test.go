@@ -19,15 +19,50 @@ along with this program. If not, see <http://www.gnu.org/licenses/>.
 package timestamp
 
 import (
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
 	"github.com/nicholas-fedor/touch/internal/filesystem"
 	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
 	"github.com/nicholas-fedor/touch/internal/platform"
 )
 
+// realFS holds the default filesystem, captured before any test swaps in a mock.
+var realFS = filesystem.Default
+
+// newMemFS returns a MemFS holding each of files, and the directories containing them,
+// with both times set to the given time, and makes it the default FS.
+func newMemFS(t *testing.T, files map[string]Time) *filesystem.MemFS {
+	t.Helper()
+
+	m := filesystem.NewMemFS()
+
+	for name, mod := range files {
+		if err := m.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		file, err := m.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		file.Close()
+
+		if err := m.Chtimes(name, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filesystem.Default = m // Override default FS with an in-memory one.
+
+	return m
+}
+
 // mockFileInfo is a simple mock for os.FileInfo in tests.
 type mockFileInfo struct {
 	mod Time
@@ -149,3 +184,414 @@ func TestGetTimesFromRef(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTimesFromNewestUnder(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T) string
+		wantMod Time
+		wantErr bool
+	}{
+		{
+			name: "newest file several levels deep",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				root := t.TempDir()
+				deep := filepath.Join(root, "a", "b", "c")
+
+				if err := os.MkdirAll(deep, 0o755); err != nil {
+					t.Fatal(err)
+				}
+
+				files := map[string]Time{
+					filepath.Join(root, "top.txt"):         base,
+					filepath.Join(root, "a", "mid.txt"):    base.Add(time.Hour),
+					filepath.Join(deep, "deepest.txt"):     base.Add(3 * time.Hour),
+					filepath.Join(root, "a", "b", "x.txt"): base.Add(2 * time.Hour),
+				}
+				for path, mod := range files {
+					if err := os.WriteFile(path, nil, 0o600); err != nil {
+						t.Fatal(err)
+					}
+
+					if err := os.Chtimes(path, mod, mod); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				// Keep directories older than every file so only the deep file wins.
+				for _, dir := range []string{deep, filepath.Dir(deep), filepath.Join(root, "a")} {
+					if err := os.Chtimes(dir, base, base); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				return root
+			},
+			wantMod: base.Add(3 * time.Hour),
+			wantErr: false,
+		},
+		{
+			name: "empty tree",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				return t.TempDir()
+			},
+			wantMod: Time{},
+			wantErr: true,
+		},
+		{
+			name: "missing directory",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				return filepath.Join(t.TempDir(), "missing")
+			},
+			wantMod: Time{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem.Default = realFS
+
+			_, got1, err := GetTimesFromNewestUnder(tt.setup(t), false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTimesFromNewestUnder() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got1.Equal(tt.wantMod) {
+				t.Errorf("GetTimesFromNewestUnder() got1 = %v, want %v", got1, tt.wantMod)
+			}
+		})
+	}
+}
+
+func TestGetTimesFromNewestUnder_memFS(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.UTC)
+
+	// The tree exists only in memory, so it is found only by walking the default FS.
+	m := newMemFS(t, map[string]Time{
+		filepath.Join("tree", "a.txt"):        base,
+		filepath.Join("tree", "sub", "b.txt"): base.Add(time.Hour),
+	})
+
+	for _, dir := range []string{"tree", filepath.Join("tree", "sub")} {
+		if err := m.Chtimes(dir, base, base); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, got1, err := GetTimesFromNewestUnder("tree", false)
+	if err != nil {
+		t.Fatalf("GetTimesFromNewestUnder() error = %v", err)
+	}
+
+	if want := base.Add(time.Hour); !got1.Equal(want) {
+		t.Errorf("GetTimesFromNewestUnder() got1 = %v, want %v", got1, want)
+	}
+}
+
+func TestGetTimesFromNearestAncestor(t *testing.T) {
+	ancestorTime := time.Date(2025, 7, 13, 8, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T) string
+		wantMod Time
+		wantErr bool
+	}{
+		{
+			name: "deep non-existent path",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				root := t.TempDir()
+				existing := filepath.Join(root, "existing")
+
+				if err := os.Mkdir(existing, 0o755); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.Chtimes(existing, ancestorTime, ancestorTime); err != nil {
+					t.Fatal(err)
+				}
+
+				return filepath.Join(existing, "x", "y", "z", "file.txt")
+			},
+			wantMod: ancestorTime,
+			wantErr: false,
+		},
+		{
+			name: "parent exists",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				root := t.TempDir()
+				if err := os.Chtimes(root, ancestorTime, ancestorTime); err != nil {
+					t.Fatal(err)
+				}
+
+				return filepath.Join(root, "file.txt")
+			},
+			wantMod: ancestorTime,
+			wantErr: false,
+		},
+		{
+			name: "ancestor is a regular file",
+			setup: func(t *testing.T) string {
+				t.Helper()
+
+				root := t.TempDir()
+				if err := os.Chtimes(root, ancestorTime, ancestorTime); err != nil {
+					t.Fatal(err)
+				}
+
+				blocker := filepath.Join(root, "blocker")
+				if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+
+				return filepath.Join(blocker, "file.txt")
+			},
+			wantMod: Time{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filesystem.Default = realFS
+
+			got, got1, err := GetTimesFromNearestAncestor(tt.setup(t))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTimesFromNearestAncestor() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got1.Equal(tt.wantMod) {
+				t.Errorf("GetTimesFromNearestAncestor() got1 = %v, want %v", got1, tt.wantMod)
+			}
+
+			if !tt.wantErr && got.IsZero() {
+				t.Errorf("GetTimesFromNearestAncestor() got = %v, want non-zero access time", got)
+			}
+		})
+	}
+}
+
+func TestGetTimesFromRefPreferBirth(t *testing.T) {
+	mod := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	birth := time.Date(2025, 1, 1, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		noDeref   bool
+		mockSetup func(*mocks.MockFS)
+		btime     Time
+		btimeOK   bool
+		wantMod   Time
+		wantErr   bool
+	}{
+		{
+			name:    "birth time available",
+			noDeref: false,
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "ref.txt").Return(&mockFileInfo{mod: mod}, nil)
+			},
+			btime:   birth,
+			btimeOK: true,
+			wantMod: birth,
+			wantErr: false,
+		},
+		{
+			name:    "birth time unavailable falls back to mtime",
+			noDeref: false,
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "ref.txt").Return(&mockFileInfo{mod: mod}, nil)
+			},
+			btime:   Time{},
+			btimeOK: false,
+			wantMod: mod,
+			wantErr: false,
+		},
+		{
+			name:    "no dereference uses Lstat",
+			noDeref: true,
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Lstat", "ref.txt").Return(&mockFileInfo{mod: mod}, nil)
+			},
+			btime:   birth,
+			btimeOK: true,
+			wantMod: birth,
+			wantErr: false,
+		},
+		{
+			name:    "missing reference",
+			noDeref: false,
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "ref.txt").Return(nil, os.ErrNotExist)
+			},
+			btime:   Time{},
+			btimeOK: false,
+			wantMod: Time{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			tt.mockSetup(mockFS)
+
+			filesystem.Default = mockFS // Override default FS with mock.
+			oldGetBtime := platform.GetBtime
+
+			defer func() { platform.GetBtime = oldGetBtime }()
+
+			platform.GetBtime = func(string, os.FileInfo, bool) (Time, bool) {
+				return tt.btime, tt.btimeOK
+			}
+
+			_, got1, err := GetTimesFromRefPreferBirth("ref.txt", tt.noDeref)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTimesFromRefPreferBirth() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got1.Equal(tt.wantMod) {
+				t.Errorf("GetTimesFromRefPreferBirth() got1 = %v, want %v", got1, tt.wantMod)
+			}
+		})
+	}
+}
+
+func TestGetTimeFromSelfAtime(t *testing.T) {
+	atime := time.Date(2025, 7, 13, 9, 15, 0, 0, time.Local)
+	errNoExecutable := errors.New("executable not found")
+
+	tests := []struct {
+		name       string
+		executable func() (string, error)
+		mockSetup  func(*mocks.MockFS)
+		want       Time
+		wantErr    bool
+	}{
+		{
+			name:       "executable atime",
+			executable: func() (string, error) { return "/usr/local/bin/touch", nil },
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "/usr/local/bin/touch").
+					Return(&mockFileInfo{mod: atime.Add(-time.Hour), sys: atime}, nil)
+			},
+			want:    atime,
+			wantErr: false,
+		},
+		{
+			name:       "executable path unknown",
+			executable: func() (string, error) { return "", errNoExecutable },
+			mockSetup:  nil,
+			want:       Time{},
+			wantErr:    true,
+		},
+		{
+			name:       "executable unreadable",
+			executable: func() (string, error) { return "/usr/local/bin/touch", nil },
+			mockSetup: func(m *mocks.MockFS) {
+				m.On("Stat", "/usr/local/bin/touch").Return(nil, os.ErrPermission)
+			},
+			want:    Time{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockFS)
+			}
+
+			filesystem.Default = mockFS // Override default FS with mock.
+			oldExecutable := executable
+			oldGetAtime := platform.GetAtime
+
+			defer func() {
+				executable = oldExecutable
+				platform.GetAtime = oldGetAtime
+			}()
+
+			executable = tt.executable
+
+			// The mock stores the access time in Sys.
+			platform.GetAtime = func(fi os.FileInfo) Time {
+				atime, _ := fi.Sys().(Time)
+
+				return atime
+			}
+
+			got, err := GetTimeFromSelfAtime()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTimeFromSelfAtime() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromSelfAtime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTimeFromProcFDs(t *testing.T) {
+	openTime := time.Date(2025, 7, 13, 9, 15, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		pid     string
+		wantPID int
+		want    Time
+		wantErr error
+	}{
+		{name: "process ID", pid: "4242", wantPID: 4242, want: openTime, wantErr: nil},
+		{name: "surrounding spaces", pid: " 17 ", wantPID: 17, want: openTime, wantErr: nil},
+		{name: "zero", pid: "0", wantPID: 0, want: Time{}, wantErr: touchErrors.ErrInvalidPID},
+		{name: "negative", pid: "-5", wantPID: 0, want: Time{}, wantErr: touchErrors.ErrInvalidPID},
+		{name: "not a number", pid: "self", wantPID: 0, want: Time{}, wantErr: touchErrors.ErrInvalidPID},
+		{name: "unreadable process", pid: "1", wantPID: 1, want: Time{}, wantErr: os.ErrPermission},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldGetProcFDsTime := platform.GetProcFDsTime
+
+			defer func() { platform.GetProcFDsTime = oldGetProcFDsTime }()
+
+			platform.GetProcFDsTime = func(pid int) (Time, error) {
+				if pid != tt.wantPID {
+					t.Errorf("GetProcFDsTime(%d), want PID %d", pid, tt.wantPID)
+				}
+
+				if pid == 1 {
+					return Time{}, os.ErrPermission
+				}
+
+				return openTime, nil
+			}
+
+			got, err := GetTimeFromProcFDs(tt.pid)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromProcFDs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromProcFDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reading the newest time from rsync file listings.
+package timestamp
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// rsyncListLayout is the date and time format of rsync --list-only output.
+const rsyncListLayout = "2006/01/02 15:04:05"
+
+// rsyncBannerPrefixes start the progress and summary lines rsync prints around a listing.
+var rsyncBannerPrefixes = []string{"receiving ", "sending ", "sent ", "total size "}
+
+// GetTimeFromRsyncList returns the newest modification time among the entries of an
+// rsync --list-only listing stored in path. Times are read in the local timezone, as
+// rsync prints them. Blank lines and rsync's progress and summary lines are ignored.
+// Returns an error if any other line can't be parsed or the listing has no entries.
+func GetTimeFromRsyncList(path string) (Time, error) {
+	file, err := filesystem.Default.Open(path)
+	if err != nil {
+		return Time{}, fmt.Errorf("open rsync listing %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var (
+		newest  Time
+		entries int
+		lineNum int
+	)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || isRsyncBanner(line) {
+			continue
+		}
+
+		_, modTime, err := parseRsyncListLine(line)
+		if err != nil {
+			return Time{}, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+
+		if entries == 0 || modTime.After(newest) {
+			newest = modTime
+		}
+
+		entries++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Time{}, fmt.Errorf("read rsync listing %s: %w", path, err)
+	}
+
+	if entries == 0 {
+		return Time{}, fmt.Errorf("%w: %s", errors.ErrEmptyRsyncList, path)
+	}
+
+	return newest, nil
+}
+
+// parseRsyncListLine parses one "perms size date time name" line of rsync --list-only
+// output, returning the entry's name and modification time. Sizes may carry digit
+// grouping or a human-readable suffix, and symlink names keep their " -> target" part.
+func parseRsyncListLine(line string) (string, Time, error) {
+	fields := make([]string, 0, 4)
+	rest := line
+
+	for range 4 {
+		rest = strings.TrimLeft(rest, " ")
+
+		field, remainder, found := strings.Cut(rest, " ")
+		if !found {
+			return "", Time{}, fmt.Errorf(
+				"%w: %q: expected perms, size, date, time, and name",
+				errors.ErrInvalidRsyncList,
+				line,
+			)
+		}
+
+		fields = append(fields, field)
+		rest = remainder
+	}
+
+	perms, size, date, clock := fields[0], fields[1], fields[2], fields[3]
+
+	name := strings.TrimLeft(rest, " ")
+	if name == "" {
+		return "", Time{}, fmt.Errorf("%w: %q: missing name", errors.ErrInvalidRsyncList, line)
+	}
+
+	if !isRsyncPerms(perms) {
+		return "", Time{}, fmt.Errorf(
+			"%w: %q: invalid permissions %q",
+			errors.ErrInvalidRsyncList,
+			line,
+			perms,
+		)
+	}
+
+	if !isRsyncSize(size) {
+		return "", Time{}, fmt.Errorf("%w: %q: invalid size %q", errors.ErrInvalidRsyncList, line, size)
+	}
+
+	modTime, err := time.ParseInLocation(rsyncListLayout, date+" "+clock, time.Local)
+	if err != nil {
+		return "", Time{}, fmt.Errorf(
+			"%w: %q: invalid date %q",
+			errors.ErrInvalidRsyncList,
+			line,
+			date+" "+clock,
+		)
+	}
+
+	return name, modTime, nil
+}
+
+// isRsyncBanner reports whether line is one of rsync's progress or summary lines.
+func isRsyncBanner(line string) bool {
+	for _, prefix := range rsyncBannerPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRsyncPerms reports whether perms is an ls-style permission string, such as
+// drwxr-xr-x, as printed by rsync.
+func isRsyncPerms(perms string) bool {
+	const typeChars = "-dlpscb"
+
+	if len(perms) != 10 || !strings.ContainsRune(typeChars, rune(perms[0])) {
+		return false
+	}
+
+	for _, c := range perms[1:] {
+		if !strings.ContainsRune("-rwxsStT", c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isRsyncSize reports whether size is a file size as printed by rsync: digits with
+// optional comma or dot grouping, optionally ending in a K, M, G, T, or P suffix.
+func isRsyncSize(size string) bool {
+	if last := len(size) - 1; last > 0 && strings.ContainsRune("KMGTP", rune(size[last])) {
+		size = size[:last]
+	}
+
+	if size == "" || size[0] < '0' || size[0] > '9' {
+		return false
+	}
+
+	for _, c := range size {
+		if (c < '0' || c > '9') && c != ',' && c != '.' {
+			return false
+		}
+	}
+
+	return true
+}
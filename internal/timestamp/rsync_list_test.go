@@ -0,0 +1,195 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reading the newest time from rsync file listings.
+package timestamp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// rsyncListFixture is rsync --list-only output for a small tree, framed by the progress
+// and summary lines rsync -v prints. The newest entry is a symlink in the middle.
+const rsyncListFixture = `receiving incremental file list
+drwxr-xr-x          4,096 2025/07/10 09:00:00 .
+-rw-r--r--         12,345 2025/07/11 12:30:15 notes with spaces.txt
+lrwxrwxrwx             11 2025/07/13 14:30:00 current -> releases/v2
+drwxr-xr-x          4,096 2025/07/12 08:00:00 releases
+-rwxr-xr-x          1.23M 2025/07/09 23:59:59 releases/tool
+
+sent 20 bytes  received 312 bytes  664.00 bytes/sec
+total size is 1,302,345  speedup is 3,922.72
+`
+
+func TestGetTimeFromRsyncList(t *testing.T) {
+	filesystem.Default = realFS
+
+	tests := []struct {
+		name      string
+		content   string
+		want      Time
+		wantErrIs error
+		wantErr   bool
+	}{
+		{
+			name:    "newest entry",
+			content: rsyncListFixture,
+			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:    "crlf line endings",
+			content: "-rw-r--r--  10 2025/07/13 14:30:00 a\r\n-rw-r--r--  10 2025/07/12 14:30:00 b\r\n",
+			want:    time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr: false,
+		},
+		{
+			name:      "unparseable line",
+			content:   "-rw-r--r--  10 2025/07/13 14:30:00 a\nnot an rsync listing\n",
+			want:      Time{},
+			wantErrIs: touchErrors.ErrInvalidRsyncList,
+			wantErr:   true,
+		},
+		{
+			name:      "no entries",
+			content:   "receiving incremental file list\n\n",
+			want:      Time{},
+			wantErrIs: touchErrors.ErrEmptyRsyncList,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "listing.txt")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := GetTimeFromRsyncList(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetTimeFromRsyncList() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("GetTimeFromRsyncList() error = %v, want %v", err, tt.wantErrIs)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromRsyncList() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseRsyncListLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantName string
+		want     Time
+		wantErr  bool
+	}{
+		{
+			name:     "regular file",
+			line:     "-rw-r--r--            123 2025/07/13 14:30:00 file.txt",
+			wantName: "file.txt",
+			want:     time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr:  false,
+		},
+		{
+			name:     "grouped size and spaced name",
+			line:     "-rw-r--r--      1,048,576 2025/01/02 03:04:05 my  file.bin",
+			wantName: "my  file.bin",
+			want:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local),
+			wantErr:  false,
+		},
+		{
+			name:     "symlink",
+			line:     "lrwxrwxrwx             11 2025/07/13 14:30:00 current -> releases/v2",
+			wantName: "current -> releases/v2",
+			want:     time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr:  false,
+		},
+		{
+			name:     "setuid and sticky bits",
+			line:     "drwxrwxrwt          4,096 2025/07/13 14:30:00 tmp",
+			wantName: "tmp",
+			want:     time.Date(2025, 7, 13, 14, 30, 0, 0, time.Local),
+			wantErr:  false,
+		},
+		{
+			name:    "missing name",
+			line:    "-rw-r--r--            123 2025/07/13 14:30:00",
+			wantErr: true,
+		},
+		{
+			name:    "invalid permissions",
+			line:    "rw-r--r--            123 2025/07/13 14:30:00 file.txt",
+			wantErr: true,
+		},
+		{
+			name:    "invalid size",
+			line:    "-rw-r--r--            12x 2025/07/13 14:30:00 file.txt",
+			wantErr: true,
+		},
+		{
+			name:    "invalid date",
+			line:    "-rw-r--r--            123 2025-07-13 14:30:00 file.txt",
+			wantErr: true,
+		},
+		{
+			name:    "invalid time",
+			line:    "-rw-r--r--            123 2025/07/13 25:30:00 file.txt",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotName, got, err := parseRsyncListLine(tt.line)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRsyncListLine() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if tt.wantErr {
+				if !errors.Is(err, touchErrors.ErrInvalidRsyncList) {
+					t.Errorf("parseRsyncListLine() error = %v, want %v", err, touchErrors.ErrInvalidRsyncList)
+				}
+
+				return
+			}
+
+			if gotName != tt.wantName {
+				t.Errorf("parseRsyncListLine() name = %q, want %q", gotName, tt.wantName)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("parseRsyncListLine() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles deterministic time derivation from seed strings.
+package timestamp
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// DefaultSeedWindow spans from the ZIP epoch to the last second representable by a signed
+// 32-bit time_t, so seeded times are valid in archives and on older filesystems alike.
+const DefaultSeedWindow = "1980-01-01T00:00:00Z,2038-01-19T03:14:07Z"
+
+// ParseSeedWindow parses a "START,END" window, with each bound parsed by ParseDate.
+// Returns an error unless both bounds parse and END is later than START.
+func ParseSeedWindow(window string) (Time, Time, error) {
+	return parseWindow(window, "seed", errors.ErrInvalidSeedWindow)
+}
+
+// parseWindow parses a "START,END" window named name, such as "seed", for error messages.
+// Malformed windows and windows whose END isn't later than START yield errInvalid.
+func parseWindow(window, name string, errInvalid error) (Time, Time, error) {
+	startStr, endStr, found := strings.Cut(window, ",")
+	if !found {
+		return Time{}, Time{}, fmt.Errorf("%w: %q", errInvalid, window)
+	}
+
+	start, err := ParseDate(strings.TrimSpace(startStr))
+	if err != nil {
+		return Time{}, Time{}, fmt.Errorf("parse %s window start: %w", name, err)
+	}
+
+	end, err := ParseDate(strings.TrimSpace(endStr))
+	if err != nil {
+		return Time{}, Time{}, fmt.Errorf("parse %s window end: %w", name, err)
+	}
+
+	if !end.After(start) {
+		return Time{}, Time{}, fmt.Errorf("%w: %q ends before it starts", errInvalid, window)
+	}
+
+	return start, end, nil
+}
+
+// TimeFromSeed maps seed to a time in [start, end) with whole-second resolution.
+// The first eight bytes of the seed's SHA-256 digest select the offset from start,
+// so the same seed and window always yield the same time on every platform.
+func TimeFromSeed(seed string, start, end Time) Time {
+	digest := sha256.Sum256([]byte(seed))
+	span := uint64(end.Unix() - start.Unix())
+
+	// Windows shorter than a second collapse to their start.
+	if span == 0 {
+		return start.Truncate(time.Second)
+	}
+
+	offset := binary.BigEndian.Uint64(digest[:8]) % span
+
+	return time.Unix(start.Unix()+int64(offset), 0)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles deterministic time derivation from seed strings.
+package timestamp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestParseSeedWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		window    string
+		wantStart Time
+		wantEnd   Time
+		wantErr   error
+	}{
+		{
+			name:      "default window",
+			window:    DefaultSeedWindow,
+			wantStart: time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "spaces around bounds",
+			window:    "2020-01-01T00:00:00Z , 2021-01-01T00:00:00Z",
+			wantStart: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "missing end",
+			window:    "2020-01-01T00:00:00Z",
+			wantStart: Time{},
+			wantEnd:   Time{},
+			wantErr:   touchErrors.ErrInvalidSeedWindow,
+		},
+		{
+			name:      "end before start",
+			window:    "2021-01-01T00:00:00Z,2020-01-01T00:00:00Z",
+			wantStart: Time{},
+			wantEnd:   Time{},
+			wantErr:   touchErrors.ErrInvalidSeedWindow,
+		},
+		{
+			name:      "unparsable bound",
+			window:    "soon,later",
+			wantStart: Time{},
+			wantEnd:   Time{},
+			wantErr:   touchErrors.ErrUnsupportedDateFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := ParseSeedWindow(tt.window)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseSeedWindow() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got.Equal(tt.wantStart) {
+				t.Errorf("ParseSeedWindow() got = %v, want %v", got, tt.wantStart)
+			}
+
+			if !got1.Equal(tt.wantEnd) {
+				t.Errorf("ParseSeedWindow() got1 = %v, want %v", got1, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestTimeFromSeed(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := TimeFromSeed("project-a", start, end)
+	if again := TimeFromSeed("project-a", start, end); !again.Equal(first) {
+		t.Errorf("TimeFromSeed() not deterministic: %v then %v", first, again)
+	}
+
+	seen := map[int64]string{}
+
+	for _, seed := range []string{"project-a", "project-b", "project-c", ""} {
+		got := TimeFromSeed(seed, start, end)
+		if got.Before(start) || !got.Before(end) {
+			t.Errorf("TimeFromSeed(%q) = %v, want within [%v, %v)", seed, got, start, end)
+		}
+
+		if got.Nanosecond() != 0 {
+			t.Errorf("TimeFromSeed(%q) = %v, want whole seconds", seed, got)
+		}
+
+		if other, ok := seen[got.Unix()]; ok {
+			t.Errorf("TimeFromSeed(%q) = %v, same as seed %q", seed, got, other)
+		}
+
+		seen[got.Unix()] = seed
+	}
+
+	// A known mapping keeps seeded times stable across releases.
+	if want := time.Date(2020, 9, 18, 11, 23, 32, 0, time.UTC); !first.Equal(want) {
+		t.Errorf("TimeFromSeed(%q) = %v, want %v", "project-a", first, want)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles time retrieval from per-file .time sidecars.
+package timestamp
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// GetTimeFromSidecar reads the RFC3339 time stored in a sidecar file.
+// Surrounding whitespace is ignored. A missing sidecar yields an error wrapping
+// os.ErrNotExist; unparsable contents yield an error wrapping ErrInvalidSidecar.
+func GetTimeFromSidecar(path string) (Time, error) {
+	file, err := filesystem.Default.Open(path)
+	if err != nil {
+		return Time{}, fmt.Errorf("read sidecar %s: %w", path, err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return Time{}, fmt.Errorf("read sidecar %s: %w", path, err)
+	}
+
+	value := strings.TrimSpace(string(data))
+
+	sidecarTime, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return Time{}, fmt.Errorf("%w: %s: %q", errors.ErrInvalidSidecar, path, value)
+	}
+
+	return sidecarTime, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles time retrieval from per-file .time sidecars.
+package timestamp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+func TestGetTimeFromSidecar(t *testing.T) {
+	filesystem.Default = realFS
+
+	tests := []struct {
+		name     string
+		contents *string
+		want     Time
+		wantErr  error
+	}{
+		{
+			name:     "present",
+			contents: func() *string { s := "2025-07-13T14:30:00Z\n"; return &s }(),
+			want:     time.Date(2025, 7, 13, 14, 30, 0, 0, time.UTC),
+			wantErr:  nil,
+		},
+		{
+			name:     "fractional seconds and offset",
+			contents: func() *string { s := "2025-07-13T14:30:00.5+02:00"; return &s }(),
+			want:     time.Date(2025, 7, 13, 12, 30, 0, 500000000, time.UTC),
+			wantErr:  nil,
+		},
+		{
+			name:     "absent",
+			contents: nil,
+			want:     Time{},
+			wantErr:  os.ErrNotExist,
+		},
+		{
+			name:     "malformed",
+			contents: func() *string { s := "yesterday"; return &s }(),
+			want:     Time{},
+			wantErr:  touchErrors.ErrInvalidSidecar,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "file.txt.time")
+			if tt.contents != nil {
+				if err := os.WriteFile(path, []byte(*tt.contents), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := GetTimeFromSidecar(path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetTimeFromSidecar() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromSidecar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles deterministic time derivation from file sizes.
+package timestamp
+
+import (
+	"math/bits"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// ParseSizeWindow parses a "START,END" window for size-mapped times, with each bound
+// parsed by ParseDate. Returns an error unless both bounds parse and END is later than START.
+func ParseSizeWindow(window string) (Time, Time, error) {
+	return parseWindow(window, "size", errors.ErrInvalidSizeWindow)
+}
+
+// TimeFromSize maps size linearly into [start, end]: a size of 0 yields start and a size of
+// maxSize or more yields end. Negative sizes count as 0, and maxSize must be positive.
+func TimeFromSize(size, maxSize int64, start, end Time) Time {
+	size = min(max(size, 0), maxSize)
+
+	// span * size / maxSize never exceeds span, but the product needs 128 bits.
+	span := uint64(end.Sub(start))
+	hi, lo := bits.Mul64(span, uint64(size))
+	offset, _ := bits.Div64(hi, lo, uint64(maxSize))
+
+	return start.Add(time.Duration(offset))
+}
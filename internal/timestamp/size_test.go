@@ -0,0 +1,103 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles deterministic time derivation from file sizes.
+package timestamp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestParseSizeWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		window    string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   error
+	}{
+		{
+			name:      "valid window",
+			window:    "2020-01-01T00:00:00Z, 2021-01-01T00:00:00Z",
+			wantStart: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantEnd:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:    "missing comma",
+			window:  "2020-01-01T00:00:00Z",
+			wantErr: touchErrors.ErrInvalidSizeWindow,
+		},
+		{
+			name:    "ends before it starts",
+			window:  "2021-01-01T00:00:00Z,2020-01-01T00:00:00Z",
+			wantErr: touchErrors.ErrInvalidSizeWindow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := ParseSizeWindow(tt.window)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseSizeWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("ParseSizeWindow() = %v, %v, want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestTimeFromSize(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		size    int64
+		maxSize int64
+		start   time.Time
+		end     time.Time
+		want    time.Time
+	}{
+		{name: "empty file at window start", size: 0, maxSize: 1000, start: start, end: end, want: start},
+		{name: "max size at window end", size: 1000, maxSize: 1000, start: start, end: end, want: end},
+		{name: "larger than max clamped to end", size: 5000, maxSize: 1000, start: start, end: end, want: end},
+		{name: "negative clamped to start", size: -1, maxSize: 1000, start: start, end: end, want: start},
+		{name: "half way", size: 500, maxSize: 1000, start: start, end: end, want: start.Add(5 * time.Hour)},
+		{name: "one tenth", size: 100, maxSize: 1000, start: start, end: end, want: start.Add(time.Hour)},
+		{
+			name:    "wide window and large sizes don't overflow",
+			size:    1 << 62,
+			maxSize: 1<<63 - 1,
+			start:   time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:     time.Date(2038, 1, 19, 3, 14, 7, 0, time.UTC),
+			want:    time.Date(2009, 1, 9, 13, 37, 3, 500000000, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TimeFromSize(tt.size, tt.maxSize, tt.start, tt.end); !got.Equal(tt.want) {
+				t.Errorf("TimeFromSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
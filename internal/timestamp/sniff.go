@@ -0,0 +1,115 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reference times chosen by sniffed content type.
+package timestamp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// sniffLen is the number of leading bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// GetTimesFromNewestType retrieves the access and modification times of the regular file
+// with the greatest modification time anywhere below dir whose content, sniffed with
+// http.DetectContentType, has the media type contentType. Parameters such as charset are
+// ignored on both sides. Returns an error if the walk or a read fails, or nothing matches.
+func GetTimesFromNewestType(dir, contentType string) (Time, Time, error) {
+	want := mediaType(contentType)
+
+	var (
+		newestAccess, newestMod Time
+		found                   bool
+	)
+
+	err := filesystem.Default.WalkDir(dir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		// Directories, symlinks, and special files have no content to sniff.
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		detected, err := sniffContentType(path)
+		if err != nil {
+			return err
+		}
+
+		if mediaType(detected) != want {
+			return nil
+		}
+
+		accessTime, modTime, err := GetTimesFromRef(path, false)
+		if err != nil {
+			return err
+		}
+
+		if !found || modTime.After(newestMod) {
+			newestAccess = accessTime
+			newestMod = modTime
+			found = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Time{}, Time{}, fmt.Errorf("walk %s: %w", dir, err)
+	}
+
+	if !found {
+		return Time{}, Time{}, fmt.Errorf("%w: %s under %s", touchErrors.ErrNoContentTypeMatches, want, dir)
+	}
+
+	return newestAccess, newestMod, nil
+}
+
+// sniffContentType returns the content type of the file at path, detected from its first
+// sniffLen bytes.
+func sniffContentType(path string) (string, error) {
+	file, err := filesystem.Default.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	buf := make([]byte, sniffLen)
+
+	n, err := io.ReadFull(file, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// mediaType returns the lowercase media type of a content type, without parameters.
+func mediaType(contentType string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
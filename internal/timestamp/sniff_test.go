@@ -0,0 +1,220 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reference times chosen by sniffed content type.
+package timestamp
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+	"github.com/nicholas-fedor/touch/internal/filesystem/mocks"
+	"github.com/nicholas-fedor/touch/internal/platform"
+)
+
+// mockDirEntry is a minimal fs.DirEntry for driving mocked walks.
+type mockDirEntry struct {
+	name string
+	mode fs.FileMode
+}
+
+func (m mockDirEntry) Name() string               { return m.name }
+func (m mockDirEntry) IsDir() bool                { return m.mode.IsDir() }
+func (m mockDirEntry) Type() fs.FileMode          { return m.mode.Type() }
+func (m mockDirEntry) Info() (fs.FileInfo, error) { return nil, fs.ErrInvalid }
+
+// Leading bytes of each sniffed file type.
+var (
+	jpegContent = []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+	pngContent  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	textContent = []byte("shopping list\n")
+)
+
+func TestGetTimesFromNewestType(t *testing.T) {
+	base := time.Date(2025, 7, 13, 12, 0, 0, 0, time.Local)
+	fixtures := t.TempDir()
+
+	// openFixture returns a fresh handle on a file holding content, as FS.Open would.
+	openFixture := func(t *testing.T, content []byte) *os.File {
+		t.Helper()
+
+		file, err := os.CreateTemp(fixtures, "fixture-*")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := file.Write(content); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+
+		return file
+	}
+
+	// walkMedia mocks a walk of media/ holding two JPEGs, a PNG, a text file,
+	// a subdirectory, and a symlink that must not be opened.
+	walkMedia := func(m *mocks.MockFS) {
+		m.On("WalkDir", "media", mock.Anything).
+			Return(func(_ string, fn fs.WalkDirFunc) error {
+				entries := []struct {
+					path string
+					mode fs.FileMode
+				}{
+					{path: "media", mode: fs.ModeDir},
+					{path: "media/a.jpg", mode: 0},
+					{path: "media/b.png", mode: 0},
+					{path: "media/sub", mode: fs.ModeDir},
+					{path: "media/sub/c.jpg", mode: 0},
+					{path: "media/notes.txt", mode: 0},
+					{path: "media/link.jpg", mode: fs.ModeSymlink},
+				}
+
+				for _, entry := range entries {
+					if err := fn(entry.path, mockDirEntry{name: filepath.Base(entry.path), mode: entry.mode}, nil); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		mockSetup   func(*testing.T, *mocks.MockFS)
+		want        Time
+		wantErr     error
+	}{
+		{
+			name:        "newest jpeg",
+			contentType: "image/jpeg",
+			mockSetup: func(t *testing.T, m *mocks.MockFS) {
+				t.Helper()
+				walkMedia(m)
+				m.On("Open", "media/a.jpg").Return(openFixture(t, jpegContent), nil)
+				m.On("Open", "media/b.png").Return(openFixture(t, pngContent), nil)
+				m.On("Open", "media/sub/c.jpg").Return(openFixture(t, jpegContent), nil)
+				m.On("Open", "media/notes.txt").Return(openFixture(t, textContent), nil)
+				m.On("Stat", "media/a.jpg").Return(mockFileInfo{mod: base.Add(2 * time.Hour)}, nil)
+				m.On("Stat", "media/sub/c.jpg").Return(mockFileInfo{mod: base.Add(time.Hour)}, nil)
+			},
+			want:    base.Add(2 * time.Hour),
+			wantErr: nil,
+		},
+		{
+			name:        "only png",
+			contentType: "image/png",
+			mockSetup: func(t *testing.T, m *mocks.MockFS) {
+				t.Helper()
+				walkMedia(m)
+				m.On("Open", "media/a.jpg").Return(openFixture(t, jpegContent), nil)
+				m.On("Open", "media/b.png").Return(openFixture(t, pngContent), nil)
+				m.On("Open", "media/sub/c.jpg").Return(openFixture(t, jpegContent), nil)
+				m.On("Open", "media/notes.txt").Return(openFixture(t, textContent), nil)
+				m.On("Stat", "media/b.png").Return(mockFileInfo{mod: base}, nil)
+			},
+			want:    base,
+			wantErr: nil,
+		},
+		{
+			name:        "parameters and case ignored",
+			contentType: "Text/Plain; charset=latin1",
+			mockSetup: func(t *testing.T, m *mocks.MockFS) {
+				t.Helper()
+				walkMedia(m)
+				m.On("Open", "media/a.jpg").Return(openFixture(t, jpegContent), nil)
+				m.On("Open", "media/b.png").Return(openFixture(t, pngContent), nil)
+				m.On("Open", "media/sub/c.jpg").Return(openFixture(t, jpegContent), nil)
+				m.On("Open", "media/notes.txt").Return(openFixture(t, textContent), nil)
+				m.On("Stat", "media/notes.txt").Return(mockFileInfo{mod: base.Add(3 * time.Hour)}, nil)
+			},
+			want:    base.Add(3 * time.Hour),
+			wantErr: nil,
+		},
+		{
+			name:        "no matches",
+			contentType: "video/mp4",
+			mockSetup: func(t *testing.T, m *mocks.MockFS) {
+				t.Helper()
+				walkMedia(m)
+				m.On("Open", "media/a.jpg").Return(openFixture(t, jpegContent), nil)
+				m.On("Open", "media/b.png").Return(openFixture(t, pngContent), nil)
+				m.On("Open", "media/sub/c.jpg").Return(openFixture(t, jpegContent), nil)
+				m.On("Open", "media/notes.txt").Return(openFixture(t, textContent), nil)
+			},
+			want:    Time{},
+			wantErr: touchErrors.ErrNoContentTypeMatches,
+		},
+		{
+			name:        "open error",
+			contentType: "image/jpeg",
+			mockSetup: func(t *testing.T, m *mocks.MockFS) {
+				t.Helper()
+				walkMedia(m)
+				m.On("Open", "media/a.jpg").Return(nil, os.ErrPermission)
+			},
+			want:    Time{},
+			wantErr: os.ErrPermission,
+		},
+		{
+			name:        "walk error",
+			contentType: "image/jpeg",
+			mockSetup: func(t *testing.T, m *mocks.MockFS) {
+				t.Helper()
+				m.On("WalkDir", "media", mock.Anything).Return(os.ErrNotExist)
+			},
+			want:    Time{},
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFS := mocks.NewMockFS(t)
+			tt.mockSetup(t, mockFS)
+
+			filesystem.Default = mockFS
+
+			oldGetAtime := platform.GetAtime
+
+			defer func() { platform.GetAtime = oldGetAtime }()
+
+			platform.GetAtime = func(fi os.FileInfo) Time { return fi.ModTime() }
+
+			gotAccess, gotMod, err := GetTimesFromNewestType("media", tt.contentType)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimesFromNewestType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !gotMod.Equal(tt.want) || !gotAccess.Equal(tt.want) {
+				t.Errorf("GetTimesFromNewestType() = %v, %v, want %v", gotAccess, gotMod, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reference times read from remote files over SSH.
+package timestamp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// DefaultSSHTimeout bounds connecting to and querying a remote reference host.
+const DefaultSSHTimeout = 10 * time.Second
+
+// remoteStatFields is the number of fields printed by the remote stat command.
+const remoteStatFields = 2
+
+// RemoteStatter retrieves the access and modification times of a file on a remote host.
+// dest is an SSH destination such as "user@host"; path is the file on that host.
+type RemoteStatter interface {
+	StatTimes(dest, path string) (Time, Time, error)
+}
+
+// sshTransport is the RemoteStatter used by GetTimesFromSSH, overridable in tests.
+var sshTransport RemoteStatter = sshCommandStatter{timeout: DefaultSSHTimeout}
+
+// GetTimesFromSSH retrieves the access and modification times of a remote reference
+// given as "[user@]host:path", such as "deploy@build01:/srv/app/VERSION".
+// Returns an error if the reference is malformed or the remote file can't be read.
+func GetTimesFromSSH(ref string) (Time, Time, error) {
+	dest, path, err := ParseSSHReference(ref)
+	if err != nil {
+		return Time{}, Time{}, err
+	}
+
+	accessTime, modTime, err := sshTransport.StatTimes(dest, path)
+	if err != nil {
+		return Time{}, Time{}, fmt.Errorf("stat %s on %s: %w", path, dest, err)
+	}
+
+	return accessTime, modTime, nil
+}
+
+// ParseSSHReference splits a "[user@]host:path" reference into its SSH destination and
+// remote path. IPv6 hosts are written in brackets, as in "user@[::1]:/path".
+func ParseSSHReference(ref string) (string, string, error) {
+	sep := strings.Index(ref, ":")
+	if sep < 0 {
+		return "", "", fmt.Errorf("%w: %q", errors.ErrInvalidSSHReference, ref)
+	}
+
+	// The host follows the user name, if any, which ends at the last "@" before the first colon.
+	hostStart := strings.LastIndex(ref[:sep], "@") + 1
+
+	// Skip past a bracketed IPv6 host so its colons aren't taken as the separator. Only a
+	// bracket opening the host counts; brackets in the path are part of the file name.
+	if strings.HasPrefix(ref[hostStart:], "[") {
+		closing := strings.Index(ref[hostStart:], "]")
+		if closing < 0 {
+			return "", "", fmt.Errorf("%w: %q", errors.ErrInvalidSSHReference, ref)
+		}
+
+		hostEnd := hostStart + closing
+
+		next := strings.Index(ref[hostEnd:], ":")
+		if next < 0 {
+			return "", "", fmt.Errorf("%w: %q", errors.ErrInvalidSSHReference, ref)
+		}
+
+		sep = hostEnd + next
+	}
+
+	dest, path := ref[:sep], ref[sep+1:]
+
+	host := dest[strings.LastIndex(dest, "@")+1:]
+	if host == "" || host == "[]" || path == "" {
+		return "", "", fmt.Errorf("%w: %q", errors.ErrInvalidSSHReference, ref)
+	}
+
+	return strings.NewReplacer("[", "", "]", "").Replace(dest), path, nil
+}
+
+// sshCommandStatter implements RemoteStatter with the system ssh client, running
+// GNU stat on the remote host. BatchMode keeps ssh from prompting for credentials.
+type sshCommandStatter struct {
+	timeout time.Duration
+}
+
+// StatTimes runs "stat -c '%X %Y'" for path on dest and parses the epoch seconds.
+func (s sshCommandStatter) StatTimes(dest, path string) (Time, Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	//nolint:gosec // The destination and quoted path come from the user's --reference-ssh value.
+	cmd := exec.CommandContext(
+		ctx,
+		"ssh",
+		"-o", "BatchMode=yes",
+		"-o", fmt.Sprintf("ConnectTimeout=%d", int(s.timeout.Seconds())),
+		"--",
+		dest,
+		"stat -c '%X %Y' -- "+posixQuote(path),
+	)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return Time{}, Time{}, fmt.Errorf("ssh timed out after %v: %w", s.timeout, ctx.Err())
+		}
+
+		return Time{}, Time{}, fmt.Errorf("ssh: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseRemoteStat(stdout.String())
+}
+
+// parseRemoteStat parses "ATIME MTIME" epoch seconds as printed by stat -c '%X %Y'.
+func parseRemoteStat(output string) (Time, Time, error) {
+	fields := strings.Fields(output)
+	if len(fields) != remoteStatFields {
+		return Time{}, Time{}, fmt.Errorf("%w: %q", errors.ErrInvalidRemoteStat, output)
+	}
+
+	seconds := make([]int64, len(fields))
+
+	for i, field := range fields {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return Time{}, Time{}, fmt.Errorf("%w: %q", errors.ErrInvalidRemoteStat, output)
+		}
+
+		seconds[i] = value
+	}
+
+	return time.Unix(seconds[0], 0), time.Unix(seconds[1], 0), nil
+}
+
+// posixQuote quotes s as a single word for a POSIX shell on the remote host.
+func posixQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,195 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reference times read from remote files over SSH.
+package timestamp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// fakeRemoteStatter is a RemoteStatter returning canned times for one remote file.
+type fakeRemoteStatter struct {
+	dest, path   string
+	atime, mtime Time
+	err          error
+}
+
+func (f *fakeRemoteStatter) StatTimes(dest, path string) (Time, Time, error) {
+	f.dest, f.path = dest, path
+
+	return f.atime, f.mtime, f.err
+}
+
+func TestGetTimesFromSSH(t *testing.T) {
+	atime := time.Unix(1752400000, 0)
+	mtime := time.Unix(1752300000, 0)
+	errRefused := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		ref       string
+		remoteErr error
+		wantDest  string
+		wantPath  string
+		wantErr   error
+	}{
+		{
+			name:      "user host and absolute path",
+			ref:       "deploy@build01:/srv/app/VERSION",
+			remoteErr: nil,
+			wantDest:  "deploy@build01",
+			wantPath:  "/srv/app/VERSION",
+			wantErr:   nil,
+		},
+		{
+			name:      "host and relative path",
+			ref:       "build01:app/VERSION",
+			remoteErr: nil,
+			wantDest:  "build01",
+			wantPath:  "app/VERSION",
+			wantErr:   nil,
+		},
+		{
+			name:      "bracketed IPv6 host",
+			ref:       "deploy@[::1]:/srv/a:b",
+			remoteErr: nil,
+			wantDest:  "deploy@::1",
+			wantPath:  "/srv/a:b",
+			wantErr:   nil,
+		},
+		{
+			name:      "bracket in path",
+			ref:       "build01:/a[1]/f",
+			remoteErr: nil,
+			wantDest:  "build01",
+			wantPath:  "/a[1]/f",
+			wantErr:   nil,
+		},
+		{
+			name:      "bracketed IPv6 host and bracket in path",
+			ref:       "[::1]:/a[1]/f",
+			remoteErr: nil,
+			wantDest:  "::1",
+			wantPath:  "/a[1]/f",
+			wantErr:   nil,
+		},
+		{
+			name:    "unclosed IPv6 host",
+			ref:     "deploy@[::1:/srv/app/VERSION",
+			wantErr: touchErrors.ErrInvalidSSHReference,
+		},
+		{
+			name:      "transport failure",
+			ref:       "build01:/srv/app/VERSION",
+			remoteErr: errRefused,
+			wantDest:  "build01",
+			wantPath:  "/srv/app/VERSION",
+			wantErr:   errRefused,
+		},
+		{
+			name:    "missing path",
+			ref:     "build01:",
+			wantErr: touchErrors.ErrInvalidSSHReference,
+		},
+		{
+			name:    "missing host",
+			ref:     "deploy@:/srv/app/VERSION",
+			wantErr: touchErrors.ErrInvalidSSHReference,
+		},
+		{
+			name:    "local path",
+			ref:     "/srv/app/VERSION",
+			wantErr: touchErrors.ErrInvalidSSHReference,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldTransport := sshTransport
+
+			defer func() { sshTransport = oldTransport }()
+
+			fake := &fakeRemoteStatter{atime: atime, mtime: mtime, err: tt.remoteErr}
+			sshTransport = fake
+
+			got, got1, err := GetTimesFromSSH(tt.ref)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetTimesFromSSH() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if fake.dest != tt.wantDest || fake.path != tt.wantPath {
+				t.Errorf("GetTimesFromSSH() stat %q on %q, want %q on %q", fake.path, fake.dest, tt.wantPath, tt.wantDest)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !got.Equal(atime) || !got1.Equal(mtime) {
+				t.Errorf("GetTimesFromSSH() = %v, %v, want %v, %v", got, got1, atime, mtime)
+			}
+		})
+	}
+}
+
+func Test_parseRemoteStat(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		wantAtime Time
+		wantMtime Time
+		wantErr   bool
+	}{
+		{
+			name:      "access and modification seconds",
+			output:    "1752400000 1752300000\n",
+			wantAtime: time.Unix(1752400000, 0),
+			wantMtime: time.Unix(1752300000, 0),
+			wantErr:   false,
+		},
+		{
+			name:    "single field",
+			output:  "1752400000",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			output:  "stat: cannot stat 'x': No such file or directory",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := parseRemoteStat(tt.output)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRemoteStat() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if !got.Equal(tt.wantAtime) || !got1.Equal(tt.wantMtime) {
+				t.Errorf("parseRemoteStat() = %v, %v, want %v, %v", got, got1, tt.wantAtime, tt.wantMtime)
+			}
+		})
+	}
+}
@@ -0,0 +1,330 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles --stat-time expressions that compute times from file stats.
+package timestamp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// StatVars holds the file stats a StatExpr can reference.
+type StatVars struct {
+	Size  int64 // Size in bytes, referenced as size.
+	Mode  int64 // Permission bits, such as 0o644, referenced as mode.
+	Lines int64 // Number of newline characters in the content, referenced as lines.
+}
+
+// statVariables maps the names usable in a StatExpr to the StatVars field they read.
+var statVariables = map[string]func(StatVars) int64{
+	"size":  func(vars StatVars) int64 { return vars.Size },
+	"mode":  func(vars StatVars) int64 { return vars.Mode },
+	"lines": func(vars StatVars) int64 { return vars.Lines },
+}
+
+// statNode is a parsed StatExpr subexpression, evaluated against a file's stats.
+type statNode func(vars StatVars) (int64, error)
+
+// StatExpr is a parsed --stat-time expression mapping a file's stats to epoch seconds.
+// It supports integer literals (decimal, or prefixed 0x, 0o, or 0b), the variables size,
+// mode, and lines, the operators + - * / % with the usual precedence, unary + and -, and
+// parentheses. Arithmetic is on 64-bit integers; overflow and division by zero are errors.
+type StatExpr struct {
+	root       statNode
+	usesLines  bool   // Whether lines is referenced, so content only needs reading when it is.
+	expression string // Source text, for error messages.
+}
+
+// statParser is a recursive descent parser over the tokens of a StatExpr.
+type statParser struct {
+	expression string
+	tokens     []string
+	pos        int
+	usesLines  bool
+}
+
+// ParseStatExpr parses and validates a --stat-time expression such as "1700000000 + size".
+func ParseStatExpr(expression string) (*StatExpr, error) {
+	tokens, err := tokenizeStatExpr(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &statParser{expression: expression, tokens: tokens}
+
+	root, err := parser.parseSum()
+	if err != nil {
+		return nil, err
+	}
+
+	if parser.pos < len(tokens) {
+		return nil, parser.fail(fmt.Sprintf("unexpected %q", tokens[parser.pos]))
+	}
+
+	return &StatExpr{root: root, usesLines: parser.usesLines, expression: expression}, nil
+}
+
+// UsesLines reports whether the expression references lines, which requires reading content.
+func (e *StatExpr) UsesLines() bool {
+	return e.usesLines
+}
+
+// Eval evaluates the expression for a file with the given stats, returning epoch seconds.
+func (e *StatExpr) Eval(vars StatVars) (int64, error) {
+	seconds, err := e.root(vars)
+	if err != nil {
+		return 0, fmt.Errorf("evaluate %q: %w", e.expression, err)
+	}
+
+	return seconds, nil
+}
+
+// tokenizeStatExpr splits an expression into numbers, names, operators, and parentheses.
+func tokenizeStatExpr(expression string) ([]string, error) {
+	var tokens []string
+
+	for i := 0; i < len(expression); {
+		char := rune(expression[i])
+
+		switch {
+		case unicode.IsSpace(char):
+			i++
+		case strings.ContainsRune("+-*/%()", char):
+			tokens = append(tokens, string(char))
+			i++
+		case isStatWordChar(char):
+			start := i
+			for i < len(expression) && isStatWordChar(rune(expression[i])) {
+				i++
+			}
+
+			tokens = append(tokens, expression[start:i])
+		default:
+			return nil, fmt.Errorf("%w: %q: unexpected %q", errors.ErrInvalidStatTime, expression, char)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty expression", errors.ErrInvalidStatTime)
+	}
+
+	return tokens, nil
+}
+
+// isStatWordChar reports whether char can be part of a number or variable name.
+func isStatWordChar(char rune) bool {
+	return char == '_' || char < unicode.MaxASCII && (unicode.IsLetter(char) || unicode.IsDigit(char))
+}
+
+// fail returns an error describing why the expression is invalid.
+func (p *statParser) fail(reason string) error {
+	return fmt.Errorf("%w: %q: %s", errors.ErrInvalidStatTime, p.expression, reason)
+}
+
+// peek returns the next token, or "" at the end of the expression.
+func (p *statParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+// parseSum parses terms joined by + and -.
+func (p *statParser) parseSum() (statNode, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+
+	for op := p.peek(); op == "+" || op == "-"; op = p.peek() {
+		p.pos++
+
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryStatNode(left, right, statOperators[op])
+	}
+
+	return left, nil
+}
+
+// parseProduct parses factors joined by *, /, and %.
+func (p *statParser) parseProduct() (statNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	for op := p.peek(); op == "*" || op == "/" || op == "%"; op = p.peek() {
+		p.pos++
+
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryStatNode(left, right, statOperators[op])
+	}
+
+	return left, nil
+}
+
+// parseFactor parses a number, a variable, a parenthesized expression, or a signed factor.
+func (p *statParser) parseFactor() (statNode, error) {
+	token := p.peek()
+	if token == "" {
+		return nil, p.fail("unexpected end of expression")
+	}
+
+	p.pos++
+
+	switch token {
+	case "+":
+		return p.parseFactor()
+	case "-":
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+
+		return binaryStatNode(constStatNode(0), operand, subtractStat), nil
+	case "(":
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ")" {
+			return nil, p.fail("missing )")
+		}
+
+		p.pos++
+
+		return inner, nil
+	}
+
+	if variable, ok := statVariables[token]; ok {
+		p.usesLines = p.usesLines || token == "lines"
+
+		return func(vars StatVars) (int64, error) { return variable(vars), nil }, nil
+	}
+
+	value, err := strconv.ParseInt(token, 0, 64)
+	if err != nil {
+		return nil, p.fail(fmt.Sprintf("unknown number or variable %q", token))
+	}
+
+	return constStatNode(value), nil
+}
+
+// constStatNode returns a node evaluating to value.
+func constStatNode(value int64) statNode {
+	return func(StatVars) (int64, error) { return value, nil }
+}
+
+// binaryStatNode returns a node applying op to the values of left and right.
+func binaryStatNode(left, right statNode, op func(a, b int64) (int64, error)) statNode {
+	return func(vars StatVars) (int64, error) {
+		a, err := left(vars)
+		if err != nil {
+			return 0, err
+		}
+
+		b, err := right(vars)
+		if err != nil {
+			return 0, err
+		}
+
+		return op(a, b)
+	}
+}
+
+// statOperators maps the binary operators of a StatExpr to their checked implementations.
+var statOperators = map[string]func(a, b int64) (int64, error){
+	"+": addStat,
+	"-": subtractStat,
+	"*": multiplyStat,
+	"/": divideStat,
+	"%": remainderStat,
+}
+
+// addStat returns a + b, or an error if the sum overflows.
+func addStat(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, fmt.Errorf("%w: %d + %d overflows", errors.ErrStatTimeArithmetic, a, b)
+	}
+
+	return a + b, nil
+}
+
+// subtractStat returns a - b, or an error if the difference overflows.
+func subtractStat(a, b int64) (int64, error) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, fmt.Errorf("%w: %d - %d overflows", errors.ErrStatTimeArithmetic, a, b)
+	}
+
+	return a - b, nil
+}
+
+// multiplyStat returns a * b, or an error if the product overflows.
+func multiplyStat(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, fmt.Errorf("%w: %d * %d overflows", errors.ErrStatTimeArithmetic, a, b)
+	}
+
+	return product, nil
+}
+
+// divideStat returns a / b truncated toward zero, or an error for division by zero or overflow.
+func divideStat(a, b int64) (int64, error) {
+	if b == 0 {
+		return 0, fmt.Errorf("%w: division by zero", errors.ErrStatTimeArithmetic)
+	}
+
+	if a == math.MinInt64 && b == -1 {
+		return 0, fmt.Errorf("%w: %d / %d overflows", errors.ErrStatTimeArithmetic, a, b)
+	}
+
+	return a / b, nil
+}
+
+// remainderStat returns a % b with the sign of a, or an error for division by zero.
+func remainderStat(a, b int64) (int64, error) {
+	if b == 0 {
+		return 0, fmt.Errorf("%w: division by zero", errors.ErrStatTimeArithmetic)
+	}
+
+	if b == -1 {
+		return 0, nil // Avoids the MinInt64 % -1 overflow.
+	}
+
+	return a % b, nil
+}
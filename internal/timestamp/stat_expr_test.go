@@ -0,0 +1,131 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles --stat-time expressions that compute times from file stats.
+package timestamp
+
+import (
+	"errors"
+	"testing"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestParseStatExpr(t *testing.T) {
+	vars := StatVars{Size: 1234, Mode: 0o644, Lines: 10}
+
+	tests := []struct {
+		name          string
+		expression    string
+		want          int64
+		wantUsesLines bool
+		wantErr       error
+	}{
+		{
+			name:       "offset by size",
+			expression: "1700000000 + size",
+			want:       1700001234,
+		},
+		{
+			name:       "precedence",
+			expression: "1700000000 + size * 60 - mode",
+			want:       1700000000 + 1234*60 - 0o644,
+		},
+		{
+			name:       "parentheses and unary minus",
+			expression: "-(size - 1700000000) / 2",
+			want:       (1700000000 - 1234) / 2,
+		},
+		{
+			name:       "octal mode and remainder",
+			expression: "1700000000 + mode % 0o100",
+			want:       1700000000 + 0o44,
+		},
+		{
+			name:          "lines",
+			expression:    "1700000000+lines*86400",
+			want:          1700000000 + 10*86400,
+			wantUsesLines: true,
+		},
+		{
+			name:       "empty",
+			expression: "  ",
+			wantErr:    touchErrors.ErrInvalidStatTime,
+		},
+		{
+			name:       "unknown variable",
+			expression: "1700000000 + inode",
+			wantErr:    touchErrors.ErrInvalidStatTime,
+		},
+		{
+			name:       "unbalanced parenthesis",
+			expression: "(1700000000 + size",
+			wantErr:    touchErrors.ErrInvalidStatTime,
+		},
+		{
+			name:       "dangling operator",
+			expression: "1700000000 +",
+			wantErr:    touchErrors.ErrInvalidStatTime,
+		},
+		{
+			name:       "missing operator",
+			expression: "1700000000 size",
+			wantErr:    touchErrors.ErrInvalidStatTime,
+		},
+		{
+			name:       "unsupported character",
+			expression: "1700000000 ^ size",
+			wantErr:    touchErrors.ErrInvalidStatTime,
+		},
+		{
+			name:       "literal out of range",
+			expression: "99999999999999999999",
+			wantErr:    touchErrors.ErrInvalidStatTime,
+		},
+		{
+			name:       "division by zero",
+			expression: "size / (mode - 0o644)",
+			wantErr:    touchErrors.ErrStatTimeArithmetic,
+		},
+		{
+			name:       "overflow",
+			expression: "9223372036854775807 + size",
+			wantErr:    touchErrors.ErrStatTimeArithmetic,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseStatExpr(tt.expression)
+			if err == nil {
+				if expr.UsesLines() != tt.wantUsesLines {
+					t.Errorf("UsesLines() = %v, want %v", expr.UsesLines(), tt.wantUsesLines)
+				}
+
+				var got int64
+
+				got, err = expr.Eval(vars)
+				if err == nil && got != tt.want {
+					t.Errorf("Eval() = %d, want %d", got, tt.want)
+				}
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseStatExpr() and Eval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles rounding times down to coarse calendar units.
+package timestamp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// Units accepted by TruncateTime.
+const (
+	TruncateDay    = "day"
+	TruncateHour   = "hour"
+	TruncateMinute = "minute"
+)
+
+// IsValidTruncation reports whether unit names a supported truncation unit.
+func IsValidTruncation(unit string) bool {
+	switch unit {
+	case TruncateDay, TruncateHour, TruncateMinute:
+		return true
+	default:
+		return false
+	}
+}
+
+// TruncateTime rounds t down to the start of its day, hour, or minute as seen on the wall
+// clock of t's own location. Unlike time.Time.Truncate, which works from UTC, this keeps
+// local midnight and hour boundaries in zones with non-hour offsets. Returns an error for
+// an unknown unit.
+func TruncateTime(t Time, unit string) (Time, error) {
+	year, month, day := t.Date()
+	loc := t.Location()
+
+	switch unit {
+	case TruncateDay:
+		return time.Date(year, month, day, 0, 0, 0, 0, loc), nil
+	case TruncateHour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, loc), nil
+	case TruncateMinute:
+		return time.Date(year, month, day, t.Hour(), t.Minute(), 0, 0, loc), nil
+	default:
+		return Time{}, fmt.Errorf("%w: %s", errors.ErrInvalidTruncation, unit)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles rounding times down to coarse calendar units.
+package timestamp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestTruncateTime(t *testing.T) {
+	// Offsets that aren't whole hours expose truncation done in UTC rather than locally.
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	chatham := time.FixedZone("CHAST", -(12*60*60 + 45*60))
+
+	tests := []struct {
+		name    string
+		t       Time
+		unit    string
+		want    Time
+		wantErr error
+	}{
+		{
+			name:    "day in a half-hour zone",
+			t:       time.Date(2025, 7, 13, 3, 10, 20, 123, kolkata),
+			unit:    TruncateDay,
+			want:    time.Date(2025, 7, 13, 0, 0, 0, 0, kolkata),
+			wantErr: nil,
+		},
+		{
+			name:    "hour in a half-hour zone",
+			t:       time.Date(2025, 7, 13, 14, 10, 20, 123, kolkata),
+			unit:    TruncateHour,
+			want:    time.Date(2025, 7, 13, 14, 0, 0, 0, kolkata),
+			wantErr: nil,
+		},
+		{
+			name:    "minute in a quarter-hour zone",
+			t:       time.Date(2025, 7, 13, 23, 59, 59, 999999999, chatham),
+			unit:    TruncateMinute,
+			want:    time.Date(2025, 7, 13, 23, 59, 0, 0, chatham),
+			wantErr: nil,
+		},
+		{
+			name:    "day just after local midnight",
+			t:       time.Date(2025, 7, 13, 0, 5, 0, 0, chatham),
+			unit:    TruncateDay,
+			want:    time.Date(2025, 7, 13, 0, 0, 0, 0, chatham),
+			wantErr: nil,
+		},
+		{
+			name:    "unknown unit",
+			t:       time.Date(2025, 7, 13, 14, 10, 20, 0, kolkata),
+			unit:    "week",
+			want:    Time{},
+			wantErr: touchErrors.ErrInvalidTruncation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TruncateTime(tt.t, tt.unit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TruncateTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("TruncateTime() = %v, want %v", got, tt.want)
+			}
+
+			if tt.wantErr == nil && got.Location() != tt.t.Location() {
+				t.Errorf("TruncateTime() location = %v, want %v", got.Location(), tt.t.Location())
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles time retrieval from time-ordered UUID file names.
+package timestamp
+
+import (
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// Constants for the layout of canonical 8-4-4-4-12 UUID strings and their embedded times.
+const (
+	uuidLen           = 36
+	uuidVersionOffset = 6
+	uuidVariantOffset = 8
+	uuidVariantMask   = 0xC0
+	uuidVariantRFC    = 0x80
+	uuidTimeHighMask  = 0x0F
+	uuidVersion1      = 1
+	uuidVersion7      = 7
+	gregorianToUnix   = 0x01B21DD213814000 // 100ns intervals from 1582-10-15, the v1 epoch, to 1970-01-01.
+	intervalsPerSec   = 10_000_000
+	nanosPerInterval  = 100
+)
+
+// GetTimeFromUUIDName extracts the time embedded in a path whose base name, ignoring any
+// extensions, is a canonical UUIDv1 or UUIDv7. Other names yield an error wrapping ErrNotTimeUUID.
+func GetTimeFromUUIDName(path string) (Time, error) {
+	name, _, _ := strings.Cut(filepath.Base(path), ".")
+
+	return TimeFromUUID(name)
+}
+
+// TimeFromUUID extracts the time embedded in a canonical UUIDv1 or UUIDv7 string: the 60-bit
+// count of 100ns intervals since 1582-10-15 for v1, or the 48-bit Unix milliseconds for v7.
+func TimeFromUUID(value string) (Time, error) {
+	uuid, err := parseUUID(value)
+	if err != nil {
+		return Time{}, err
+	}
+
+	switch uuid[uuidVersionOffset] >> 4 {
+	case uuidVersion1:
+		// time_low, time_mid, and the low 12 bits of time_hi_and_version, most significant last.
+		intervals := int64(uuid[uuidVersionOffset]&uuidTimeHighMask)<<56 | int64(uuid[7])<<48 |
+			int64(uuid[4])<<40 | int64(uuid[5])<<32 |
+			int64(uuid[0])<<24 | int64(uuid[1])<<16 | int64(uuid[2])<<8 | int64(uuid[3])
+		intervals -= gregorianToUnix
+
+		return time.Unix(intervals/intervalsPerSec, intervals%intervalsPerSec*nanosPerInterval), nil
+	case uuidVersion7:
+		millis := int64(uuid[0])<<40 | int64(uuid[1])<<32 | int64(uuid[2])<<24 |
+			int64(uuid[3])<<16 | int64(uuid[4])<<8 | int64(uuid[5])
+
+		return time.UnixMilli(millis), nil
+	default:
+		return Time{}, fmt.Errorf("%w: %s", errors.ErrNotTimeUUID, value)
+	}
+}
+
+// parseUUID decodes a canonical, case-insensitive 8-4-4-4-12 hex UUID with the RFC 9562 variant.
+func parseUUID(value string) ([16]byte, error) {
+	var uuid [16]byte
+
+	if len(value) != uuidLen || value[8] != '-' || value[13] != '-' || value[18] != '-' || value[23] != '-' {
+		return uuid, fmt.Errorf("%w: %s", errors.ErrNotTimeUUID, value)
+	}
+
+	if _, err := hex.Decode(uuid[:], []byte(strings.ReplaceAll(value, "-", ""))); err != nil {
+		return uuid, fmt.Errorf("%w: %s", errors.ErrNotTimeUUID, value)
+	}
+
+	if uuid[uuidVariantOffset]&uuidVariantMask != uuidVariantRFC {
+		return uuid, fmt.Errorf("%w: %s", errors.ErrNotTimeUUID, value)
+	}
+
+	return uuid, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles time retrieval from time-ordered UUID file names.
+package timestamp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestGetTimeFromUUIDName(t *testing.T) {
+	// The RFC 9562 example UUIDs, both generated at 2022-02-22 19:22:22 UTC.
+	exampleTime := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		path    string
+		want    time.Time
+		wantErr error
+	}{
+		{
+			name:    "v1",
+			path:    "/data/c232ab00-9414-11ec-b3c8-9f6bdeced846",
+			want:    exampleTime,
+			wantErr: nil,
+		},
+		{
+			name:    "v1 with sub-second intervals",
+			path:    "c232ab01-9414-11ec-b3c8-9f6bdeced846",
+			want:    exampleTime.Add(100 * time.Nanosecond),
+			wantErr: nil,
+		},
+		{
+			name:    "v7 upper case with extension",
+			path:    "logs/017F22E2-79B0-7CC3-98C4-DC0C0C07398F.json",
+			want:    exampleTime,
+			wantErr: nil,
+		},
+		{
+			name:    "v7 with double extension",
+			path:    "017f22e2-79b0-7cc3-98c4-dc0c0c07398f.tar.gz",
+			want:    exampleTime,
+			wantErr: nil,
+		},
+		{
+			name:    "v4 has no time",
+			path:    "919108f7-52d1-4320-9bac-f847db4148a8",
+			want:    time.Time{},
+			wantErr: touchErrors.ErrNotTimeUUID,
+		},
+		{
+			name:    "non-RFC variant",
+			path:    "017f22e2-79b0-7cc3-c8c4-dc0c0c07398f",
+			want:    time.Time{},
+			wantErr: touchErrors.ErrNotTimeUUID,
+		},
+		{
+			name:    "misplaced hyphen",
+			path:    "017f22e-279b0-7cc3-98c4-dc0c0c07398f",
+			want:    time.Time{},
+			wantErr: touchErrors.ErrNotTimeUUID,
+		},
+		{
+			name:    "not hex",
+			path:    "017f22e2-79b0-7cc3-98c4-dc0c0c07398g",
+			want:    time.Time{},
+			wantErr: touchErrors.ErrNotTimeUUID,
+		},
+		{
+			name:    "prefixed name",
+			path:    "backup-017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+			want:    time.Time{},
+			wantErr: touchErrors.ErrNotTimeUUID,
+		},
+		{
+			name:    "ordinary name",
+			path:    "notes.txt",
+			want:    time.Time{},
+			wantErr: touchErrors.ErrNotTimeUUID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTimeFromUUIDName(tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromUUIDName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromUUIDName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reading record dates from WARC web archives.
+package timestamp
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// warcVersionPrefix starts the first line of every WARC record.
+const warcVersionPrefix = "WARC/"
+
+// gzipMagic starts gzip streams, such as the per-record members of a .warc.gz file.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// GetTimeFromWARC returns the WARC-Date of the first response or resource record in the
+// WARC file archive whose WARC-Target-URI is targetURI. Gzip-compressed archives are
+// detected and read transparently. Returns an error if the archive is malformed or has
+// no such record.
+func GetTimeFromWARC(archive, targetURI string) (Time, error) {
+	file, err := filesystem.Default.Open(archive)
+	if err != nil {
+		return Time{}, fmt.Errorf("open WARC %s: %w", archive, err)
+	}
+	defer file.Close()
+
+	buffered := bufio.NewReader(file)
+
+	var reader *bufio.Reader
+
+	if magic, _ := buffered.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return Time{}, fmt.Errorf("decompress WARC %s: %w", archive, err)
+		}
+		defer gzipReader.Close()
+
+		reader = bufio.NewReader(gzipReader)
+	} else {
+		reader = buffered
+	}
+
+	for {
+		headers, err := readWARCHeaders(reader)
+		if errors.Is(err, io.EOF) {
+			return Time{}, fmt.Errorf("%w: %s in %s", touchErrors.ErrWARCRecordNotFound, targetURI, archive)
+		}
+
+		if err != nil {
+			return Time{}, fmt.Errorf("read WARC %s: %w", archive, err)
+		}
+
+		recordType := strings.ToLower(headers["warc-type"])
+		uri := strings.Trim(headers["warc-target-uri"], "<>")
+
+		if uri == targetURI && (recordType == "response" || recordType == "resource") {
+			date, err := time.Parse(time.RFC3339Nano, headers["warc-date"])
+			if err != nil {
+				return Time{}, fmt.Errorf("%w: WARC-Date %q: %w", touchErrors.ErrInvalidWARC, headers["warc-date"], err)
+			}
+
+			return date, nil
+		}
+
+		// Skip the record's content block; the separator that follows is skipped
+		// with the blank lines before the next record.
+		length, err := strconv.ParseInt(headers["content-length"], 10, 64)
+		if err != nil || length < 0 {
+			return Time{}, fmt.Errorf("%w: Content-Length %q", touchErrors.ErrInvalidWARC, headers["content-length"])
+		}
+
+		if _, err := io.CopyN(io.Discard, reader, length); err != nil {
+			return Time{}, fmt.Errorf("%w: truncated record: %w", touchErrors.ErrInvalidWARC, err)
+		}
+	}
+}
+
+// readWARCHeaders reads the version line and named fields of the next WARC record,
+// returning the fields keyed by lowercase name. Blank lines before the record are
+// skipped. Returns io.EOF when no records remain.
+func readWARCHeaders(reader *bufio.Reader) (map[string]string, error) {
+	var line string
+
+	for line == "" {
+		var err error
+
+		line, err = reader.ReadString('\n')
+		if errors.Is(err, io.EOF) && strings.TrimSpace(line) == "" {
+			return nil, io.EOF
+		}
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		line = strings.TrimSpace(line)
+	}
+
+	if !strings.HasPrefix(line, warcVersionPrefix) {
+		return nil, fmt.Errorf("%w: want %s version line, got %q", touchErrors.ErrInvalidWARC, warcVersionPrefix, line)
+	}
+
+	headers := make(map[string]string)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("%w: truncated header: %w", touchErrors.ErrInvalidWARC, err)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			return headers, nil
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			return nil, fmt.Errorf("%w: header line %q", touchErrors.ErrInvalidWARC, line)
+		}
+
+		headers[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(value)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles reading record dates from WARC web archives.
+package timestamp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+	"github.com/nicholas-fedor/touch/internal/filesystem"
+)
+
+// warcRecord formats a single WARC/1.1 record with the given headers and content block.
+func warcRecord(recordType, targetURI, date, block string) string {
+	return fmt.Sprintf(
+		"WARC/1.1\r\nWARC-Type: %s\r\nWARC-Target-URI: %s\r\nWARC-Date: %s\r\n"+
+			"Content-Type: application/http\r\nContent-Length: %d\r\n\r\n%s\r\n\r\n",
+		recordType, targetURI, date, len(block), block,
+	)
+}
+
+// warcFixture is a tiny archive: a warcinfo record, then a request and response for one
+// page, a response for an image, and a resource record in WARC 1.0 angle-bracket form.
+// Blocks contain blank lines and WARC-like text that must be skipped as content.
+var warcFixture = "WARC/1.1\r\nWARC-Type: warcinfo\r\nWARC-Date: 2024-01-01T00:00:00Z\r\n" +
+	"Content-Length: 22\r\n\r\nsoftware: test-crawler\r\n\r\n" +
+	warcRecord("request", "https://example.com/", "2024-03-05T10:00:00Z",
+		"GET / HTTP/1.1\r\nHost: example.com\r\n\r\n") +
+	warcRecord("response", "https://example.com/", "2024-03-05T10:00:01Z",
+		"HTTP/1.1 200 OK\r\n\r\nWARC/1.1\r\nWARC-Type: response\r\n\r\n<html></html>") +
+	warcRecord("response", "https://example.com/logo.png", "2024-03-05T10:00:02.5Z",
+		"HTTP/1.1 200 OK\r\n\r\n\x89PNG") +
+	warcRecord("resource", "<https://example.com/robots.txt>", "2024-03-06T08:30:00Z",
+		"User-agent: *\n")
+
+func TestGetTimeFromWARC(t *testing.T) {
+	filesystem.Default = realFS
+
+	dir := t.TempDir()
+
+	plain := filepath.Join(dir, "crawl.warc")
+	if err := os.WriteFile(plain, []byte(warcFixture), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Compress each record as its own gzip member, as .warc.gz files do.
+	var compressed bytes.Buffer
+
+	for _, record := range []string{warcRecord("request", "https://example.com/", "2024-03-05T10:00:00Z", "GET /"), warcFixture} {
+		writer := gzip.NewWriter(&compressed)
+		if _, err := writer.Write([]byte(record)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gzipped := filepath.Join(dir, "crawl.warc.gz")
+	if err := os.WriteFile(gzipped, compressed.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	badDate := filepath.Join(dir, "bad-date.warc")
+	if err := os.WriteFile(badDate, []byte(warcRecord("response", "https://example.com/", "yesterday", "")), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	notWARC := filepath.Join(dir, "not.warc")
+	if err := os.WriteFile(notWARC, []byte("<html></html>\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Cut the archive part way through the first response's content block.
+	truncated := filepath.Join(dir, "truncated.warc")
+	if err := os.WriteFile(truncated, []byte(warcFixture[:strings.Index(warcFixture, "<html>")]), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		archive   string
+		targetURI string
+		want      Time
+		wantErr   error
+	}{
+		{
+			name:      "response after its request",
+			archive:   plain,
+			targetURI: "https://example.com/",
+			want:      time.Date(2024, 3, 5, 10, 0, 1, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "fractional seconds",
+			archive:   plain,
+			targetURI: "https://example.com/logo.png",
+			want:      time.Date(2024, 3, 5, 10, 0, 2, 500000000, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "resource with bracketed target",
+			archive:   plain,
+			targetURI: "https://example.com/robots.txt",
+			want:      time.Date(2024, 3, 6, 8, 30, 0, 0, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "gzip members",
+			archive:   gzipped,
+			targetURI: "https://example.com/logo.png",
+			want:      time.Date(2024, 3, 5, 10, 0, 2, 500000000, time.UTC),
+			wantErr:   nil,
+		},
+		{
+			name:      "missing record",
+			archive:   plain,
+			targetURI: "https://example.com/missing",
+			want:      Time{},
+			wantErr:   touchErrors.ErrWARCRecordNotFound,
+		},
+		{
+			name:      "invalid date",
+			archive:   badDate,
+			targetURI: "https://example.com/",
+			want:      Time{},
+			wantErr:   touchErrors.ErrInvalidWARC,
+		},
+		{
+			name:      "not a WARC file",
+			archive:   notWARC,
+			targetURI: "https://example.com/",
+			want:      Time{},
+			wantErr:   touchErrors.ErrInvalidWARC,
+		},
+		{
+			name:      "truncated archive",
+			archive:   truncated,
+			targetURI: "https://example.com/robots.txt",
+			want:      Time{},
+			wantErr:   touchErrors.ErrInvalidWARC,
+		},
+		{
+			name:      "missing archive",
+			archive:   filepath.Join(dir, "missing.warc"),
+			targetURI: "https://example.com/",
+			want:      Time{},
+			wantErr:   os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTimeFromWARC(tt.archive, tt.targetURI)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetTimeFromWARC() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("GetTimeFromWARC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles ISO 8601 week-date parsing.
+package timestamp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nicholas-fedor/touch/internal/errors"
+)
+
+// Bounds of ISO 8601 week dates.
+const (
+	minISOWeek    = 1
+	isoWeekAnchor = 4  // January 4th always falls in week 1, which holds the year's first Thursday.
+	isoLastAnchor = 28 // December 28th always falls in the year's last week.
+)
+
+// weekDatePrefixPattern matches strings that look like week dates, such as "2025-W", so
+// malformed ones are reported as such rather than tried against the other layouts.
+var weekDatePrefixPattern = regexp.MustCompile(`^\d{4}-W`)
+
+// weekDatePattern matches ISO 8601 extended week dates YYYY-Www[-D], such as "2025-W28-1".
+var weekDatePattern = regexp.MustCompile(`^(\d{4})-W(\d{2})(?:-([1-7]))?$`)
+
+// parseWeekDate resolves an ISO 8601 week date YYYY-Www[-D] to midnight in loc, with weekday
+// D from 1 (Monday) to 7 (Sunday) defaulting to Monday. Week 1 is the week holding the year's
+// first Thursday, so its first days may fall in the previous Gregorian year, and the last
+// week's final days in the next. It reports false when dateStr doesn't look like a week
+// date, and an error when it does but the week or weekday is out of range.
+func parseWeekDate(dateStr string, loc *time.Location) (Time, bool, error) {
+	trimmed := strings.TrimSpace(dateStr)
+	if !weekDatePrefixPattern.MatchString(trimmed) {
+		return Time{}, false, nil
+	}
+
+	match := weekDatePattern.FindStringSubmatch(trimmed)
+	if match == nil {
+		return Time{}, true, fmt.Errorf("%w: invalid week date %q", errors.ErrUnsupportedDateFormat, dateStr)
+	}
+
+	year, _ := strconv.Atoi(match[1]) // Four digits, so it can't fail.
+	week, _ := strconv.Atoi(match[2])
+
+	weekday := 1
+	if match[3] != "" {
+		weekday, _ = strconv.Atoi(match[3])
+	}
+
+	if week < minISOWeek || week > isoWeeksInYear(year) {
+		return Time{}, true, fmt.Errorf(
+			"%w: week date %q: %d has no week %d",
+			errors.ErrUnsupportedDateFormat,
+			dateStr,
+			year,
+			week,
+		)
+	}
+
+	// Step back from January 4th to the Monday starting week 1.
+	anchor := time.Date(year, time.January, isoWeekAnchor, 0, 0, 0, 0, loc)
+	anchorWeekday := (int(anchor.Weekday())+daysPerWeek-1)%daysPerWeek + 1
+
+	day := isoWeekAnchor - (anchorWeekday - 1) + (week-1)*daysPerWeek + (weekday - 1)
+
+	return time.Date(year, time.January, day, 0, 0, 0, 0, loc), true, nil
+}
+
+// isoWeeksInYear returns the number of ISO 8601 weeks in year, 52 or 53.
+func isoWeeksInYear(year int) int {
+	_, week := time.Date(year, time.December, isoLastAnchor, 0, 0, 0, 0, time.UTC).ISOWeek()
+
+	return week
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2025 Nicholas Fedor <nick@nickfedor.com>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program. If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package timestamp handles ISO 8601 week-date parsing.
+package timestamp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	touchErrors "github.com/nicholas-fedor/touch/internal/errors"
+)
+
+func TestParseDate_weekDates(t *testing.T) {
+	tests := []struct {
+		name      string
+		dateStr   string
+		want      Time
+		wantErrIs error
+	}{
+		{
+			name:    "monday of week 28",
+			dateStr: "2025-W28-1",
+			want:    time.Date(2025, 7, 7, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:    "sunday of week 28",
+			dateStr: "2025-W28-7",
+			want:    time.Date(2025, 7, 13, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:    "weekday defaults to monday",
+			dateStr: "2025-W28",
+			want:    time.Date(2025, 7, 7, 0, 0, 0, 0, time.Local),
+		},
+		{
+			// 2025 starts on a Wednesday, so week 1 starts in late December 2024.
+			name:    "week 1 starting in previous year",
+			dateStr: "2025-W01-1",
+			want:    time.Date(2024, 12, 30, 0, 0, 0, 0, time.Local),
+		},
+		{
+			// 2021 starts on a Friday, so January 1st to 3rd belong to 2020's week 53.
+			name:    "week 1 after a year start in week 53",
+			dateStr: "2021-W01",
+			want:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:    "week 53 spilling into next year",
+			dateStr: "2020-W53-5",
+			want:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.Local),
+		},
+		{
+			// 2009 starts on a Thursday, so week 1 starts on December 29th, 2008.
+			name:    "week 1 starting three days early",
+			dateStr: "2009-W01-3",
+			want:    time.Date(2008, 12, 31, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:      "week 53 in a 52-week year",
+			dateStr:   "2025-W53-1",
+			wantErrIs: touchErrors.ErrUnsupportedDateFormat,
+		},
+		{
+			name:      "week 0",
+			dateStr:   "2025-W00-1",
+			wantErrIs: touchErrors.ErrUnsupportedDateFormat,
+		},
+		{
+			name:      "weekday out of range",
+			dateStr:   "2025-W28-8",
+			wantErrIs: touchErrors.ErrUnsupportedDateFormat,
+		},
+		{
+			name:      "single digit week",
+			dateStr:   "2025-W7",
+			wantErrIs: touchErrors.ErrUnsupportedDateFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.dateStr)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("ParseDate(%q) error = %v, want %v", tt.dateStr, err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error = %v", tt.dateStr, err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.dateStr, got, tt.want)
+			}
+
+			// The result must be the requested ISO week, whatever its Gregorian year.
+			if year, week := got.ISOWeek(); fmt.Sprintf("%04d-W%02d", year, week) != tt.dateStr[:8] {
+				t.Errorf("ParseDate(%q) = %v, in ISO week %d-W%02d", tt.dateStr, got, year, week)
+			}
+		})
+	}
+}
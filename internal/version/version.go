@@ -26,9 +26,9 @@ var (
 
 // Info holds version information for the CLI.
 type Info struct {
-	Version string
-	Commit  string
-	Date    string
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
 }
 
 // GetVersionInfo returns version information, using debug.ReadBuildInfo for source builds